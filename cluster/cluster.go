@@ -2,6 +2,7 @@ package cluster
 
 import (
 	"log/slog"
+	"sync/atomic"
 	"time"
 
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -16,16 +17,21 @@ import (
 var extensionID = extensions.NextExtensionID()
 
 type Cluster struct {
-	ActorSystem    *actor.ActorSystem
-	Config         *Config
-	Gossip         *Gossiper
-	PubSub         *PubSub
-	Remote         *remote.Remote
-	PidCache       *PidCacheValue
-	MemberList     *MemberList
-	IdentityLookup IdentityLookup
-	kinds          map[string]*ActivatedKind
-	context        Context
+	ActorSystem        *actor.ActorSystem
+	Config             *Config
+	Gossip             *Gossiper
+	PubSub             *PubSub
+	Singletons         *Singletons
+	LeaderElection     *LeaderElection
+	DistributedData    *DistributedData
+	SplitBrainResolver *SplitBrainResolver
+	Remote             *remote.Remote
+	PidCache           *PidCacheValue
+	MemberList         *MemberList
+	IdentityLookup     IdentityLookup
+	kinds              map[string]*ActivatedKind
+	context            Context
+	draining           atomic.Bool
 }
 
 var _ extensions.Extension = &Cluster{}
@@ -48,6 +54,10 @@ func New(actorSystem *actor.ActorSystem, config *Config) *Cluster {
 	var err error
 	c.Gossip, err = newGossiper(c)
 	c.PubSub = NewPubSub(c)
+	c.Singletons = newSingletons(c)
+	c.LeaderElection = newLeaderElection(c)
+	c.DistributedData = newDistributedData(c)
+	c.SplitBrainResolver = newSplitBrainResolver(c)
 
 	if err != nil {
 		panic(err)
@@ -58,10 +68,14 @@ func New(actorSystem *actor.ActorSystem, config *Config) *Cluster {
 
 func (c *Cluster) subscribeToTopologyEvents() {
 	c.ActorSystem.EventStream.Subscribe(func(evt interface{}) {
-		if clusterTopology, ok := evt.(*ClusterTopology); ok {
-			for _, member := range clusterTopology.Left {
+		switch evt := evt.(type) {
+		case *ClusterTopology:
+			for _, member := range evt.Left {
 				c.PidCache.RemoveByMember(member)
 			}
+		case *ActivationTerminated:
+			// the activations terminated on other members are broadcast by their placement actors
+			c.PidCache.RemoveByValue(evt.ClusterIdentity.Identity, evt.ClusterIdentity.Kind, evt.Pid)
 		}
 	})
 }
@@ -83,6 +97,9 @@ func (c *Cluster) GetBlockedMembers() set.Set[string] {
 
 func (c *Cluster) StartMember() {
 	cfg := c.Config
+	if err := cfg.Validate(); err != nil {
+		panic(err)
+	}
 	c.Remote = remote.NewRemote(c.ActorSystem, c.Config.RemoteConfig)
 
 	c.initKinds()
@@ -103,6 +120,9 @@ func (c *Cluster) StartMember() {
 	}
 	c.PubSub.Start()
 	c.MemberList.InitializeTopologyConsensus()
+	c.LeaderElection.Start()
+	c.DistributedData.Start()
+	c.Singletons.Start()
 
 	if err := cfg.ClusterProvider.StartMember(c); err != nil {
 		panic(err)
@@ -122,6 +142,9 @@ func (c *Cluster) GetClusterKinds() []string {
 
 func (c *Cluster) StartClient() {
 	cfg := c.Config
+	if err := cfg.Validate(); err != nil {
+		panic(err)
+	}
 	c.Remote = remote.NewRemote(c.ActorSystem, c.Config.RemoteConfig)
 
 	c.Remote.Start()
@@ -139,6 +162,10 @@ func (c *Cluster) StartClient() {
 }
 
 func (c *Cluster) Shutdown(graceful bool) {
+	if c.ActorSystem.IsStopped() {
+		// the member was downed, see SplitBrainResolver
+		return
+	}
 	c.Gossip.SetState(GracefullyLeftKey, &emptypb.Empty{})
 	c.ActorSystem.Shutdown()
 	if graceful {
@@ -186,8 +213,27 @@ func (c *Cluster) TryGetClusterKind(kind string) (*ActivatedKind, bool) {
 	return k, ok
 }
 
+// Labels returns the labels of this member, the labels of WithMemberLabels override the labels of remote.WithLabels
+func (c *Cluster) Labels() map[string]string {
+	labels := make(map[string]string, len(c.Config.RemoteConfig.Labels)+len(c.Config.Labels))
+	for k, v := range c.Config.RemoteConfig.Labels {
+		labels[k] = v
+	}
+	for k, v := range c.Config.Labels {
+		labels[k] = v
+	}
+
+	return labels
+}
+
 func (c *Cluster) initKinds() {
+	labels := c.Labels()
 	for name, kind := range c.Config.Kinds {
+		if !remote.HasLabels(labels, kind.Placement) {
+			// the member is not advertised as a host of the kind, so no activation is placed on it
+			c.Logger().Info("Kind is not placed on this member", slog.String("kind", name), slog.Any("placement", kind.Placement))
+			continue
+		}
 		c.kinds[name] = kind.Build(c)
 	}
 	c.ensureTopicKindRegistered()
@@ -204,7 +250,10 @@ func (c *Cluster) ensureTopicKindRegistered() {
 		}
 	}
 	if !hasTopicKind {
-		store := &EmptyKeyValueStore[*Subscribers]{}
+		var store KeyValueStore[*Subscribers] = &EmptyKeyValueStore[*Subscribers]{}
+		if c.Config.PubSubConfig.SubscriptionStore != nil {
+			store = c.Config.PubSubConfig.SubscriptionStore
+		}
 
 		c.kinds[TopicActorKind] = NewKind(TopicActorKind, actor.PropsFromProducer(func() actor.Actor {
 			return NewTopicActor(store, c.Logger())
@@ -3,6 +3,7 @@ package cluster
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -31,10 +32,11 @@ func (p *inmemoryProvider) init(c *Cluster) error {
 	}
 	p.cluster = c
 	p.self = &Member{
-		Host:  host,
-		Port:  int32(port),
-		Id:    fmt.Sprintf("%s@%s:%d", name, host, port),
-		Kinds: c.GetClusterKinds(),
+		Host:   host,
+		Port:   int32(port),
+		Id:     fmt.Sprintf("%s@%s:%d", name, host, port),
+		Kinds:  c.GetClusterKinds(),
+		Labels: c.Labels(),
 	}
 
 	return nil
@@ -188,3 +190,63 @@ func TestCluster_Get(t *testing.T) {
 		assert.NotNil(pid)
 	})
 }
+
+func TestCluster_Labels(t *testing.T) {
+	system := actor.NewActorSystem()
+	remoteConfig := remote.Configure("127.0.0.1", 0, remote.WithLabels(map[string]string{"zone": "us-1", "gpu": "true"}))
+	c := New(system, Configure("mycluster", nil, &fakeIdentityLookup{}, remoteConfig,
+		WithMemberLabels(map[string]string{RoleLabel: "worker", ZoneLabel: "eu-1"})))
+
+	assert.Equal(t, map[string]string{"role": "worker", "zone": "eu-1", "gpu": "true"}, c.Labels())
+}
+
+func TestValidateLabel(t *testing.T) {
+	for _, key := range []string{RoleLabel, "topology.kubernetes.io/zone", "app_version", "a.b-c"} {
+		assert.NoError(t, ValidateLabel(key, "eu-west-1.a_b"), key)
+	}
+	assert.NoError(t, ValidateLabel(ZoneLabel, ""))
+
+	for key, value := range map[string]string{
+		"":                       "value",
+		"a/b/c":                  "value",
+		"Upper.Case/zone":        "value",
+		"-zone":                  "value",
+		"zone=":                  "value",
+		strings.Repeat("k", 64):  "value",
+		"zone":                   strings.Repeat("v", 64),
+		"role":                   "front end",
+		"topology.kubernetes.io": "eu/1",
+	} {
+		assert.ErrorIs(t, ValidateLabel(key, value), ErrInvalidLabel, "%s=%s", key, value)
+	}
+}
+
+func TestConfigure_WithInvalidMemberLabels(t *testing.T) {
+	remoteConfig := remote.Configure("127.0.0.1", 0, remote.WithLabels(map[string]string{"gpu": "yes please"}))
+	config := Configure("mycluster", nil, &fakeIdentityLookup{}, remoteConfig,
+		WithMemberLabels(map[string]string{"topology.kubernetes.io/zone": "eu-1", "a/b/c": "invalid"}))
+
+	assert.Equal(t, map[string]string{"topology.kubernetes.io/zone": "eu-1"}, config.Labels)
+	err := config.Validate()
+	assert.ErrorIs(t, err, ErrInvalidLabel)
+	assert.ErrorContains(t, err, "a/b/c")
+	assert.ErrorContains(t, err, "yes please")
+
+	c := New(actor.NewActorSystem(), config)
+	assert.Panics(t, c.StartMember)
+}
+
+func TestCluster_KindPlacement(t *testing.T) {
+	props := actor.PropsFromFunc(func(ctx actor.Context) {})
+	c := newClusterForTest("mycluster", nil,
+		WithMemberLabels(map[string]string{RoleLabel: "worker", ZoneLabel: "eu-1"}),
+		WithKinds(
+			NewKind("anywhere", props),
+			NewKind("worker", props).WithPlacement(map[string]string{RoleLabel: "worker", ZoneLabel: "eu-1"}),
+			NewKind("frontend", props).WithPlacement(map[string]string{RoleLabel: "frontend"}),
+			NewKind("us", props).WithPlacement(map[string]string{RoleLabel: "worker", ZoneLabel: "us-1"}),
+		))
+	c.initKinds()
+
+	assert.ElementsMatch(t, []string{"anywhere", "worker", TopicActorKind}, c.GetClusterKinds())
+}
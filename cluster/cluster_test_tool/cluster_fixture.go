@@ -12,6 +12,8 @@ import (
 	"github.com/asynkron/protoactor-go/remote"
 	"github.com/google/uuid"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
 type ClusterFixture interface {
@@ -28,6 +30,7 @@ type ClusterFixtureConfig struct {
 	Configure          func(*cluster.Config) *cluster.Config
 	GetIdentityLookup  func(clusterName string) cluster.IdentityLookup
 	OnDeposing         func()
+	Network            *PartitionableNetwork
 }
 
 type ClusterFixtureOption func(*ClusterFixtureConfig)
@@ -67,6 +70,13 @@ func WithOnDeposing(onDeposing func()) ClusterFixtureOption {
 	}
 }
 
+// WithPartitionableNetwork connects the members of the cluster fixture through the network
+func WithPartitionableNetwork(network *PartitionableNetwork) ClusterFixtureOption {
+	return func(c *ClusterFixtureConfig) {
+		c.Network = network
+	}
+}
+
 const InvalidIdentity string = "invalid"
 
 type BaseClusterFixture struct {
@@ -176,14 +186,21 @@ func (b *BaseClusterFixture) spawnClusterNodes() []*cluster.Cluster {
 
 // spawnClusterMember spawns a cluster members
 func (b *BaseClusterFixture) spawnClusterMember() *cluster.Cluster {
+	system := actor.NewActorSystem()
+
+	var remoteOptions []remote.ConfigOption
+	if b.config.Network != nil {
+		remoteOptions = append(remoteOptions, remote.WithDialOptions(
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			b.config.Network.dialOption(system.Address),
+		))
+	}
 	config := cluster.Configure(b.clusterName, b.config.GetClusterProvider(), b.config.GetIdentityLookup(b.clusterName),
-		remote.Configure("localhost", 0),
+		remote.Configure("localhost", 0, remoteOptions...),
 		cluster.WithKinds(b.config.GetClusterKinds()...),
 	)
 	config = b.config.Configure(config)
 
-	system := actor.NewActorSystem()
-
 	c := cluster.New(system, config)
 	c.StartMember()
 	return c
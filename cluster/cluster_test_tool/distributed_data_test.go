@@ -0,0 +1,103 @@
+package cluster_test_tool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/cluster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestDistributedDataReplicatesCounter(t *testing.T) {
+	fixture := NewBaseInMemoryClusterFixture(3)
+	fixture.Initialize()
+	defer fixture.ShutDown()
+
+	members := fixture.GetMembers()
+	changed := make(chan uint64, 10)
+	members[0].DistributedData.Subscribe("hits", func(data cluster.ReplicatedData) {
+		changed <- data.(*cluster.GCounter).Value()
+	})
+
+	for _, member := range members {
+		id := member.ActorSystem.ID
+		_, err := member.DistributedData.Update("hits", cluster.NewGCounter(), cluster.ConsistencyLocal, func(data cluster.ReplicatedData) cluster.ReplicatedData {
+			return data.(*cluster.GCounter).Increment(id, 1)
+		})
+		require.NoError(t, err)
+	}
+
+	value := func(c *cluster.Cluster) uint64 {
+		data, err := c.DistributedData.Get("hits", cluster.ConsistencyLocal)
+		if err != nil || data == nil {
+			return 0
+		}
+		return data.(*cluster.GCounter).Value()
+	}
+	WaitUntil(t, func() bool {
+		for _, member := range members {
+			if value(member) != 3 {
+				return false
+			}
+		}
+		return true
+	}, "counter replicas did not converge", 10*time.Second)
+
+	var last uint64
+	for len(changed) > 0 {
+		last = <-changed
+	}
+	assert.Equal(t, uint64(3), last)
+
+	// the leader prunes the count of a member which left, the total is kept
+	leaving := members[2]
+	fixture.RemoveNode(leaving, true)
+	WaitUntil(t, func() bool {
+		for _, member := range fixture.GetMembers() {
+			data, err := member.DistributedData.Get("hits", cluster.ConsistencyLocal)
+			if err != nil || data == nil {
+				return false
+			}
+			counter := data.(*cluster.GCounter)
+			if counter.Value() != 3 || len(counter.Pruned) != 1 || counter.Pruned[0] != leaving.ActorSystem.ID {
+				return false
+			}
+		}
+		return true
+	}, "counter of the member which left was not pruned", 20*time.Second)
+}
+
+func TestDistributedDataWriteAllIsReadLocally(t *testing.T) {
+	fixture := NewBaseInMemoryClusterFixture(3)
+	fixture.Initialize()
+	defer fixture.ShutDown()
+
+	members := fixture.GetMembers()
+	writer := members[0]
+	_, err := writer.DistributedData.Update("config", cluster.NewLWWMap(), cluster.ConsistencyAll, func(data cluster.ReplicatedData) cluster.ReplicatedData {
+		return data.(*cluster.LWWMap).Put(writer.ActorSystem.ID, "mode", wrapperspb.String("fast"))
+	})
+	require.NoError(t, err)
+
+	for _, member := range members {
+		data, err := member.DistributedData.Get("config", cluster.ConsistencyLocal)
+		require.NoError(t, err)
+		require.NotNil(t, data)
+
+		value, ok := data.(*cluster.LWWMap).Get("mode")
+		assert.True(t, ok)
+		assert.Equal(t, "fast", value.(*wrapperspb.StringValue).Value)
+	}
+
+	data, err := members[1].DistributedData.Get("config", cluster.ConsistencyMajority)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mode"}, data.(*cluster.LWWMap).Keys())
+
+	// a key can not change its type
+	_, err = members[1].DistributedData.Update("config", cluster.NewGCounter(), cluster.ConsistencyLocal, func(data cluster.ReplicatedData) cluster.ReplicatedData {
+		return cluster.NewGCounter()
+	})
+	assert.Error(t, err)
+}
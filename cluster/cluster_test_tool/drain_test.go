@@ -0,0 +1,125 @@
+package cluster_test_tool
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/cluster"
+	"github.com/asynkron/protoactor-go/cluster/identitylookup/partition"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const drainKind = "drain-test"
+
+func TestDrainMovesActivationsAfterInFlightRequests(t *testing.T) {
+	fixture := NewBaseInMemoryClusterFixture(2,
+		WithGetClusterKinds(func() []*cluster.Kind {
+			return []*cluster.Kind{
+				cluster.NewKind(drainKind, actor.PropsFromFunc(func(ctx actor.Context) {
+					if msg, ok := ctx.Message().(*wrapperspb.StringValue); ok {
+						if msg.Value == "slow" {
+							time.Sleep(500 * time.Millisecond)
+						}
+						ctx.Respond(wrapperspb.String(ctx.ActorSystem().Address()))
+					}
+				})),
+			}
+		}),
+		WithGetIdentityLookup(func(string) cluster.IdentityLookup { return partition.New() }),
+	)
+	fixture.Initialize()
+	defer fixture.ShutDown()
+
+	members := fixture.GetMembers()
+	caller, drained := members[0], members[1]
+	host := func(identity, message string) string {
+		res, err := caller.Request(identity, drainKind, wrapperspb.String(message))
+		require.NoError(t, err)
+		return res.(*wrapperspb.StringValue).Value
+	}
+
+	var hosted []string
+	for i := 0; len(hosted) < 3 && i < 100; i++ {
+		identity := fmt.Sprintf("grain-%d", i)
+		if host(identity, "hello") == drained.ActorSystem.Address() {
+			hosted = append(hosted, identity)
+		}
+	}
+	require.Len(t, hosted, 3)
+
+	// the request in the mailbox of the activation is handled before it stops
+	slow := make(chan string, 1)
+	go func() { slow <- host(hosted[0], "slow") }()
+	time.Sleep(100 * time.Millisecond)
+
+	require.NoError(t, drained.Drain(context.Background()))
+	assert.True(t, drained.IsDraining())
+	assert.Equal(t, drained.ActorSystem.Address(), <-slow)
+	assert.Equal(t, int32(0), drained.GetClusterKind(drainKind).Count())
+
+	WaitUntil(t, func() bool {
+		return caller.MemberList.IsDraining(drained.ActorSystem.ID)
+	}, "the draining state was not gossiped", 5*time.Second)
+
+	// the grains are activated again on the member which is not draining
+	for _, identity := range hosted {
+		WaitUntil(t, func() bool {
+			res, err := caller.Request(identity, drainKind, wrapperspb.String("hello"))
+			return err == nil && res.(*wrapperspb.StringValue).Value == caller.ActorSystem.Address()
+		}, "the grain was not activated on another member", 5*time.Second)
+	}
+	assert.Equal(t, int32(0), drained.GetClusterKind(drainKind).Count())
+}
+
+func TestDrainStopsActivationsAtTimeout(t *testing.T) {
+	fixture := NewBaseInMemoryClusterFixture(1,
+		WithGetClusterKinds(func() []*cluster.Kind {
+			return []*cluster.Kind{
+				cluster.NewKind(drainKind, actor.PropsFromFunc(func(ctx actor.Context) {
+					if _, ok := ctx.Message().(*wrapperspb.StringValue); ok {
+						time.Sleep(time.Second)
+						ctx.Respond(wrapperspb.String("done"))
+					}
+				})),
+			}
+		}),
+		WithClusterConfigure(func(c *cluster.Config) *cluster.Config {
+			c.DrainTimeout = 200 * time.Millisecond
+			return c
+		}),
+	)
+	fixture.Initialize()
+	defer fixture.ShutDown()
+
+	member := fixture.GetMembers()[0]
+	pid := member.Get("busy", drainKind)
+	require.NotNil(t, pid)
+	WaitUntil(t, func() bool {
+		return member.GetClusterKind(drainKind).Count() == 1
+	}, "the grain was not activated", 5*time.Second)
+	for i := 0; i < 5; i++ {
+		member.ActorSystem.Root.Send(pid, wrapperspb.String("work"))
+	}
+
+	start := time.Now()
+	err := member.Drain(context.Background())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 2*time.Second)
+	WaitUntil(t, func() bool {
+		return member.GetClusterKind(drainKind).Count() == 0
+	}, "the activation was not stopped", 5*time.Second)
+}
+
+func TestGracefulShutdownDoesNotDrain(t *testing.T) {
+	fixture := NewBaseInMemoryClusterFixture(1)
+	fixture.Initialize()
+
+	member := fixture.GetMembers()[0]
+	fixture.ShutDown()
+	assert.False(t, member.IsDraining())
+}
@@ -0,0 +1,58 @@
+package cluster_test_tool
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/cluster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestGossipKeyEmitsTheChangesOfAllMembers(t *testing.T) {
+	fixture := NewBaseInMemoryClusterFixture(3)
+	fixture.Initialize()
+	defer fixture.ShutDown()
+
+	members := fixture.GetMembers()
+	key := cluster.NewGossipKey[*wrapperspb.StringValue](members[0].Gossip, "greeting")
+
+	var mu sync.Mutex
+	changes := map[string]string{}
+	sub := key.Subscribe(func(change cluster.GossipKeyChange[*wrapperspb.StringValue]) {
+		mu.Lock()
+		defer mu.Unlock()
+		changes[change.MemberID] = change.Value.Value
+	})
+	defer key.Unsubscribe(sub)
+	changed := func(memberID, value string) func() bool {
+		return func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return changes[memberID] == value
+		}
+	}
+
+	// a value of another type is skipped
+	members[2].Gossip.SetState("greeting", &cluster.MemberLabels{})
+
+	for _, member := range members {
+		other := cluster.NewGossipKey[*wrapperspb.StringValue](member.Gossip, key.Key())
+		require.NoError(t, other.SetStateRequest(wrapperspb.String("hello from "+member.ActorSystem.ID)))
+	}
+	for _, member := range members {
+		WaitUntil(t, changed(member.ActorSystem.ID, "hello from "+member.ActorSystem.ID), "the change was not emitted", 10*time.Second)
+	}
+
+	values, err := key.Get()
+	require.NoError(t, err)
+	require.Len(t, values, len(members))
+	for _, member := range members {
+		assert.Equal(t, "hello from "+member.ActorSystem.ID, values[member.ActorSystem.ID].Value)
+	}
+
+	key.SetState(wrapperspb.String("changed"))
+	WaitUntil(t, changed(members[0].ActorSystem.ID, "changed"), "the local change was not emitted", 10*time.Second)
+}
@@ -0,0 +1,100 @@
+package cluster_test_tool
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/cluster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const gossipTestKey = "gossip-test"
+
+// hasGossipState returns true if each member has the state of the key set by the member with the id
+func hasGossipState(members []*cluster.Cluster, memberID string, value string) bool {
+	for _, member := range members {
+		state, err := member.Gossip.GetState(gossipTestKey)
+		if err != nil {
+			return false
+		}
+		entry, ok := state[memberID]
+		if !ok {
+			return false
+		}
+		labels := &cluster.MemberLabels{}
+		if err := entry.Value.UnmarshalTo(labels); err != nil || labels.Labels["value"] != value {
+			return false
+		}
+	}
+	return true
+}
+
+func TestGossipConvergesToJoiningMember(t *testing.T) {
+	fixture := NewBaseInMemoryClusterFixture(3)
+	fixture.Initialize()
+	defer fixture.ShutDown()
+
+	members := fixture.GetMembers()
+	origin := members[0]
+	origin.Gossip.SetState(gossipTestKey, &cluster.MemberLabels{Labels: map[string]string{"value": "initial"}})
+	WaitUntil(t, func() bool {
+		return hasGossipState(fixture.GetMembers(), origin.ActorSystem.ID, "initial")
+	}, "the state did not reach all members", 10*time.Second)
+
+	joined := fixture.SpawnNode()
+	WaitUntil(t, func() bool {
+		return hasGossipState([]*cluster.Cluster{joined}, origin.ActorSystem.ID, "initial")
+	}, "the state did not reach the joining member", 10*time.Second)
+
+	stats := joined.Gossip.Stats()
+	assert.Positive(t, stats.DigestExchanges)
+	assert.Positive(t, stats.BytesReceived)
+	assert.Positive(t, stats.EntriesReceived)
+
+	origin.Gossip.SetState(gossipTestKey, &cluster.MemberLabels{Labels: map[string]string{"value": "changed"}})
+	WaitUntil(t, func() bool {
+		return hasGossipState(fixture.GetMembers(), origin.ActorSystem.ID, "changed")
+	}, "the changed state did not reach all members", 10*time.Second)
+	WaitUntil(t, func() bool {
+		return origin.Gossip.Stats().ConvergenceTime > 0
+	}, "the convergence of the state was not recorded", 10*time.Second)
+}
+
+// BenchmarkGossipConvergence measures the time and the gossip traffic for a change of the state of a member to
+// reach all the members of a cluster of 50 members
+func BenchmarkGossipConvergence(b *testing.B) {
+	const clusterSize = 50
+	fixture := NewBaseInMemoryClusterFixture(clusterSize, WithClusterConfigure(func(c *cluster.Config) *cluster.Config {
+		c.GossipInterval = 50 * time.Millisecond
+		return c
+	}))
+	fixture.Initialize()
+	defer fixture.ShutDown()
+
+	members := fixture.GetMembers()
+	require.Len(b, members, clusterSize)
+	bytesSent := func() int64 {
+		var total int64
+		for _, member := range members {
+			total += member.Gossip.Stats().BytesSent
+		}
+		return total
+	}
+
+	origin := members[0]
+	b.ResetTimer()
+	startBytes := bytesSent()
+	for i := 0; i < b.N; i++ {
+		value := fmt.Sprintf("value-%d", i)
+		origin.Gossip.SetState(gossipTestKey, &cluster.MemberLabels{Labels: map[string]string{"value": value}})
+		WaitUntil(b, func() bool {
+			return hasGossipState(members, origin.ActorSystem.ID, value)
+		}, "the state did not reach all members", 30*time.Second)
+	}
+	b.StopTimer()
+
+	b.ReportMetric(float64(bytesSent()-startBytes)/float64(b.N), "gossip-bytes/op")
+	b.ReportMetric(origin.Gossip.Stats().ConvergenceTime.Seconds(), "convergence-s")
+}
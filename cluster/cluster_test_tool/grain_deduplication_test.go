@@ -0,0 +1,61 @@
+package cluster_test_tool
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/asynkron/protoactor-go/cluster"
+	"github.com/asynkron/protoactor-go/protobuf/protoc-gen-go-grain/test/hello"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type countingHello struct {
+	runs *atomic.Int32
+}
+
+func (h *countingHello) Init(cluster.GrainContext)           {}
+func (h *countingHello) Terminate(cluster.GrainContext)      {}
+func (h *countingHello) ReceiveDefault(cluster.GrainContext) {}
+
+func (h *countingHello) SayHello(_ *emptypb.Empty, _ cluster.GrainContext) (*hello.SayHelloResponse, error) {
+	return &hello.SayHelloResponse{Message: fmt.Sprint(h.runs.Add(1))}, nil
+}
+
+func TestGrainClientCallsRunOncePerRequestID(t *testing.T) {
+	var runs atomic.Int32
+	fixture := NewBaseInMemoryClusterFixture(2, WithGetClusterKinds(func() []*cluster.Kind {
+		return []*cluster.Kind{
+			hello.NewHelloKind(func() hello.Hello { return &countingHello{runs: &runs} }, 0).WithDeduplication(100),
+		}
+	}))
+	fixture.Initialize()
+	defer fixture.ShutDown()
+
+	members := fixture.GetMembers()
+	say := func(c *cluster.Cluster, opts ...cluster.GrainCallOption) string {
+		res, err := hello.GetHelloGrainClient(c, "greeter").SayHello(&emptypb.Empty{}, opts...)
+		require.NoError(t, err)
+		return res.Message
+	}
+
+	// the request id is kept across members, the call runs on the grain only once
+	assert.Equal(t, "1", say(members[0], cluster.WithRequestID("first")))
+	assert.Equal(t, "1", say(members[1], cluster.WithRequestID("first")))
+	assert.Equal(t, "2", say(members[0], cluster.WithIdempotency()))
+	assert.Equal(t, "3", say(members[1]))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(c *cluster.Cluster) {
+			defer wg.Done()
+			assert.Equal(t, "4", say(c, cluster.WithRequestID("concurrent")))
+		}(members[i%2])
+	}
+	wg.Wait()
+	assert.Equal(t, int32(4), runs.Load())
+}
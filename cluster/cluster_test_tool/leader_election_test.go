@@ -0,0 +1,81 @@
+package cluster_test_tool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/cluster"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeaderElectionElectsNewLeaderWhenLeaderLeaves(t *testing.T) {
+	fixture := NewBaseInMemoryClusterFixture(3)
+	fixture.Initialize()
+	defer fixture.ShutDown()
+
+	changed := make(chan *cluster.LeaderChanged, 10)
+	observer := fixture.GetMembers()[0]
+	observer.ActorSystem.EventStream.Subscribe(func(evt interface{}) {
+		if msg, ok := evt.(*cluster.LeaderChanged); ok {
+			changed <- msg
+		}
+	})
+
+	// agreed returns the leader all members agree on
+	agreed := func() (*cluster.Cluster, uint64) {
+		var leader *cluster.Cluster
+		var token uint64
+		for _, member := range fixture.GetMembers() {
+			elected, term := member.LeaderElection.Leader()
+			if elected == nil || (token != 0 && term != token) {
+				return nil, 0
+			}
+			token = term
+
+			if member.LeaderElection.IsLeader() {
+				if leader != nil {
+					return nil, 0
+				}
+				leader = member
+			}
+		}
+		return leader, token
+	}
+
+	var leader *cluster.Cluster
+	var token uint64
+	WaitUntil(t, func() bool {
+		leader, token = agreed()
+		return leader != nil
+	}, "members did not elect a leader", 10*time.Second)
+
+	leaderToken, ok := leader.LeaderElection.Token()
+	assert.True(t, ok)
+	assert.Equal(t, token, leaderToken)
+
+	fixture.RemoveNode(leader, true)
+
+	var next *cluster.Cluster
+	var nextToken uint64
+	WaitUntil(t, func() bool {
+		next, nextToken = agreed()
+		return next != nil && next != leader
+	}, "members did not elect a new leader", 20*time.Second)
+
+	assert.Greater(t, nextToken, token)
+	for _, member := range fixture.GetMembers() {
+		assert.False(t, member.LeaderElection.ValidateToken(token), "stale token was accepted")
+		assert.True(t, member.LeaderElection.ValidateToken(nextToken))
+	}
+
+	if observer != leader {
+		var last *cluster.LeaderChanged
+		for len(changed) > 0 {
+			last = <-changed
+		}
+		if assert.NotNil(t, last) {
+			assert.Equal(t, nextToken, last.Token)
+			assert.Equal(t, next.ActorSystem.ID, last.Leader.Id)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package cluster_test_tool
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/cluster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const placedKind = "placed-test"
+
+var memberLabels = []map[string]string{
+	{cluster.RoleLabel: "frontend", cluster.ZoneLabel: "eu-1"},
+	{cluster.RoleLabel: "worker", cluster.ZoneLabel: "eu-1"},
+	{cluster.RoleLabel: "worker", cluster.ZoneLabel: "us-1"},
+}
+
+// newLabeledFixture starts a member with each of the memberLabels, the placed kind is only placed on the workers of eu-1
+func newLabeledFixture() *BaseClusterFixture {
+	spawned := 0
+	fixture := NewBaseInMemoryClusterFixture(len(memberLabels),
+		WithGetClusterKinds(func() []*cluster.Kind {
+			return []*cluster.Kind{
+				cluster.NewKind(placedKind, actor.PropsFromFunc(func(ctx actor.Context) {
+					if _, ok := ctx.Message().(*wrapperspb.StringValue); ok {
+						ctx.Respond(wrapperspb.String(ctx.ActorSystem().Address()))
+					}
+				})).WithPlacement(map[string]string{cluster.RoleLabel: "worker", cluster.ZoneLabel: "eu-1"}),
+			}
+		}),
+		WithClusterConfigure(func(c *cluster.Config) *cluster.Config {
+			// the members are configured in the order they are spawned
+			cluster.WithMemberLabels(memberLabels[spawned])(c)
+			spawned++
+			return c
+		}),
+	)
+	fixture.Initialize()
+
+	return fixture
+}
+
+func TestMemberLabelsArePropagatedToAllMembers(t *testing.T) {
+	fixture := newLabeledFixture()
+	defer fixture.ShutDown()
+
+	members := fixture.GetMembers()
+	for _, member := range members {
+		for i, other := range members {
+			assert.Equal(t, memberLabels[i], member.MemberList.Labels(other.ActorSystem.ID))
+		}
+
+		workers := member.MemberList.MembersWithLabels(map[string]string{cluster.RoleLabel: "worker"})
+		assert.Len(t, workers, 2)
+		for _, worker := range workers {
+			assert.Equal(t, "worker", worker.Role())
+		}
+	}
+}
+
+func TestKindPlacementOnlyActivatesOnMatchingMembers(t *testing.T) {
+	fixture := newLabeledFixture()
+	defer fixture.ShutDown()
+
+	members := fixture.GetMembers()
+	frontend, worker := members[0], members[1]
+
+	for _, member := range members {
+		_, hosted := member.TryGetClusterKind(placedKind)
+		assert.Equal(t, member == worker, hosted, "placement of member %s", member.ActorSystem.ID)
+	}
+
+	for i := 0; i < 20; i++ {
+		res, err := frontend.Request(fmt.Sprintf("grain-%d", i), placedKind, wrapperspb.String("hello"))
+		require.NoError(t, err)
+		assert.Equal(t, worker.ActorSystem.Address(), res.(*wrapperspb.StringValue).Value)
+	}
+}
@@ -0,0 +1,129 @@
+package cluster_test_tool
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+
+	"github.com/asynkron/protoactor-go/cluster"
+	"google.golang.org/grpc"
+)
+
+// ErrNetworkPartitioned is returned when a member dials a member on another side of a network partition
+var ErrNetworkPartitioned = errors.New("network partitioned")
+
+// PartitionableNetwork connects the members of a cluster fixture and simulates network partitions between them.
+// The connections between the members of different sides of a partition are cut, and they cannot connect again
+// until the partition heals.
+type PartitionableNetwork struct {
+	mu    sync.Mutex
+	sides map[string]int
+	conns map[*partitionableConn]empty
+}
+
+type empty struct{}
+
+// partitionableConn is a connection from the member with the address returned by from, the address of a member is
+// only known once it started
+type partitionableConn struct {
+	net.Conn
+	network *PartitionableNetwork
+	from    func() string
+	to      string
+}
+
+func NewPartitionableNetwork() *PartitionableNetwork {
+	return &PartitionableNetwork{conns: map[*partitionableConn]empty{}}
+}
+
+// Partition cuts the network between the sides, the members which are on no side reach all members
+func (n *PartitionableNetwork) Partition(sides ...[]*cluster.Cluster) {
+	n.mu.Lock()
+	n.sides = make(map[string]int)
+	for side, members := range sides {
+		for _, member := range members {
+			n.sides[member.ActorSystem.Address()] = side
+		}
+	}
+
+	var cut []*partitionableConn
+	for conn := range n.conns {
+		if n.isCut(conn.from(), conn.to) {
+			cut = append(cut, conn)
+		}
+	}
+	n.mu.Unlock()
+
+	for _, conn := range cut {
+		_ = conn.Close()
+	}
+}
+
+// Heal reconnects all members
+func (n *PartitionableNetwork) Heal() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.sides = nil
+}
+
+// dialOption returns the dial option connecting the member with the address returned by from through the network
+func (n *PartitionableNetwork) dialOption(from func() string) grpc.DialOption {
+	return grpc.WithContextDialer(func(ctx context.Context, to string) (net.Conn, error) {
+		if n.cut(from(), to) {
+			return nil, ErrNetworkPartitioned
+		}
+
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", to)
+		if err != nil {
+			return nil, err
+		}
+
+		return n.track(&partitionableConn{Conn: conn, network: n, from: from, to: to})
+	})
+}
+
+func (n *PartitionableNetwork) track(conn *partitionableConn) (net.Conn, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	// the network may have been partitioned while dialing
+	if n.isCut(conn.from(), conn.to) {
+		_ = conn.Conn.Close()
+		return nil, ErrNetworkPartitioned
+	}
+	n.conns[conn] = empty{}
+
+	return conn, nil
+}
+
+func (n *PartitionableNetwork) cut(from, to string) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.isCut(from, to)
+}
+
+func (n *PartitionableNetwork) isCut(from, to string) bool {
+	fromSide, ok := n.sides[from]
+	if !ok {
+		return false
+	}
+	toSide, ok := n.sides[to]
+
+	return ok && fromSide != toSide
+}
+
+func (n *PartitionableNetwork) forget(conn *partitionableConn) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	delete(n.conns, conn)
+}
+
+func (c *partitionableConn) Close() error {
+	c.network.forget(c)
+	return c.Conn.Close()
+}
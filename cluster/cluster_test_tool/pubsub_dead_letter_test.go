@@ -0,0 +1,195 @@
+package cluster_test_tool
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/cluster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	deadLetterTopic = "dead-letters"
+	poisonData      = 13
+)
+
+// poisonSubscriber is slower than the subscriber timeout for the poison data while it is poisoned, the next
+// messages may time out and be delivered again while it processes the poison data
+type poisonSubscriber struct {
+	mu       sync.Mutex
+	data     []int
+	failures atomic.Int32
+	poisoned atomic.Bool
+}
+
+func (s *poisonSubscriber) receive(ctx actor.Context) {
+	if msg, ok := ctx.Message().(*DataPublished); ok {
+		if msg.Data == poisonData && s.poisoned.Load() {
+			time.Sleep(150 * time.Millisecond)
+			s.failures.Add(1)
+			return
+		}
+		s.mu.Lock()
+		s.data = append(s.data, int(msg.Data))
+		s.mu.Unlock()
+	}
+}
+
+// received returns the data in the order it was first received, the data delivered again is ignored
+func (s *poisonSubscriber) received() []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seen := make(map[int]bool)
+	received := make([]int, 0, len(s.data))
+	for _, data := range s.data {
+		if !seen[data] {
+			seen[data] = true
+			received = append(received, data)
+		}
+	}
+
+	return received
+}
+
+// deadLetterFixture starts a cluster retrying the deliveries 3 times and subscribes to the dead-letter topic
+func deadLetterFixture(t *testing.T, opts ...cluster.ConfigOption) (*BaseClusterFixture, func() []*cluster.PubSubDeadLetter) {
+	fixture := NewBaseInMemoryClusterFixture(2, WithClusterConfigure(func(c *cluster.Config) *cluster.Config {
+		cluster.WithPubSubSubscriberTimeout(100 * time.Millisecond)(c)
+		cluster.WithPubSubDeliveryRetryPolicy(func(string, *cluster.SubscriberIdentity) *cluster.RetryPolicy {
+			return &cluster.RetryPolicy{MaxAttempts: 3, InitialBackoff: 100 * time.Millisecond}
+		})(c)
+		cluster.WithPubSubDeadLetterTopic(deadLetterTopic)(c)
+		for _, opt := range opts {
+			opt(c)
+		}
+		return c
+	}))
+	fixture.Initialize()
+
+	var mu sync.Mutex
+	var deadLetters []*cluster.PubSubDeadLetter
+	_, err := fixture.GetMembers()[1].SubscribeWithReceive(deadLetterTopic, func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(*cluster.PubSubDeadLetter); ok {
+			mu.Lock()
+			deadLetters = append(deadLetters, msg)
+			mu.Unlock()
+		}
+	})
+	require.NoError(t, err)
+
+	return fixture, func() []*cluster.PubSubDeadLetter {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]*cluster.PubSubDeadLetter(nil), deadLetters...)
+	}
+}
+
+// assertPoisonDeadLetter checks the dead letter of the poison data, then replays it to the healed subscriber
+func assertPoisonDeadLetter(t *testing.T, member *cluster.Cluster, topic string, subscriber *poisonSubscriber, deadLetters func() []*cluster.PubSubDeadLetter) {
+	WaitUntil(t, func() bool {
+		return len(deadLetters()) == 1
+	}, "the poison message was not published to the dead-letter topic", DefaultWaitTimeout)
+
+	deadLetter := deadLetters()[0]
+	assert.Equal(t, topic, deadLetter.Topic)
+	assert.NotNil(t, deadLetter.Subscriber.GetPid())
+	assert.Equal(t, cluster.DeliveryStatus_Timeout, deadLetter.Status)
+	assert.EqualValues(t, 3, deadLetter.Attempts)
+	assert.NotEmpty(t, deadLetter.Error)
+	assert.NotNil(t, deadLetter.FailedAt)
+	message, err := deadLetter.Message.UnmarshalNew()
+	require.NoError(t, err)
+	assert.EqualValues(t, poisonData, message.(*DataPublished).Data)
+
+	// the subscriber is healed once it processed all the attempts
+	WaitUntil(t, func() bool {
+		return subscriber.failures.Load() == 3
+	}, "the subscriber did not process the attempts", DefaultWaitTimeout)
+	subscriber.poisoned.Store(false)
+	assert.NotContains(t, subscriber.received(), poisonData)
+	require.NoError(t, member.ReplayDeadLetter(deadLetter))
+	assert.Contains(t, subscriber.received(), poisonData)
+}
+
+func TestPoisonMessagesArePublishedToTheDeadLetterTopic(t *testing.T) {
+	const topic = "poisoned-topic"
+	fixture, deadLetters := deadLetterFixture(t)
+	defer fixture.ShutDown()
+
+	member := fixture.GetMembers()[0]
+	subscriber := &poisonSubscriber{}
+	subscriber.poisoned.Store(true)
+	_, err := member.SubscribeWithReceive(topic, subscriber.receive)
+	require.NoError(t, err)
+
+	for _, data := range []int{1, poisonData, 2} {
+		_, err := member.Publisher().Publish(context.Background(), topic, &DataPublished{Data: int32(data)})
+		require.NoError(t, err)
+	}
+
+	assertPoisonDeadLetter(t, member, topic, subscriber, deadLetters)
+	WaitUntil(t, func() bool {
+		return assert.ObjectsAreEqual([]int{1, 2, poisonData}, subscriber.received())
+	}, "the subscriber did not receive the other messages", DefaultWaitTimeout)
+}
+
+func TestDurableTopicSkipsPoisonMessages(t *testing.T) {
+	const topic = "durable-poisoned-topic"
+	fixture, deadLetters := deadLetterFixture(t, cluster.WithPubSubDurableTopics(cluster.NewInMemoryTopicLogStore(), func(topic string) bool {
+		return topic != deadLetterTopic
+	}))
+	defer fixture.ShutDown()
+
+	member := fixture.GetMembers()[0]
+	subscriber := &poisonSubscriber{}
+	subscriber.poisoned.Store(true)
+	_, err := member.SubscribeWithReceive(topic, subscriber.receive)
+	require.NoError(t, err)
+
+	for _, data := range []int{1, poisonData, 2} {
+		_, err := member.Publisher().Publish(context.Background(), topic, &DataPublished{Data: int32(data)})
+		require.NoError(t, err)
+	}
+
+	// the durable topic delivers the next messages once the poison message is skipped
+	assertPoisonDeadLetter(t, member, topic, subscriber, deadLetters)
+	WaitUntil(t, func() bool {
+		return assert.ObjectsAreEqual([]int{1, poisonData, 2}, subscriber.received()) ||
+			assert.ObjectsAreEqual([]int{1, 2, poisonData}, subscriber.received())
+	}, "the subscriber did not receive the messages after the poison message", DefaultWaitTimeout)
+}
+
+func TestDeliveryRetriesDoNotHoldUpTheOtherSubscribers(t *testing.T) {
+	fixture, _ := deadLetterFixture(t, cluster.WithPubSubDeliveryRetryPolicy(func(string, *cluster.SubscriberIdentity) *cluster.RetryPolicy {
+		return &cluster.RetryPolicy{MaxAttempts: 3, InitialBackoff: 5 * time.Second}
+	}))
+	defer fixture.ShutDown()
+
+	member := fixture.GetMembers()[0]
+	poisoned := &poisonSubscriber{}
+	poisoned.poisoned.Store(true)
+	_, err := member.SubscribeWithReceive("poisoned-topic", poisoned.receive)
+	require.NoError(t, err)
+	healthy := &poisonSubscriber{}
+	_, err = member.SubscribeWithReceive("healthy-topic", healthy.receive)
+	require.NoError(t, err)
+
+	_, err = member.Publisher().Publish(context.Background(), "poisoned-topic", &DataPublished{Data: poisonData})
+	require.NoError(t, err)
+	WaitUntil(t, func() bool {
+		return poisoned.failures.Load() == 1
+	}, "the poisoned subscriber did not process the first attempt", DefaultWaitTimeout)
+
+	// the delivery to the poisoned subscriber waits for its next attempt, the other subscribers do not
+	_, err = member.Publisher().Publish(context.Background(), "healthy-topic", &DataPublished{Data: 1})
+	require.NoError(t, err)
+	WaitUntil(t, func() bool {
+		return assert.ObjectsAreEqual([]int{1}, healthy.received())
+	}, "the healthy subscriber waited for the retries of the poisoned one", time.Second)
+}
@@ -0,0 +1,128 @@
+package cluster_test_tool
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/cluster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const durableSubscriberKind = "durable-subscriber"
+
+// durableDeliveries records the data received by a subscriber, the subscriber is slower than the subscriber
+// timeout while it is blocked
+type durableDeliveries struct {
+	mu      sync.Mutex
+	data    []int
+	blocked atomic.Bool
+}
+
+func (d *durableDeliveries) props() *actor.Props {
+	return actor.PropsFromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(*DataPublished); ok {
+			if d.blocked.Load() {
+				time.Sleep(300 * time.Millisecond)
+			}
+			d.mu.Lock()
+			d.data = append(d.data, int(msg.Data))
+			d.mu.Unlock()
+		}
+	})
+}
+
+// received returns the data in the order it was first received, the data delivered again is ignored
+func (d *durableDeliveries) received() []int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	seen := make(map[int]bool)
+	received := make([]int, 0, len(d.data))
+	for _, data := range d.data {
+		if !seen[data] {
+			seen[data] = true
+			received = append(received, data)
+		}
+	}
+
+	return received
+}
+
+func TestDurableTopicDeliversInOrderAfterSubscriberFailures(t *testing.T) {
+	const topic = "durable-topic"
+	deliveries := &durableDeliveries{}
+	store := cluster.NewInMemoryTopicLogStore()
+	fixture := NewBaseInMemoryClusterFixture(2,
+		WithGetClusterKinds(func() []*cluster.Kind {
+			return []*cluster.Kind{cluster.NewKind(durableSubscriberKind, deliveries.props())}
+		}),
+		WithClusterConfigure(func(c *cluster.Config) *cluster.Config {
+			cluster.WithPubSubSubscriberTimeout(100 * time.Millisecond)(c)
+			cluster.WithPubSubRedeliveryInterval(50 * time.Millisecond)(c)
+			cluster.WithPubSubDurableTopics(store, func(topic string) bool { return topic == "durable-topic" })(c)
+			return c
+		}),
+	)
+	fixture.Initialize()
+	defer fixture.ShutDown()
+
+	member := fixture.GetMembers()[0]
+	_, err := member.SubscribeByClusterIdentity(topic, cluster.NewClusterIdentity("subscriber", durableSubscriberKind))
+	require.NoError(t, err)
+
+	publish := func(from, to int) {
+		for i := from; i < to; i++ {
+			res, err := member.Publisher().Publish(context.Background(), topic, &DataPublished{Data: int32(i)})
+			require.NoError(t, err)
+			assert.Equal(t, cluster.PublishStatus_Ok, res.Status)
+		}
+	}
+	expected := func(to int) []int {
+		data := make([]int, to)
+		for i := range data {
+			data[i] = i
+		}
+		return data
+	}
+
+	// the batches which timed out are delivered again once the subscriber recovered
+	deliveries.blocked.Store(true)
+	publish(0, 5)
+	time.Sleep(500 * time.Millisecond)
+	deliveries.blocked.Store(false)
+	WaitUntil(t, func() bool {
+		return len(deliveries.received()) == 5
+	}, "the subscriber did not receive all the data", DefaultWaitTimeout)
+	assert.Equal(t, expected(5), deliveries.received())
+
+	// the topic restarted on another activation resumes from the committed offset of the subscriber
+	topicPid := member.Get(topic, cluster.TopicActorKind)
+	require.NotNil(t, topicPid)
+	deliveries.blocked.Store(true)
+	publish(5, 8)
+	require.NoError(t, member.ActorSystem.Root.StopFuture(topicPid).Wait())
+	deliveries.blocked.Store(false)
+	publish(8, 10)
+	WaitUntil(t, func() bool {
+		return len(deliveries.received()) == 10
+	}, "the subscriber did not receive the data published before the topic restarted", DefaultWaitTimeout)
+	assert.Equal(t, expected(10), deliveries.received())
+
+	// the log is truncated once all subscribers committed their offset
+	WaitUntil(t, func() bool {
+		entries, err := store.Read(context.Background(), topic, 0, 10)
+		return err == nil && len(entries) == 0
+	}, "the log of the topic was not truncated", DefaultWaitTimeout)
+
+	// other topics are not durable
+	_, err = member.Publisher().Publish(context.Background(), "other-topic", &DataPublished{Data: 1})
+	require.NoError(t, err)
+	last, err := store.LastOffset(context.Background(), "other-topic")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), last)
+}
@@ -0,0 +1,70 @@
+package cluster_test_tool
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/cluster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSubscriptionFiltersAreEvaluatedByTheTopic(t *testing.T) {
+	const topic = "filtered-topic"
+	fixture := NewBaseInMemoryClusterFixture(1)
+	fixture.Initialize()
+	defer fixture.ShutDown()
+
+	member := fixture.GetMembers()[0]
+	var mu sync.Mutex
+	received := make(map[string][]proto.Message)
+	subscribe := func(name string, filter *cluster.SubscriptionFilter) {
+		pid := member.ActorSystem.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+			switch msg := ctx.Message().(type) {
+			case *DataPublished, *Response:
+				mu.Lock()
+				received[name] = append(received[name], msg.(proto.Message))
+				mu.Unlock()
+			}
+		}))
+		subscriber := &cluster.SubscriberIdentity{Identity: &cluster.SubscriberIdentity_Pid{Pid: pid}}
+		_, err := member.SubscribeWithFilter(topic, subscriber, filter)
+		require.NoError(t, err)
+	}
+	subscribe("all", nil)
+	subscribe("large", &cluster.SubscriptionFilter{Expression: "data > 2"})
+	subscribe("responses", &cluster.SubscriptionFilter{MessageTypes: []string{"cluster_test_tool.Response"}})
+	subscribe("none", &cluster.SubscriptionFilter{Expression: "data > 100"})
+
+	batch := &cluster.PubSubBatch{Envelopes: []proto.Message{
+		&DataPublished{Data: 1}, &DataPublished{Data: 3}, &Response{}, &DataPublished{Data: 4},
+	}}
+	_, err := member.Publisher().PublishBatch(context.Background(), topic, batch)
+	require.NoError(t, err)
+
+	WaitUntil(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(received["all"]) == 4 && len(received["large"]) == 2 && len(received["responses"]) == 1
+	}, "the subscribers did not receive the filtered messages", DefaultWaitTimeout)
+	time.Sleep(100 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []int32{3, 4}, []int32{received["large"][0].(*DataPublished).Data, received["large"][1].(*DataPublished).Data})
+	assert.IsType(t, &Response{}, received["responses"][0])
+	assert.Empty(t, received["none"])
+
+	// the topic rejects invalid filters
+	pid := member.ActorSystem.Root.Spawn(actor.PropsFromFunc(func(actor.Context) {}))
+	res, err := member.Request(topic, cluster.TopicActorKind, &cluster.SubscribeRequest{
+		Subscriber: &cluster.SubscriberIdentity{Identity: &cluster.SubscriberIdentity_Pid{Pid: pid}},
+		Filter:     &cluster.SubscriptionFilter{Expression: "data >"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, cluster.ErrorReason_INVALID_ARGUMENT, res.(*cluster.GrainErrorResponse).Reason)
+}
@@ -0,0 +1,77 @@
+package cluster_test_tool
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/cluster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartitionedTopicDeliversEachKeyInOrder(t *testing.T) {
+	const (
+		topic       = "partitioned-topic"
+		partitions  = 4
+		keys        = 5
+		perKey      = 20
+		subscribers = 2
+	)
+	fixture := NewBaseInMemoryClusterFixture(3, WithClusterConfigure(func(c *cluster.Config) *cluster.Config {
+		cluster.WithPubSubPartitionedTopic(topic, partitions)(c)
+		return c
+	}))
+	fixture.Initialize()
+	defer fixture.ShutDown()
+
+	members := fixture.GetMembers()
+	var mu sync.Mutex
+	received := make([]map[int][]int, subscribers)
+	for i := range received {
+		i := i
+		received[i] = make(map[int][]int)
+		_, err := members[i].SubscribeWithReceive(topic, func(ctx actor.Context) {
+			if msg, ok := ctx.Message().(*DataPublished); ok {
+				mu.Lock()
+				received[i][int(msg.Data)/perKey] = append(received[i][int(msg.Data)/perKey], int(msg.Data)%perKey)
+				mu.Unlock()
+			}
+		})
+		require.NoError(t, err)
+	}
+
+	producer := members[2].BatchingProducer(topic, cluster.WithBatchingProducerBatchSize(7))
+	defer producer.Dispose()
+	var infos []*cluster.ProduceProcessInfo
+	for i := 0; i < perKey; i++ {
+		for key := 0; key < keys; key++ {
+			info, err := producer.ProduceWithOrderingKey(context.Background(), fmt.Sprintf("key-%d", key), &DataPublished{Data: int32(key*perKey + i)})
+			require.NoError(t, err)
+			infos = append(infos, info)
+		}
+	}
+	for _, info := range infos {
+		<-info.Finished
+		require.NoError(t, info.Err)
+	}
+
+	expected := make(map[int][]int)
+	for key := 0; key < keys; key++ {
+		for i := 0; i < perKey; i++ {
+			expected[key] = append(expected[key], i)
+		}
+	}
+	WaitUntil(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, r := range received {
+			if !assert.ObjectsAreEqual(expected, r) {
+				return false
+			}
+		}
+		return true
+	}, "the subscribers did not receive the messages of each key in order", DefaultWaitTimeout)
+}
@@ -0,0 +1,81 @@
+package cluster_test_tool
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/cluster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWildcardSubscriptionsReceiveMatchingTopics(t *testing.T) {
+	fixture := NewBaseInMemoryClusterFixture(2)
+	fixture.Initialize()
+	defer fixture.ShutDown()
+
+	members := fixture.GetMembers()
+	var mu sync.Mutex
+	received := make(map[string][]int)
+	subscribe := func(member *cluster.Cluster, topic string) {
+		_, err := member.SubscribeWithReceive(topic, func(ctx actor.Context) {
+			if msg, ok := ctx.Message().(*DataPublished); ok {
+				mu.Lock()
+				received[topic] = append(received[topic], int(msg.Data))
+				mu.Unlock()
+			}
+		})
+		require.NoError(t, err)
+	}
+	subscribe(members[0], "orders.*")
+	subscribe(members[1], "orders.>")
+	subscribe(members[1], "orders.eu.de")
+
+	// the topics forward their batches once the patterns are gossiped to all members
+	for _, member := range members {
+		WaitUntil(t, func() bool {
+			state, err := member.Gossip.GetState(cluster.TopicPatternsKey)
+			if err != nil {
+				return false
+			}
+			var patterns []string
+			for _, value := range state {
+				gossiped := &cluster.TopicPatterns{}
+				if value.Value.UnmarshalTo(gossiped) == nil {
+					patterns = append(patterns, gossiped.Patterns...)
+				}
+			}
+			return len(patterns) == 2
+		}, "the patterns were not gossiped", DefaultWaitTimeout)
+	}
+
+	publish := func(topic string, data int) {
+		_, err := members[0].Publisher().Publish(context.Background(), topic, &DataPublished{Data: int32(data)})
+		require.NoError(t, err)
+	}
+	publish("orders.eu", 1)
+	publish("orders.eu.de", 2)
+	publish("payments.eu", 3)
+	publish("orders", 4)
+
+	expected := map[string][]int{
+		"orders.*":     {1},
+		"orders.>":     {1, 2},
+		"orders.eu.de": {2},
+	}
+	WaitUntil(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return assert.ObjectsAreEqual(expected, received)
+	}, "the subscribers did not receive the messages of the matching topics", DefaultWaitTimeout)
+	time.Sleep(200 * time.Millisecond)
+	mu.Lock()
+	assert.Equal(t, expected, received)
+	mu.Unlock()
+
+	_, err := members[0].Publisher().Publish(context.Background(), "orders.*", &DataPublished{Data: 5})
+	assert.ErrorIs(t, err, cluster.ErrPublishToTopicPattern)
+}
@@ -0,0 +1,86 @@
+package cluster_test_tool
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/cluster"
+	"github.com/asynkron/protoactor-go/protobuf/protoc-gen-go-grain/test/hello"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// failingHello fails the calls with the reason of the next failure, it succeeds once there are no more failures
+type failingHello struct {
+	countingHello
+	failures chan string
+}
+
+func (h *failingHello) SayHello(r *emptypb.Empty, ctx cluster.GrainContext) (*hello.SayHelloResponse, error) {
+	select {
+	case reason := <-h.failures:
+		h.runs.Add(1)
+		return nil, cluster.NewGrainErrorResponse(reason, "failed")
+	default:
+		return h.countingHello.SayHello(r, ctx)
+	}
+}
+
+func TestGrainCallsRetryAccordingToPolicy(t *testing.T) {
+	var runs atomic.Int32
+	failures := make(chan string, 10)
+	fixture := NewBaseInMemoryClusterFixture(2,
+		WithGetClusterKinds(func() []*cluster.Kind {
+			return []*cluster.Kind{
+				hello.NewHelloKind(func() hello.Hello {
+					return &failingHello{countingHello: countingHello{runs: &runs}, failures: failures}
+				}, 0),
+			}
+		}),
+		WithClusterConfigure(func(c *cluster.Config) *cluster.Config {
+			policy := cluster.DefaultRetryPolicy()
+			policy.InitialBackoff = 10 * time.Millisecond
+			policy.MaxAttempts = 4
+			c.RetryPolicy = policy
+			return c
+		}),
+	)
+	fixture.Initialize()
+	defer fixture.ShutDown()
+
+	client := hello.GetHelloGrainClient(fixture.GetMembers()[0], "retried")
+
+	// transient failures are retried by the cluster policy
+	failures <- cluster.ErrorReason_UNAVAILABLE
+	failures <- cluster.ErrorReason_RESOURCE_EXHAUSTED
+	res, err := client.SayHello(&emptypb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, "3", res.Message)
+
+	// other failures are returned at once
+	failures <- cluster.ErrorReason_INVALID_ARGUMENT
+	_, err = client.SayHello(&emptypb.Empty{})
+	assert.Equal(t, cluster.ErrorReason_INVALID_ARGUMENT, cluster.Reason(err))
+	assert.Equal(t, int32(4), runs.Load())
+
+	// the policy of the call replaces the policy of the cluster
+	failures <- cluster.ErrorReason_UNAVAILABLE
+	_, err = client.SayHello(&emptypb.Empty{}, cluster.WithRetryPolicy(&cluster.RetryPolicy{MaxAttempts: 1}))
+	assert.Equal(t, cluster.ErrorReason_UNAVAILABLE, cluster.Reason(err))
+
+	// the deadline stops the retries
+	for i := 0; i < 5; i++ {
+		failures <- cluster.ErrorReason_UNAVAILABLE
+	}
+	start := time.Now()
+	_, err = client.SayHello(&emptypb.Empty{}, cluster.WithRetryPolicy(&cluster.RetryPolicy{
+		InitialBackoff:   50 * time.Millisecond,
+		Deadline:         125 * time.Millisecond,
+		RetryableReasons: []string{cluster.ErrorReason_UNAVAILABLE},
+	}))
+	assert.Equal(t, cluster.ErrorReason_UNAVAILABLE, cluster.Reason(err))
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, 2, len(failures), "two retries fit in the deadline")
+}
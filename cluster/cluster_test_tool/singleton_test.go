@@ -0,0 +1,111 @@
+package cluster_test_tool
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/cluster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const singletonKind = "singleton-test"
+
+// newSingletonFixture returns a fixture hosting the singleton kind, which responds with the address of its host
+func newSingletonFixture(running *atomic.Int32) *BaseClusterFixture {
+	return NewBaseInMemoryClusterFixture(3, WithGetClusterKinds(func() []*cluster.Kind {
+		return []*cluster.Kind{
+			cluster.NewKind(singletonKind, actor.PropsFromFunc(func(ctx actor.Context) {
+				switch ctx.Message().(type) {
+				case *actor.Started:
+					running.Add(1)
+				case *actor.Stopped:
+					running.Add(-1)
+				case *wrapperspb.StringValue:
+					ctx.Respond(wrapperspb.String(ctx.ActorSystem().Address()))
+				}
+			})).AsSingleton(),
+		}
+	}))
+}
+
+// singletonHost returns the address of the member hosting the singleton, as seen by the member
+func singletonHost(t *testing.T, c *cluster.Cluster) string {
+	proxy, err := c.Singletons.Proxy(singletonKind)
+	require.NoError(t, err)
+
+	res, err := c.ActorSystem.Root.RequestFuture(proxy, &wrapperspb.StringValue{}, 5*time.Second).Result()
+	if err != nil {
+		return ""
+	}
+	return res.(*wrapperspb.StringValue).Value
+}
+
+func TestSingletonFailsOverWhenHostLeaves(t *testing.T) {
+	var running atomic.Int32
+	fixture := newSingletonFixture(&running)
+	fixture.Initialize()
+	defer fixture.ShutDown()
+
+	host := func(c *cluster.Cluster) string { return singletonHost(t, c) }
+
+	members := fixture.GetMembers()
+	first := host(members[0])
+	require.NotEmpty(t, first)
+	for _, member := range members[1:] {
+		assert.Equal(t, first, host(member))
+	}
+	assert.Equal(t, int32(1), running.Load())
+
+	for _, member := range members {
+		if member.ActorSystem.Address() == first {
+			fixture.RemoveNode(member, true)
+			break
+		}
+	}
+
+	WaitUntil(t, func() bool {
+		for _, member := range fixture.GetMembers() {
+			if next := host(member); next == "" || next == first {
+				return false
+			}
+		}
+		return true
+	}, "singleton was not moved to another member", 20*time.Second)
+	assert.Equal(t, int32(1), running.Load())
+}
+
+func TestSingletonMovesWhenHostDrains(t *testing.T) {
+	var running atomic.Int32
+	fixture := newSingletonFixture(&running)
+	fixture.Initialize()
+	defer fixture.ShutDown()
+
+	members := fixture.GetMembers()
+	first := singletonHost(t, members[0])
+	require.NotEmpty(t, first)
+
+	var drained *cluster.Cluster
+	for _, member := range members {
+		if member.ActorSystem.Address() == first {
+			drained = member
+			break
+		}
+	}
+	require.NoError(t, drained.Drain(context.Background()))
+
+	// the member is still part of the cluster, its proxy forwards to the new host
+	WaitUntil(t, func() bool {
+		for _, member := range members {
+			if next := singletonHost(t, member); next == "" || next == first {
+				return false
+			}
+		}
+		return true
+	}, "singleton was not moved away from the draining member", 20*time.Second)
+	assert.Equal(t, int32(1), running.Load())
+}
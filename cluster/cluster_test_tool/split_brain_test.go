@@ -0,0 +1,100 @@
+package cluster_test_tool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/cluster"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSplitBrainFixture(clusterSize int, strategy cluster.SplitBrainStrategy) (*BaseClusterFixture, *PartitionableNetwork) {
+	network := NewPartitionableNetwork()
+	fixture := NewBaseInMemoryClusterFixture(clusterSize, WithPartitionableNetwork(network), WithClusterConfigure(func(c *cluster.Config) *cluster.Config {
+		cluster.WithHeartbeatExpiration(4 * time.Second)(c)
+		cluster.WithSplitBrainResolver(strategy, time.Second)(c)
+		return c
+	}))
+	fixture.Initialize()
+
+	return fixture, network
+}
+
+// waitForPartitionResolved waits until the downed members are shut down and the topology of each kept member
+// only contains the kept members
+func waitForPartitionResolved(t *testing.T, kept []*cluster.Cluster, downed []*cluster.Cluster) {
+	t.Helper()
+
+	WaitUntil(t, func() bool {
+		for _, member := range downed {
+			if !member.ActorSystem.IsStopped() {
+				return false
+			}
+		}
+		for _, member := range kept {
+			if member.ActorSystem.IsStopped() || member.MemberList.Length() != len(kept) {
+				return false
+			}
+		}
+		return true
+	}, "the network partition was not resolved", 30*time.Second)
+
+	for _, member := range kept {
+		assert.False(t, member.ActorSystem.IsStopped(), "kept member %s is down", member.ActorSystem.ID)
+		for _, other := range kept {
+			assert.True(t, member.MemberList.ContainsMemberID(other.ActorSystem.ID))
+		}
+		for _, other := range downed {
+			assert.True(t, member.Remote.BlockList().IsBlocked(other.ActorSystem.ID))
+		}
+	}
+}
+
+func TestSplitBrainKeepMajorityDownsTheMinority(t *testing.T) {
+	fixture, network := newSplitBrainFixture(5, cluster.KeepMajority)
+	defer fixture.ShutDown()
+
+	members := fixture.GetMembers()
+	majority, minority := members[:3], members[3:]
+	network.Partition(majority, minority)
+
+	waitForPartitionResolved(t, majority, minority)
+}
+
+func TestSplitBrainKeepOldestKeepsTheSideOfTheOldestMember(t *testing.T) {
+	fixture, network := newSplitBrainFixture(3, cluster.KeepOldest)
+	defer fixture.ShutDown()
+
+	// the members started in order, the oldest is alone on its side
+	members := fixture.GetMembers()
+	oldest, others := members[:1], members[1:]
+	network.Partition(oldest, others)
+
+	waitForPartitionResolved(t, oldest, others)
+}
+
+func TestSplitBrainKeepRefereeKeepsTheSideOfTheReferee(t *testing.T) {
+	var referee string
+	fixture, network := newSplitBrainFixture(3, func(partition *cluster.NetworkPartition) bool {
+		return cluster.KeepReferee(referee)(partition)
+	})
+	defer fixture.ShutDown()
+
+	members := fixture.GetMembers()
+	referee = members[2].ActorSystem.Address()
+	others, refereeSide := members[:2], members[2:]
+	network.Partition(others, refereeSide)
+
+	waitForPartitionResolved(t, refereeSide, others)
+}
+
+func TestSplitBrainStaticQuorumDownsTheSidesWithoutQuorum(t *testing.T) {
+	fixture, network := newSplitBrainFixture(4, cluster.StaticQuorum(3))
+	defer fixture.ShutDown()
+
+	// no side has the quorum, the whole cluster is downed
+	members := fixture.GetMembers()
+	network.Partition(members[:2], members[2:])
+
+	waitForPartitionResolved(t, nil, members)
+}
@@ -16,6 +16,7 @@ func WaitUntil(t testing.TB, cond func() bool, errorMsg string, timeout time.Dur
 		case <-after:
 			t.Error(errorMsg)
 			debug.PrintStack()
+			return
 		default:
 			if cond() {
 				return
@@ -39,6 +39,7 @@ type AutoManagedProvider struct {
 	autoManagePort        int
 	memberPort            int
 	knownKinds            []string
+	knownLabels           map[string]string
 	knownNodes            []*NodeModel
 	hosts                 []string
 	refreshTTL            time.Duration
@@ -105,6 +106,7 @@ func (p *AutoManagedProvider) init(cluster *cluster.Cluster) error {
 	p.address = host
 	p.memberPort = port
 	p.knownKinds = cluster.GetClusterKinds()
+	p.knownLabels = cluster.Labels()
 	p.deregistered = false
 	p.shutdown = false
 	p.cluster = cluster
@@ -246,10 +248,11 @@ func (p *AutoManagedProvider) monitorStatuses() {
 			continue
 		}
 		ms := &cluster.Member{
-			Id:    node.ID,
-			Host:  node.Address,
-			Port:  int32(node.Port),
-			Kinds: node.Kinds,
+			Id:     node.ID,
+			Host:   node.Address,
+			Port:   int32(node.Port),
+			Kinds:  node.Kinds,
+			Labels: node.Labels,
 		}
 		members = append(members, ms)
 		newNodes = append(newNodes, node)
@@ -380,5 +383,8 @@ func (p *AutoManagedProvider) isActiveProviderRunning() bool {
 }
 
 func (p *AutoManagedProvider) getCurrentNode() *NodeModel {
-	return NewNode(p.clusterName, p.cluster.ActorSystem.ID, p.address, p.memberPort, p.autoManagePort, p.knownKinds)
+	node := NewNode(p.clusterName, p.cluster.ActorSystem.ID, p.address, p.memberPort, p.autoManagePort, p.knownKinds)
+	node.Labels = p.knownLabels
+
+	return node
 }
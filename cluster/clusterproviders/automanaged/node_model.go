@@ -2,12 +2,13 @@ package automanaged
 
 // NodeModel represents a node in the cluster
 type NodeModel struct {
-	ID             string   `json:"id"`
-	Address        string   `json:"address"`
-	AutoManagePort int      `json:"auto_manage_port"`
-	Port           int      `json:"port"`
-	Kinds          []string `json:"kinds"`
-	ClusterName    string   `json:"cluster_name"`
+	ID             string            `json:"id"`
+	Address        string            `json:"address"`
+	AutoManagePort int               `json:"auto_manage_port"`
+	Port           int               `json:"port"`
+	Kinds          []string          `json:"kinds"`
+	Labels         map[string]string `json:"labels,omitempty"`
+	ClusterName    string            `json:"cluster_name"`
 }
 
 // NewNode returns a new node for the cluster
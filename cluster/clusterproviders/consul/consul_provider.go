@@ -3,6 +3,8 @@ package consul
 import (
 	"fmt"
 	"log/slog"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -23,6 +25,7 @@ type Provider struct {
 	address            string
 	port               int
 	knownKinds         []string
+	knownLabels        map[string]string
 	index              uint64 // consul blocking index
 	client             *api.Client
 	ttl                time.Duration
@@ -74,6 +77,7 @@ func (p *Provider) init(c *cluster.Cluster) error {
 	p.address = host
 	p.port = port
 	p.knownKinds = knownKinds
+	p.knownLabels = c.Labels()
 	return nil
 }
 
@@ -134,15 +138,18 @@ func blockingUpdateTTL(p *Provider) error {
 }
 
 func (p *Provider) registerService() error {
+	meta, err := labelsToMeta(p.id, p.knownLabels)
+	if err != nil {
+		return err
+	}
+
 	s := &api.AgentServiceRegistration{
 		ID:      p.id,
 		Name:    p.clusterName,
 		Tags:    p.knownKinds,
 		Address: p.address,
 		Port:    p.port,
-		Meta: map[string]string{
-			"id": p.id,
-		},
+		Meta:    meta,
 		Check: &api.AgentServiceCheck{
 			DeregisterCriticalServiceAfter: p.deregisterCritical.String(),
 			TTL:                            p.ttl.String(),
@@ -151,6 +158,73 @@ func (p *Provider) registerService() error {
 	return p.client.Agent().ServiceRegister(s)
 }
 
+const (
+	// labelMetaPrefix prefixes the labels of the member in the service meta
+	labelMetaPrefix = "label-"
+	// maxMetaKeyLength is the longest meta key consul accepts
+	maxMetaKeyLength = 128
+)
+
+// labelsToMeta returns the service meta of the member. Consul only accepts the meta keys made of letters, digits,
+// '-' and '_', the other characters of the label keys are escaped, see encodeMetaKey.
+func labelsToMeta(id string, labels map[string]string) (map[string]string, error) {
+	meta := map[string]string{
+		"id": id,
+	}
+	for k, v := range labels {
+		key := labelMetaPrefix + encodeMetaKey(k)
+		if len(key) > maxMetaKeyLength {
+			return nil, fmt.Errorf("the label key %q is too long for the consul service meta", k)
+		}
+		meta[key] = v
+	}
+
+	return meta, nil
+}
+
+func labelsFromMeta(meta map[string]string) map[string]string {
+	labels := make(map[string]string)
+	for k, v := range meta {
+		if strings.HasPrefix(k, labelMetaPrefix) {
+			labels[decodeMetaKey(strings.TrimPrefix(k, labelMetaPrefix))] = v
+		}
+	}
+
+	return labels
+}
+
+// encodeMetaKey escapes the characters of the key other than letters, digits and '-' as '_' followed by their
+// hexadecimal code, e.g. "topology.kubernetes.io/zone" is "topology_2Ekubernetes_2Eio_2Fzone"
+func encodeMetaKey(key string) string {
+	var b strings.Builder
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		if c == '-' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9') {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "_%02X", c)
+		}
+	}
+
+	return b.String()
+}
+
+func decodeMetaKey(key string) string {
+	var b strings.Builder
+	for i := 0; i < len(key); i++ {
+		if key[i] == '_' && i+2 < len(key) {
+			if c, err := strconv.ParseUint(key[i+1:i+3], 16, 8); err == nil {
+				b.WriteByte(byte(c))
+				i += 2
+				continue
+			}
+		}
+		b.WriteByte(key[i])
+	}
+
+	return b.String()
+}
+
 func (p *Provider) deregisterService() error {
 	return p.client.Agent().ServiceDeregister(p.id)
 }
@@ -182,10 +256,11 @@ func (p *Provider) notifyStatuses() {
 				p.cluster.Logger().Info("meta['id'] was empty, fixeds", slog.String("id", memberId))
 			}
 			members = append(members, &cluster.Member{
-				Id:    memberId,
-				Host:  v.Service.Address,
-				Port:  int32(v.Service.Port),
-				Kinds: v.Service.Tags,
+				Id:     memberId,
+				Host:   v.Service.Address,
+				Port:   int32(v.Service.Port),
+				Kinds:  v.Service.Tags,
+				Labels: labelsFromMeta(v.Service.Meta),
 			})
 		}
 	}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -163,6 +164,34 @@ func TestUpdateTTL_DoesNotReregisterAfterShutdown(t *testing.T) {
 	a.Falsef(found, "service was still registered in consul after shutdown (service status: %s)", status)
 }
 
+func TestLabelsMeta(t *testing.T) {
+	labels := map[string]string{cluster.RoleLabel: "worker", cluster.ZoneLabel: "eu-1"}
+	meta, err := labelsToMeta("member-1", labels)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"id": "member-1", "label-role": "worker", "label-zone": "eu-1"}, meta)
+	assert.Equal(t, labels, labelsFromMeta(meta))
+}
+
+func TestLabelsMetaEscapesKeys(t *testing.T) {
+	labels := map[string]string{"topology.kubernetes.io/zone": "eu-west-1a", "app_version": "1.2"}
+	meta, err := labelsToMeta("member-1", labels)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"id": "member-1",
+		"label-topology_2Ekubernetes_2Eio_2Fzone": "eu-west-1a",
+		"label-app_5Fversion":                     "1.2",
+	}, meta)
+	for key := range meta {
+		assert.Regexp(t, "^[A-Za-z0-9_-]+$", key)
+	}
+	assert.Equal(t, labels, labelsFromMeta(meta))
+
+	_, err = labelsToMeta("member-1", map[string]string{strings.Repeat("a.", 60) + "io/zone": "eu-1"})
+	assert.Error(t, err)
+}
+
 func findService(t *testing.T, p *Provider) (found bool, status string) {
 	service := p.cluster.Config.Name
 	port := p.cluster.Config.RemoteConfig.Port
@@ -115,10 +115,11 @@ func (pa *providerActor) processConsulUpdate(index uint64, result interface{}, c
 				ctx.Logger().Info("meta['id'] was empty, fixed", slog.String("id", memberId))
 			}
 			members = append(members, &cluster.Member{
-				Id:    memberId,
-				Host:  v.Service.Address,
-				Port:  int32(v.Service.Port),
-				Kinds: v.Service.Tags,
+				Id:     memberId,
+				Host:   v.Service.Address,
+				Port:   int32(v.Service.Port),
+				Kinds:  v.Service.Tags,
+				Labels: labelsFromMeta(v.Service.Meta),
 			})
 		}
 	}
@@ -70,6 +70,7 @@ func (p *Provider) init(c *cluster.Cluster) error {
 	knownKinds := c.GetClusterKinds()
 	nodeName := fmt.Sprintf("%v@%v", p.clusterName, memberID)
 	p.self = NewNode(nodeName, host, port, knownKinds)
+	p.self.Labels = c.Labels()
 	p.self.SetMeta("id", p.getID())
 	return nil
 }
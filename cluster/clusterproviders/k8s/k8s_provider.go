@@ -38,6 +38,7 @@ type Provider struct {
 	address        string
 	namespace      string
 	knownKinds     []string
+	knownLabels    map[string]string
 	clusterPods    map[types.UID]*v1.Pod
 	port           int
 	client         *kubernetes.Clientset
@@ -90,6 +91,7 @@ func (p *Provider) init(c *cluster.Cluster) error {
 	p.cluster = c
 	p.id = strings.Replace(uuid.New().String(), "-", "", -1)
 	p.knownKinds = c.GetClusterKinds()
+	p.knownLabels = c.Labels()
 	p.clusterName = c.Config.Name
 	p.clusterPods = make(map[types.UID]*v1.Pod)
 	p.host = host
@@ -206,9 +208,34 @@ func (p *Provider) registerMember(timeout time.Duration) error {
 	}
 	pod.SetLabels(labels)
 
+	if err := annotateMemberLabels(pod, p.knownLabels); err != nil {
+		return err
+	}
+
 	return p.replacePodLabels(ctx, pod)
 }
 
+// annotateMemberLabels sets the labels of the member in the AnnotationMemberLabels of the pod
+func annotateMemberLabels(pod *v1.Pod, memberLabels map[string]string) error {
+	if len(memberLabels) == 0 {
+		return nil
+	}
+
+	value, err := json.Marshal(memberLabels)
+	if err != nil {
+		return fmt.Errorf("unable to encode the labels of the member: %w", err)
+	}
+
+	annotations := pod.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[AnnotationMemberLabels] = string(value)
+	pod.SetAnnotations(annotations)
+
+	return nil
+}
+
 func (p *Provider) startWatchingClusterAsync(c *cluster.Cluster) {
 	msg := StartWatchingCluster{p.clusterName}
 	c.ActorSystem.Root.Send(p.clusterMonitor, &msg)
@@ -318,11 +345,17 @@ func mapPodsToMembers(clusterPods map[types.UID]*v1.Pod, logger *slog.Logger) []
 		if clusterPod.Status.Phase == "Running" && len(clusterPod.Status.PodIPs) > 0 {
 
 			var kinds []string
+			labels := make(map[string]string)
 			for key, value := range clusterPod.ObjectMeta.Labels {
 				if strings.HasPrefix(key, LabelKind) && value == "true" {
 					kinds = append(kinds, strings.Replace(key, fmt.Sprintf("%s-", LabelKind), "", 1))
 				}
 			}
+			if memberLabels, ok := clusterPod.ObjectMeta.Annotations[AnnotationMemberLabels]; ok {
+				if err := json.Unmarshal([]byte(memberLabels), &labels); err != nil {
+					logger.Error("Can not decode the labels of the member", slog.String("podName", clusterPod.ObjectMeta.Name), slog.Any("error", err))
+				}
+			}
 
 			host := clusterPod.Status.PodIP
 			port, err := strconv.Atoi(clusterPod.ObjectMeta.Labels[LabelPort])
@@ -349,10 +382,11 @@ func mapPodsToMembers(clusterPods map[types.UID]*v1.Pod, logger *slog.Logger) []
 			logger.Debug("Pod is running and all containers are ready", slog.String("podName", clusterPod.ObjectMeta.Name), slog.Any("podIPs", clusterPod.Status.PodIPs), slog.String("podPhase", string(clusterPod.Status.Phase)))
 
 			members = append(members, &cluster.Member{
-				Id:    mid,
-				Host:  host,
-				Port:  int32(port),
-				Kinds: kinds,
+				Id:     mid,
+				Host:   host,
+				Port:   int32(port),
+				Kinds:  kinds,
+				Labels: labels,
 			})
 		} else {
 			logger.Debug("Pod is not in Running state", slog.String("podName", clusterPod.ObjectMeta.Name), slog.Any("podIPs", clusterPod.Status.PodIPs), slog.String("podPhase", string(clusterPod.Status.Phase)))
@@ -384,24 +418,38 @@ func (p *Provider) deregisterMember(timeout time.Duration) error {
 
 	pod.SetLabels(labels)
 
+	if annotations := pod.GetAnnotations(); annotations != nil {
+		delete(annotations, AnnotationMemberLabels)
+		pod.SetAnnotations(annotations)
+	}
+
 	return p.replacePodLabels(ctx, pod)
 }
 
-// prepares a patching payload and sends it to kubernetes to replace labels
+// prepares a patching payload and sends it to kubernetes to replace labels and annotations
 func (p *Provider) replacePodLabels(ctx context.Context, pod *v1.Pod) error {
-	p.cluster.Logger().Debug("Setting pod labels to ", slog.Any("labels", pod.GetLabels()))
+	p.cluster.Logger().Debug("Setting pod labels to ", slog.Any("labels", pod.GetLabels()), slog.Any("annotations", pod.GetAnnotations()))
 
-	payload := []struct {
+	type operation struct {
 		Op    string `json:"op"`
 		Path  string `json:"path"`
 		Value Labels `json:"value"`
-	}{
+	}
+	payload := []operation{
 		{
 			Op:    "replace",
 			Path:  "/metadata/labels",
 			Value: pod.GetLabels(),
 		},
 	}
+	if annotations := pod.GetAnnotations(); annotations != nil {
+		// add replaces the annotations, and creates them when the pod has none
+		payload = append(payload, operation{
+			Op:    "add",
+			Path:  "/metadata/annotations",
+			Value: annotations,
+		})
+	}
 
 	payloadData, err := json.Marshal(payload)
 	if err != nil {
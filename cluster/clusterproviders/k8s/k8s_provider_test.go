@@ -3,9 +3,11 @@ package k8s
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"net"
 	"os"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,7 +17,9 @@ import (
 	"github.com/asynkron/protoactor-go/cluster"
 	"github.com/asynkron/protoactor-go/remote"
 	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 func newClusterForTest(name string, addr string, cp cluster.ClusterProvider, id cluster.IdentityLookup) *cluster.Cluster {
@@ -172,3 +176,65 @@ func TestUpdateMemberState_DoesNotReregisterAfterShutdown(t *testing.T) {
 
 	assert.Equal(ProviderShuttingDownError, err)
 }
+
+func TestMapPodsToMembers(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pod1",
+			Labels: map[string]string{
+				LabelCluster:         "mycluster",
+				LabelPort:            "8090",
+				LabelMemberID:        "member-1",
+				LabelKind + "-kind1": "true",
+				"app":                "myapp",
+			},
+			Annotations: map[string]string{
+				AnnotationMemberLabels: `{"role":"worker","topology.kubernetes.io/zone":"eu-1"}`,
+			},
+		},
+		Status: v1.PodStatus{
+			Phase:             v1.PodRunning,
+			PodIP:             "10.0.0.1",
+			PodIPs:            []v1.PodIP{{IP: "10.0.0.1"}},
+			ContainerStatuses: []v1.ContainerStatus{{Ready: true}},
+		},
+	}
+
+	members := mapPodsToMembers(map[types.UID]*v1.Pod{"pod1": pod}, slog.Default())
+
+	assert.Len(t, members, 1)
+	assert.Equal(t, "member-1", members[0].Id)
+	assert.Equal(t, []string{"kind1"}, members[0].Kinds)
+	assert.Equal(t, map[string]string{cluster.RoleLabel: "worker", "topology.kubernetes.io/zone": "eu-1"}, members[0].Labels)
+}
+
+func TestAnnotateMemberLabels(t *testing.T) {
+	labels := map[string]string{cluster.RoleLabel: "worker", "topology.kubernetes.io/zone": "eu-west-1a"}
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pod1",
+			Labels: map[string]string{
+				LabelCluster:  "mycluster",
+				LabelPort:     "8090",
+				LabelMemberID: "member-1",
+			},
+		},
+		Status: v1.PodStatus{
+			Phase:             v1.PodRunning,
+			PodIP:             "10.0.0.1",
+			PodIPs:            []v1.PodIP{{IP: "10.0.0.1"}},
+			ContainerStatuses: []v1.ContainerStatus{{Ready: true}},
+		},
+	}
+
+	assert.NoError(t, annotateMemberLabels(pod, labels))
+	// the pod labels stay valid kubernetes labels, the label keys of the member are only annotated
+	for key := range pod.GetLabels() {
+		assert.Equal(t, 1, strings.Count(key, "/"), key)
+	}
+	assert.Contains(t, pod.GetAnnotations(), AnnotationMemberLabels)
+
+	members := mapPodsToMembers(map[types.UID]*v1.Pod{"pod1": pod}, slog.Default())
+	assert.Len(t, members, 1)
+	assert.Equal(t, labels, members[0].Labels)
+}
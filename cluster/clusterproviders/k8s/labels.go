@@ -9,3 +9,8 @@ const (
 	LabelStatusValue = LabelPrefix + "status-value"
 	LabelMemberID    = LabelPrefix + "member-id"
 )
+
+// AnnotationMemberLabels is the pod annotation holding the labels of the member as a JSON object. The labels are
+// annotated rather than added to the pod labels, whose keys can not nest a label key such as
+// "topology.kubernetes.io/zone".
+const AnnotationMemberLabels = LabelPrefix + "member-labels"
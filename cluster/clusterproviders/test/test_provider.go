@@ -79,7 +79,9 @@ func (t *Provider) StartMember(c *cluster.Cluster) error {
 	t.id = c.ActorSystem.ID
 	t.startTtlReport()
 	t.agent.SubscribeStatusUpdate(t.notifyStatuses)
-	t.agent.RegisterService(NewAgentServiceStatus(t.id, host, port, kinds))
+	status := NewAgentServiceStatus(t.id, host, port, kinds)
+	status.Labels = c.Labels()
+	t.agent.RegisterService(status)
 	return nil
 }
 
@@ -109,12 +111,17 @@ func (t *Provider) notifyStatuses() {
 	for _, status := range statuses {
 		copiedKinds := make([]string, 0, len(status.Kinds))
 		copiedKinds = append(copiedKinds, status.Kinds...)
+		copiedLabels := make(map[string]string, len(status.Labels))
+		for k, v := range status.Labels {
+			copiedLabels[k] = v
+		}
 
 		members = append(members, &cluster.Member{
-			Id:    status.ID,
-			Port:  int32(status.Port),
-			Host:  status.Host,
-			Kinds: copiedKinds,
+			Id:     status.ID,
+			Port:   int32(status.Port),
+			Host:   status.Host,
+			Kinds:  copiedKinds,
+			Labels: copiedLabels,
 		})
 	}
 	t.memberList.UpdateClusterTopology(members)
@@ -203,11 +210,12 @@ func (m *InMemAgent) onStatusUpdate() {
 }
 
 type AgentServiceStatus struct {
-	ID    string
-	TTL   time.Time // last alive time
-	Host  string
-	Port  int
-	Kinds []string
+	ID     string
+	TTL    time.Time // last alive time
+	Host   string
+	Port   int
+	Kinds  []string
+	Labels map[string]string
 }
 
 // NewAgentServiceStatus creates a new AgentServiceStatus.
@@ -84,6 +84,12 @@ func (suite *MiscTestSuite) TestNode() {
 	suite.Nil(err)
 	node.Meta = nil
 	suite.Equal(node2, node)
+
+	node.Labels = map[string]string{cluster.RoleLabel: "worker"}
+	suite.Equal(map[string]string{"role": "worker"}, node.MemberStatus().Labels)
+	data, err = node.Serialize()
+	suite.Nil(err)
+	suite.Contains(string(data), `"labels":{"role":"worker"}`)
 }
 
 type MiscTestSuite struct {
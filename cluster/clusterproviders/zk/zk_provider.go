@@ -101,6 +101,7 @@ func (p *Provider) init(c *cluster.Cluster) error {
 	knownKinds := c.GetClusterKinds()
 	nodeName := fmt.Sprintf("%v@%v:%v", p.clusterName, host, port)
 	p.self = NewNode(nodeName, host, port, knownKinds)
+	p.self.Labels = c.Labels()
 	p.self.SetMeta(metaKeyID, p.getID())
 
 	if err = p.createClusterNode(p.clusterKey); err != nil {
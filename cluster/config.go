@@ -1,6 +1,7 @@
 package cluster
 
 import (
+	"errors"
 	"log/slog"
 	"time"
 
@@ -28,7 +29,18 @@ type Config struct {
 	GossipFanOut                                 int
 	GossipMaxSend                                int
 	HeartbeatExpiration                          time.Duration // Gossip heartbeat timeout. If the member does not update its heartbeat within this period, it will be added to the BlockList
+	GossipMaxMessageSize                         int           // size in bytes of the gossip entries sent in a message, the remaining entries are sent in the next rounds, 0 means no limit
+	GossipAntiEntropyInterval                    time.Duration // interval of the digest exchanges with a random member, which repair the entries missed by the gossip, 0 disables them
 	PubSubConfig                                 *PubSubConfig
+	SingletonConfig                              *SingletonConfig
+	LeaderSelector                               LeaderSelector
+	RetryPolicy                                  *RetryPolicy  // retry policy of the grain calls, nil retries with the RetryCount of the call
+	DrainTimeout                                 time.Duration // time the activations get to complete their requests when the member drains, see Cluster.Drain
+	SplitBrainResolverConfig                     *SplitBrainResolverConfig
+	Labels                                       map[string]string // labels of the member, e.g. role, zone or version, propagated by the ClusterProvider and the gossip
+
+	// errs are the errors of the options, see Validate
+	errs []error
 }
 
 func Configure(clusterName string, clusterProvider ClusterProvider, identityLookup IdentityLookup, remoteConfig *remote.Config, options ...ConfigOption) *Config {
@@ -43,13 +55,20 @@ func Configure(clusterName string, clusterProvider ClusterProvider, identityLook
 		Kinds:                     make(map[string]*Kind),
 		ClusterContextProducer:    newDefaultClusterContext,
 		MaxNumberOfEventsInRequestLogThrottledPeriod: defaultMaxNumberOfEvetsInRequestLogThrottledPeriod,
-		TimeoutTime:          time.Second * 5,
-		GossipInterval:       time.Millisecond * 300,
-		GossipRequestTimeout: time.Millisecond * 500,
-		GossipFanOut:         3,
-		GossipMaxSend:        50,
-		HeartbeatExpiration:  time.Second * 20,
-		PubSubConfig:         newPubSubConfig(),
+		TimeoutTime:               time.Second * 5,
+		GossipInterval:            time.Millisecond * 300,
+		GossipRequestTimeout:      time.Millisecond * 500,
+		GossipFanOut:              3,
+		GossipMaxSend:             50,
+		HeartbeatExpiration:       time.Second * 20,
+		GossipMaxMessageSize:      1024 * 1024,
+		GossipAntiEntropyInterval: time.Second * 5,
+		PubSubConfig:              newPubSubConfig(),
+		SingletonConfig:           newSingletonConfig(),
+		LeaderSelector:            LowestIDLeaderSelector,
+		DrainTimeout:              time.Second * 20,
+		SplitBrainResolverConfig:  newSplitBrainResolverConfig(),
+		Labels:                    make(map[string]string),
 	}
 
 	for _, option := range options {
@@ -59,6 +78,24 @@ func Configure(clusterName string, clusterProvider ClusterProvider, identityLook
 	return config
 }
 
+// Validate returns the errors of the options applied to the config, e.g. an invalid member label, and the
+// invalid labels of remote.WithLabels which the member would carry
+func (c *Config) Validate() error {
+	errs := append([]error(nil), c.errs...)
+	if c.RemoteConfig != nil {
+		for k, v := range c.RemoteConfig.Labels {
+			if _, overridden := c.Labels[k]; overridden {
+				continue
+			}
+			if err := ValidateLabel(k, v); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
 // ToClusterContextConfig converts this cluster Config Context parameters
 // into a valid ClusterContextConfig value and returns a pointer to its memory
 func (c *Config) ToClusterContextConfig(logger *slog.Logger) *ClusterContextConfig {
@@ -95,7 +132,15 @@ func withClusterReceiveMiddleware() actor.PropsOption {
 				handleStarted(c, next, envelope)
 			case *actor.Stopped:
 				handleStopped(c, next, envelope)
+			case *GrainHandoff:
+				handleGrainHandoff(c, next, envelope)
+			case actor.AutoReceiveMessage, actor.SystemMessage, *actor.ReceiveTimeout:
+				next(c, envelope)
 			default:
+				if isHandedOff(c) {
+					forwardHandedOff(c, envelope)
+					return
+				}
 				next(c, envelope)
 			}
 
@@ -105,13 +150,13 @@ func withClusterReceiveMiddleware() actor.PropsOption {
 }
 
 func handleStopped(c actor.ReceiverContext, next actor.ReceiverFunc, envelope *actor.MessageEnvelope) {
-	/*
-	   clusterKind.Dec();
-	*/
 	cl := GetCluster(c.ActorSystem())
 	identity := GetClusterIdentity(c)
 
 	if identity != nil {
+		if clusterKind, ok := cl.TryGetClusterKind(identity.Kind); ok {
+			clusterKind.deactivated(c.Self())
+		}
 		cl.ActorSystem.EventStream.Publish(&ActivationTerminating{
 			Pid:             c.Self(),
 			ClusterIdentity: identity,
@@ -127,9 +172,16 @@ func handleStarted(c actor.ReceiverContext, next actor.ReceiverFunc, envelope *a
 	cl := GetCluster(c.ActorSystem())
 	identity := GetClusterIdentity(c)
 
+	if identity != nil {
+		if clusterKind, ok := cl.TryGetClusterKind(identity.Kind); ok {
+			clusterKind.activated(c.Self())
+		}
+	}
+
 	grainInit := &ClusterInit{
-		Identity: identity,
-		Cluster:  cl,
+		Identity:     identity,
+		Cluster:      cl,
+		HandoffState: getHandoffState(c),
 	}
 
 	ge := actor.WrapEnvelope(grainInit)
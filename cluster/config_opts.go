@@ -48,6 +48,102 @@ func WithPubSubSubscriberTimeout(timeout time.Duration) ConfigOption {
 	}
 }
 
+// WithPubSubSubscriptionStore sets the store persisting the subscribers of the topics when the topic kind is
+// registered by the cluster, see the kvstore package for persistent stores.
+// Default is EmptyKeyValueStore, the subscriptions are lost with their topic actor.
+func WithPubSubSubscriptionStore(store KeyValueStore[*Subscribers]) ConfigOption {
+	return func(c *Config) {
+		c.PubSubConfig.SubscriptionStore = store
+	}
+}
+
+// WithPubSubPartitionedTopic shards the topic across the topic actors of the partitions, the messages with the same
+// ordering key go to the same partition. All members must configure the same partitions.
+func WithPubSubPartitionedTopic(topic string, partitions int) ConfigOption {
+	return func(c *Config) {
+		if c.PubSubConfig.PartitionedTopics == nil {
+			c.PubSubConfig.PartitionedTopics = make(map[string]int)
+		}
+		c.PubSubConfig.PartitionedTopics[topic] = partitions
+	}
+}
+
+// WithPubSubDurableTopics makes the topics selected by durableTopic durable, all topics when it is nil.
+// The batches published to a durable topic are appended to the log store and delivered in order to each
+// subscriber at least once, a subscriber subscribing again resumes from its committed offset.
+func WithPubSubDurableTopics(store TopicLogStore, durableTopic func(topic string) bool) ConfigOption {
+	return func(c *Config) {
+		c.PubSubConfig.LogStore = store
+		c.PubSubConfig.DurableTopic = durableTopic
+	}
+}
+
+// WithPubSubRedeliveryInterval sets the delay before a batch of a durable topic is delivered again to a
+// subscriber which failed to acknowledge it.
+// Default is 1s.
+func WithPubSubRedeliveryInterval(interval time.Duration) ConfigOption {
+	return func(c *Config) {
+		c.PubSubConfig.RedeliveryInterval = interval
+	}
+}
+
+// WithPubSubDeliveryRetryPolicy sets the retry policy of the deliveries to the subscribers of the topics, the
+// policy of a subscriber may depend on the topic and the subscriber. A batch failing the MaxAttempts of the policy
+// is a poison message, it is published to the dead-letter topic and the subscriber receives the next batches.
+func WithPubSubDeliveryRetryPolicy(policy func(topic string, subscriber *SubscriberIdentity) *RetryPolicy) ConfigOption {
+	return func(c *Config) {
+		c.PubSubConfig.DeliveryRetryPolicy = policy
+	}
+}
+
+// WithPubSubDeadLetterTopic sets the topic receiving the messages which could not be delivered to a subscriber,
+// with the metadata of the failure, see PubSubDeadLetter.
+func WithPubSubDeadLetterTopic(topic string) ConfigOption {
+	return func(c *Config) {
+		c.PubSubConfig.DeadLetterTopic = topic
+	}
+}
+
+// WithLeaderSelector sets the selector of a new leader when the cluster has no leader.
+// Default is LowestIDLeaderSelector.
+func WithLeaderSelector(selector LeaderSelector) ConfigOption {
+	return func(c *Config) {
+		c.LeaderSelector = selector
+	}
+}
+
+// WithSingletonHostSelector sets the selector of the members hosting singleton kinds.
+// Default is OldestMemberSelector.
+func WithSingletonHostSelector(selector SingletonHostSelector) ConfigOption {
+	return func(c *Config) {
+		c.SingletonConfig.HostSelector = selector
+	}
+}
+
+// WithSingletonProxyBufferSize sets the number of messages a singleton proxy buffers while the singleton is moved.
+// Default is 10000.
+func WithSingletonProxyBufferSize(size int) ConfigOption {
+	return func(c *Config) {
+		c.SingletonConfig.ProxyBufferSize = size
+	}
+}
+
+// WithGrainRetryPolicy sets the retry policy of the grain calls to kinds without retry policy.
+// Default is nil, calls are retried according to their RetryCount and RetryAction.
+func WithGrainRetryPolicy(policy *RetryPolicy) ConfigOption {
+	return func(c *Config) {
+		c.RetryPolicy = policy
+	}
+}
+
+// WithDrainTimeout sets the time the activations get to complete their requests when the member drains.
+// It should be shorter than the termination grace period of the deployment. Default is 20s.
+func WithDrainTimeout(timeout time.Duration) ConfigOption {
+	return func(c *Config) {
+		c.DrainTimeout = timeout
+	}
+}
+
 // WithHeartbeatExpiration sets the gossip heartbeat expiration.
 func WithHeartbeatExpiration(t time.Duration) ConfigOption {
 	return func(c *Config) {
@@ -55,6 +151,58 @@ func WithHeartbeatExpiration(t time.Duration) ConfigOption {
 	}
 }
 
+// WithGossipMaxMessageSize sets the size in bytes of the gossip entries sent in a message, the remaining entries are
+// sent in the next gossip rounds. A message contains at least one entry. 0 means no limit.
+// Default is 1MiB.
+func WithGossipMaxMessageSize(size int) ConfigOption {
+	return func(c *Config) {
+		c.GossipMaxMessageSize = size
+	}
+}
+
+// WithGossipAntiEntropyInterval sets the interval of the digest exchanges with a random member, which repair the
+// gossip entries a member missed. 0 disables them.
+// Default is 5s.
+func WithGossipAntiEntropyInterval(interval time.Duration) ConfigOption {
+	return func(c *Config) {
+		c.GossipAntiEntropyInterval = interval
+	}
+}
+
+// WithSplitBrainResolver resolves the network partitions with the strategy, once the unreachable members did not
+// change for stableAfter, see SplitBrainResolver.
+// Default is no strategy, each side of a partition keeps running.
+func WithSplitBrainResolver(strategy SplitBrainStrategy, stableAfter time.Duration) ConfigOption {
+	return func(c *Config) {
+		c.SplitBrainResolverConfig.Strategy = strategy
+		c.SplitBrainResolverConfig.StableAfter = stableAfter
+	}
+}
+
+// WithSplitBrainDownAction sets the action of the members of the sides of a network partition which are downed.
+// Default shuts the member down.
+func WithSplitBrainDownAction(down func(c *Cluster)) ConfigOption {
+	return func(c *Config) {
+		c.SplitBrainResolverConfig.Down = down
+	}
+}
+
+// WithMemberLabels adds labels to the member, e.g. RoleLabel, ZoneLabel or VersionLabel. The labels are part of
+// the Member reported by the ClusterProvider, they select the members of LabelStrategy and Kind.WithPlacement.
+// The labels which are not valid, see ValidateLabel, are not added and fail the start of the member,
+// see Config.Validate. Default is no labels.
+func WithMemberLabels(labels map[string]string) ConfigOption {
+	return func(c *Config) {
+		for k, v := range labels {
+			if err := ValidateLabel(k, v); err != nil {
+				c.errs = append(c.errs, err)
+				continue
+			}
+			c.Labels[k] = v
+		}
+	}
+}
+
 func WithRequestLog(enabled bool) ConfigOption {
 	return func(c *Config) {
 		c.RequestLog = enabled
@@ -61,7 +61,9 @@ func (hdl *gossipConsensusHandler) TrySetConsensus(consensus interface{}) {
 }
 
 func (hdl *gossipConsensusHandler) TryResetConsensus() {
-	// this is a noop for now need to discuss the right
-	// approach for check waiting in Go as might be another
-	// way of expressing this
+	hdl.result.Lock()
+	defer hdl.result.Unlock()
+
+	hdl.result.value = nil
+	hdl.result.consensus = false
 }
@@ -121,7 +121,8 @@ func (ccb *ConsensusCheckBuilder) MapToValue(valueTuple *consensusValue) func(st
 }
 
 func (ccb *ConsensusCheckBuilder) build() func(*GossipState, map[string]empty) (bool, interface{}) {
-	getValidMemberStates := func(state *GossipState, ids map[string]empty, result []map[string]*GossipMemberState) {
+	getValidMemberStates := func(state *GossipState, ids map[string]empty) []map[string]*GossipMemberState {
+		var result []map[string]*GossipMemberState
 		for member, memberState := range state.Members {
 			if _, ok := ids[member]; ok {
 				result = append(result, map[string]*GossipMemberState{
@@ -129,6 +130,7 @@ func (ccb *ConsensusCheckBuilder) build() func(*GossipState, map[string]empty) (
 				})
 			}
 		}
+		return result
 	}
 
 	showLog := func(hasConsensus bool, topologyHash uint64, valueTuples []*consensusMemberValue) {
@@ -157,8 +159,7 @@ func (ccb *ConsensusCheckBuilder) build() func(*GossipState, map[string]empty) (
 		mapToValue := ccb.MapToValue(ccb.getConsensusValues[0])
 
 		return func(state *GossipState, ids map[string]empty) (bool, interface{}) {
-			var memberStates []map[string]*GossipMemberState
-			getValidMemberStates(state, ids, memberStates)
+			memberStates := getValidMemberStates(state, ids)
 
 			if len(memberStates) < len(ids) { // Not all members have state...
 				return false, nil
@@ -180,8 +181,7 @@ func (ccb *ConsensusCheckBuilder) build() func(*GossipState, map[string]empty) (
 	}
 
 	return func(state *GossipState, ids map[string]empty) (bool, interface{}) {
-		var memberStates []map[string]*GossipMemberState
-		getValidMemberStates(state, ids, memberStates)
+		memberStates := getValidMemberStates(state, ids)
 
 		if len(memberStates) < len(ids) { // Not all members have state...
 			return false, nil
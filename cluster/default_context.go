@@ -44,6 +44,10 @@ func (dcc *DefaultContext) Request(identity, kind string, message interface{}, o
 		o(callConfig)
 	}
 
+	if policy := dcc.retryPolicy(kind, callConfig); policy != nil {
+		return dcc.requestWithRetryPolicy(identity, kind, message, callConfig, policy)
+	}
+
 	_context := callConfig.Context
 
 	// get the configuration from the composed Cluster value
@@ -81,7 +85,7 @@ selectloop:
 			}
 
 			// TODO: why is err != nil when res != nil?
-			resp, err = _context.RequestFuture(pid, message, ttl).Result()
+			resp, err = requestFuture(_context, pid, message, ttl, callConfig.RequestID).Result()
 			if resp != nil {
 				break selectloop
 			}
@@ -112,6 +116,9 @@ selectloop:
 	return resp, err
 }
 
+// RequestFuture sends the message to the grain and returns the future of its response. Only the lookup of the
+// activation is retried, with the RetryCount of the call: the retry policies of the kind and of the cluster do not
+// apply and a call with WithRetryPolicy fails with ErrRetryPolicyNotSupported.
 func (dcc *DefaultContext) RequestFuture(identity string, kind string, message interface{}, opts ...GrainCallOption) (*actor.Future, error) {
 	var counter int
 	callConfig := DefaultGrainCallConfig(dcc.cluster)
@@ -119,6 +126,10 @@ func (dcc *DefaultContext) RequestFuture(identity string, kind string, message i
 		o(callConfig)
 	}
 
+	if callConfig.RetryPolicy != nil {
+		return nil, ErrRetryPolicyNotSupported
+	}
+
 	_context := callConfig.Context
 
 	dcc.cluster.Logger().Debug("Requesting future", slog.String("identity", identity), slog.String("kind", kind), slog.String("type", reflect.TypeOf(message).String()), slog.Any("message", message))
@@ -147,12 +158,122 @@ func (dcc *DefaultContext) RequestFuture(identity string, kind string, message i
 				continue
 			}
 
-			f := _context.RequestFuture(pid, message, ttl)
+			f := requestFuture(_context, pid, message, ttl, callConfig.RequestID)
 			return f, nil
 		}
 	}
 }
 
+// returns the retry policy of the call, of the kind or of the cluster, in that order
+func (dcc *DefaultContext) retryPolicy(kind string, callConfig *GrainCallConfig) *RetryPolicy {
+	if callConfig.RetryPolicy != nil {
+		return callConfig.RetryPolicy
+	}
+	if k, ok := dcc.cluster.Config.Kinds[kind]; ok && k.RetryPolicy != nil {
+		return k.RetryPolicy
+	}
+
+	return dcc.cluster.Config.RetryPolicy
+}
+
+func (dcc *DefaultContext) requestWithRetryPolicy(identity, kind string, message interface{}, callConfig *GrainCallConfig, policy *RetryPolicy) (interface{}, error) {
+	var deadline time.Time
+	if policy.Deadline > 0 {
+		deadline = time.Now().Add(policy.Deadline)
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := dcc.requestAttempt(identity, kind, message, callConfig, deadline)
+
+		// grain error responses are returned as responses, the generated clients turn them into errors
+		failure := err
+		if errResp, ok := resp.(*GrainErrorResponse); ok && errResp != nil {
+			failure = errResp
+		}
+		if failure == nil || !policy.IsRetryable(failure) {
+			return resp, err
+		}
+
+		backoff := policy.Backoff(attempt)
+		maxAttempts := policy.attemptsLimit()
+		exhausted := maxAttempts > 0 && attempt >= maxAttempts
+		if !exhausted && !deadline.IsZero() && time.Now().Add(backoff).After(deadline) {
+			exhausted = true
+		}
+		if exhausted {
+			if err != nil {
+				err = fmt.Errorf("request failed after %d attempts: %w", attempt, err)
+			}
+			return resp, err
+		}
+
+		dcc.cluster.Logger().Debug("Retrying request", slog.String("identity", identity), slog.String("kind", kind), slog.Int("attempt", attempt), slog.Duration("backoff", backoff), slog.Any("error", failure))
+		time.Sleep(backoff)
+	}
+}
+
+// sends a single attempt of the call, the attempt times out with the call or at the deadline.
+// The attempt is not sent once the deadline has passed, the future of a zero or negative timeout never times out.
+func (dcc *DefaultContext) requestAttempt(identity, kind string, message interface{}, callConfig *GrainCallConfig, deadline time.Time) (interface{}, error) {
+	if _, ok := attemptTimeout(callConfig.Timeout, deadline); !ok {
+		return nil, actor.ErrTimeout
+	}
+
+	pid := dcc.getPid(identity, kind)
+	if pid == nil {
+		dcc.cluster.Logger().Debug("Requesting PID from IdentityLookup but got nil", slog.String("identity", identity), slog.String("kind", kind))
+		return nil, errActivationNotFound
+	}
+
+	// the lookup of the activation may have used up what was left of the deadline
+	ttl, ok := attemptTimeout(callConfig.Timeout, deadline)
+	if !ok {
+		return nil, actor.ErrTimeout
+	}
+
+	resp, err := requestFuture(callConfig.Context, pid, message, ttl, callConfig.RequestID).Result()
+	if isTransientRequestError(err) {
+		dcc.cluster.PidCache.Remove(identity, kind)
+	}
+	if err == actor.ErrDeadLetter || err == remote.ErrDeadLetter {
+		// the activation no longer exists, the lookup should not return it again
+		dcc.cluster.IdentityLookup.RemovePid(NewClusterIdentity(identity, kind), pid)
+	}
+
+	return resp, err
+}
+
+// returns the timeout of an attempt, the call timeout capped by the deadline, and false when the deadline has passed
+func attemptTimeout(timeout time.Duration, deadline time.Time) (time.Duration, bool) {
+	if deadline.IsZero() {
+		return timeout, true
+	}
+
+	remaining := time.Until(deadline)
+	if remaining <= 0 {
+		return 0, false
+	}
+	if remaining < timeout {
+		return remaining, true
+	}
+
+	return timeout, true
+}
+
+// sends the request with the request id header when the call has one
+func requestFuture(context actor.SenderContext, pid *actor.PID, message interface{}, timeout time.Duration, requestID string) *actor.Future {
+	if requestID == "" {
+		return context.RequestFuture(pid, message, timeout)
+	}
+
+	future := actor.NewFuture(context.ActorSystem(), timeout)
+	envelope := &actor.MessageEnvelope{Message: message, Sender: future.PID()}
+	envelope.SetHeader(RequestIDHeader, requestID)
+	context.Send(pid, envelope)
+
+	return future
+}
+
 // gets the cached PID for the given identity
 // it can return nil if none is found.
 func (dcc *DefaultContext) getPid(identity, kind string) *actor.PID {
@@ -0,0 +1,409 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/eventstream"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const DistributedDataActorName = "distributed-data"
+
+var (
+	// ErrDistributedDataNotStarted is returned when distributed data is used before the cluster member started
+	ErrDistributedDataNotStarted = errors.New("distributed data is only available on started cluster members")
+	// ErrConsistencyNotReached is returned when not enough members replied to a read or write within the timeout
+	ErrConsistencyNotReached = errors.New("distributed data consistency not reached")
+)
+
+// ReplicatedData is a conflict-free replicated data type (CRDT) replicated between the members by DistributedData.
+// The replicas of a key are merged into each other, so they converge to the same state without coordination.
+type ReplicatedData interface {
+	proto.Message
+	// Merge returns the state combining this replica with another replica of the same type
+	Merge(other ReplicatedData) ReplicatedData
+	// Prune returns the state where the state of the removed member, which left the cluster,
+	// is collapsed into the state of the into member
+	Prune(removed, into string) ReplicatedData
+}
+
+// Consistency is the number of members a read or write of DistributedData waits for
+type Consistency int
+
+const (
+	// ConsistencyLocal only reads or writes the replica of the member, writes reach the other members by gossip
+	ConsistencyLocal Consistency = iota
+	// ConsistencyMajority reads from or writes to the majority of the members
+	ConsistencyMajority
+	// ConsistencyAll reads from or writes to all members
+	ConsistencyAll
+)
+
+func (c Consistency) String() string {
+	switch c {
+	case ConsistencyLocal:
+		return "local"
+	case ConsistencyMajority:
+		return "majority"
+	case ConsistencyAll:
+		return "all"
+	default:
+		return fmt.Sprintf("Consistency(%d)", int(c))
+	}
+}
+
+// required returns the number of members, including this member, a read or write waits for
+func (c Consistency) required(members int) int {
+	switch c {
+	case ConsistencyMajority:
+		return members/2 + 1
+	case ConsistencyAll:
+		return members
+	default:
+		return 1
+	}
+}
+
+// DataChanged is published on the EventStream when the replica of a key changed on the member
+type DataChanged struct {
+	Key  string
+	Data ReplicatedData
+}
+
+// DistributedData replicates application state between the members as CRDTs, see GCounter, PNCounter, ORSet and LWWMap.
+// Every member keeps a replica of each key and gossips it when it changes, members merge the replicas they receive.
+// When a member leaves, the leader elected by LeaderElection prunes the state of the member from the replicas.
+type DistributedData struct {
+	cluster *Cluster
+	pid     *actor.PID
+	sub     *eventstream.Subscription
+}
+
+func newDistributedData(c *Cluster) *DistributedData {
+	return &DistributedData{cluster: c}
+}
+
+// Start the distributed data replicator of the member
+func (dd *DistributedData) Start() {
+	system := dd.cluster.ActorSystem
+	props := actor.PropsFromProducer(func() actor.Actor {
+		return &distributedDataActor{cluster: dd.cluster, replicas: map[string]ReplicatedData{}, left: map[string]empty{}}
+	})
+
+	var err error
+	dd.pid, err = system.Root.SpawnNamed(props, DistributedDataActorName)
+	if err != nil {
+		panic(err) // let it crash
+	}
+
+	dd.sub = system.EventStream.Subscribe(func(evt interface{}) {
+		switch msg := evt.(type) {
+		case *ClusterTopology, *LeaderChanged:
+			system.Root.Send(dd.pid, msg)
+		case *GossipUpdate:
+			if strings.HasPrefix(msg.Key, DistributedDataKeyPrefix) && msg.MemberID != system.ID {
+				system.Root.Send(dd.pid, msg)
+			}
+		}
+	})
+	dd.cluster.Logger().Info("Started Cluster DistributedData")
+}
+
+// Get returns the replica of the key, read from the members required by the consistency.
+// The returned data is nil if no member has a replica of the key.
+func (dd *DistributedData) Get(key string, consistency Consistency) (ReplicatedData, error) {
+	if dd.pid == nil {
+		return nil, ErrDistributedDataNotStarted
+	}
+
+	system := dd.cluster.ActorSystem
+	timeout := dd.cluster.Config.TimeoutTime
+	others := dd.otherMembers()
+	required := consistency.required(len(others)+1) - 1
+
+	var replicas []ReplicatedData
+	if required > 0 {
+		futures := make([]*actor.Future, 0, len(others))
+		for _, member := range others {
+			futures = append(futures, system.Root.RequestFuture(dd.replicatorOf(member), &ReplicaReadRequest{Key: key}, timeout))
+		}
+
+		replied := 0
+		for _, future := range futures {
+			res, err := future.Result()
+			if err != nil {
+				continue
+			}
+			replied++
+
+			if data := unpackReplica(res.(*ReplicaReadResponse).Data); data != nil {
+				replicas = append(replicas, data)
+			}
+			if replied >= required {
+				break
+			}
+		}
+
+		if replied < required {
+			return nil, fmt.Errorf("%w: %d of %d members replied to read %s", ErrConsistencyNotReached, replied+1, required+1, key)
+		}
+	}
+
+	return dd.request(&mergeReplicas{key: key, replicas: replicas})
+}
+
+// Update applies modify to the replica of the key and writes the result to the members required by the consistency.
+// modify receives a copy of the replica, or initial when the member has no replica of the key yet.
+func (dd *DistributedData) Update(key string, initial ReplicatedData, consistency Consistency, modify func(data ReplicatedData) ReplicatedData) (ReplicatedData, error) {
+	if dd.pid == nil {
+		return nil, ErrDistributedDataNotStarted
+	}
+
+	data, err := dd.request(&updateReplica{key: key, initial: initial, modify: modify})
+	if err != nil {
+		return nil, err
+	}
+
+	others := dd.otherMembers()
+	required := consistency.required(len(others)+1) - 1
+	if required == 0 {
+		return data, nil
+	}
+
+	packed, err := anypb.New(data)
+	if err != nil {
+		return nil, err
+	}
+
+	system := dd.cluster.ActorSystem
+	timeout := dd.cluster.Config.TimeoutTime
+	futures := make([]*actor.Future, 0, len(others))
+	for _, member := range others {
+		futures = append(futures, system.Root.RequestFuture(dd.replicatorOf(member), &ReplicaWriteRequest{Key: key, Data: packed}, timeout))
+	}
+
+	acknowledged := 0
+	for _, future := range futures {
+		if _, err := future.Result(); err == nil {
+			acknowledged++
+		}
+		if acknowledged >= required {
+			return data, nil
+		}
+	}
+
+	return data, fmt.Errorf("%w: %d of %d members acknowledged write %s", ErrConsistencyNotReached, acknowledged+1, required+1, key)
+}
+
+// Subscribe calls handler with the replica of the key every time it changes on the member
+func (dd *DistributedData) Subscribe(key string, handler func(data ReplicatedData)) *eventstream.Subscription {
+	return dd.cluster.ActorSystem.EventStream.SubscribeWithPredicate(func(evt interface{}) {
+		handler(evt.(*DataChanged).Data)
+	}, func(evt interface{}) bool {
+		changed, ok := evt.(*DataChanged)
+		return ok && changed.Key == key
+	})
+}
+
+// Unsubscribe stops the subscription returned by Subscribe
+func (dd *DistributedData) Unsubscribe(sub *eventstream.Subscription) {
+	dd.cluster.ActorSystem.EventStream.Unsubscribe(sub)
+}
+
+func (dd *DistributedData) request(msg interface{}) (ReplicatedData, error) {
+	res, err := dd.cluster.ActorSystem.Root.RequestFuture(dd.pid, msg, dd.cluster.Config.TimeoutTime).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	result := res.(*replicaResult)
+	return result.data, result.err
+}
+
+func (dd *DistributedData) otherMembers() Members {
+	others := make(Members, 0)
+	for _, member := range dd.cluster.MemberList.Members().Members() {
+		if member.Id != dd.cluster.ActorSystem.ID {
+			others = append(others, member)
+		}
+	}
+
+	return others
+}
+
+func (dd *DistributedData) replicatorOf(member *Member) *actor.PID {
+	return actor.NewPID(member.Address(), DistributedDataActorName)
+}
+
+func unpackReplica(packed *anypb.Any) ReplicatedData {
+	if packed == nil {
+		return nil
+	}
+
+	msg, err := packed.UnmarshalNew()
+	if err != nil {
+		return nil
+	}
+
+	data, _ := msg.(ReplicatedData)
+	return data
+}
+
+type updateReplica struct {
+	key     string
+	initial ReplicatedData
+	modify  func(data ReplicatedData) ReplicatedData
+}
+
+type mergeReplicas struct {
+	key      string
+	replicas []ReplicatedData
+}
+
+type replicaResult struct {
+	data ReplicatedData
+	err  error
+}
+
+// distributedDataActor owns the replicas of the member, all changes to the replicas go through it
+type distributedDataActor struct {
+	cluster  *Cluster
+	replicas map[string]ReplicatedData
+	members  Members
+	// members which left and may still have state in the replicas
+	left map[string]empty
+}
+
+func (a *distributedDataActor) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *actor.Started:
+		a.members = a.cluster.MemberList.Members().Members()
+	case *updateReplica:
+		data, ok := a.replicas[msg.key]
+		if !ok {
+			data = msg.initial
+		}
+		if data == nil {
+			ctx.Respond(&replicaResult{err: fmt.Errorf("no replica of %s and no initial value", msg.key)})
+			return
+		}
+
+		updated := msg.modify(proto.Clone(data).(ReplicatedData))
+		if ok && !sameType(data, updated) {
+			ctx.Respond(&replicaResult{err: fmt.Errorf("cannot update %s of type %s with %s", msg.key, typeName(data), typeName(updated))})
+			return
+		}
+		a.set(ctx, msg.key, updated)
+		ctx.Respond(&replicaResult{data: proto.Clone(updated).(ReplicatedData)})
+	case *mergeReplicas:
+		for _, data := range msg.replicas {
+			if err := a.merge(ctx, msg.key, data); err != nil {
+				ctx.Logger().Warn("Could not merge replica", slog.String("key", msg.key), slog.Any("error", err))
+			}
+		}
+
+		var data ReplicatedData
+		if replica, ok := a.replicas[msg.key]; ok {
+			data = proto.Clone(replica).(ReplicatedData)
+		}
+		ctx.Respond(&replicaResult{data: data})
+	case *ReplicaReadRequest:
+		res := &ReplicaReadResponse{}
+		if replica, ok := a.replicas[msg.Key]; ok {
+			res.Data, _ = anypb.New(replica)
+		}
+		ctx.Respond(res)
+	case *ReplicaWriteRequest:
+		data := unpackReplica(msg.Data)
+		if data == nil {
+			ctx.Logger().Warn("Could not unpack replica", slog.String("key", msg.Key), slog.String("type", msg.Data.GetTypeUrl()))
+			return
+		}
+		if err := a.merge(ctx, msg.Key, data); err != nil {
+			ctx.Logger().Warn("Could not merge replica", slog.String("key", msg.Key), slog.Any("error", err))
+			return
+		}
+		ctx.Respond(&ReplicaWriteResponse{})
+	case *GossipUpdate:
+		key := strings.TrimPrefix(msg.Key, DistributedDataKeyPrefix)
+		data := unpackReplica(msg.Value)
+		if data == nil {
+			ctx.Logger().Warn("Could not unpack replica", slog.String("key", key), slog.String("member", msg.MemberID))
+			return
+		}
+		if err := a.merge(ctx, key, data); err != nil {
+			ctx.Logger().Warn("Could not merge replica", slog.String("key", key), slog.String("member", msg.MemberID), slog.Any("error", err))
+		}
+	case *ClusterTopology:
+		a.members = msg.Members
+		for _, member := range msg.Left {
+			if member.Id != a.cluster.ActorSystem.ID {
+				a.left[member.Id] = empty{}
+			}
+		}
+		a.prune(ctx)
+	case *LeaderChanged:
+		a.prune(ctx)
+	}
+}
+
+func (a *distributedDataActor) merge(ctx actor.Context, key string, data ReplicatedData) error {
+	replica, ok := a.replicas[key]
+	if !ok {
+		a.set(ctx, key, data)
+		return nil
+	}
+
+	if !sameType(replica, data) {
+		return fmt.Errorf("cannot merge %s of type %s with %s", key, typeName(replica), typeName(data))
+	}
+	a.set(ctx, key, replica.Merge(data))
+
+	return nil
+}
+
+// set replaces the replica of the key, gossiping it and notifying the subscribers if it changed
+func (a *distributedDataActor) set(ctx actor.Context, key string, data ReplicatedData) {
+	if replica, ok := a.replicas[key]; ok && proto.Equal(replica, data) {
+		return
+	}
+
+	a.replicas[key] = data
+	a.cluster.Gossip.SetState(DistributedDataKeyPrefix+key, data)
+	ctx.ActorSystem().EventStream.Publish(&DataChanged{Key: key, Data: proto.Clone(data).(ReplicatedData)})
+}
+
+// prune collapses the state of the members which left into the state of this member, if it is the leader.
+// Only the leader prunes, so the state of a member is collapsed only once. A leader which is itself leaving
+// does not prune, as its view of the members which left is not reliable anymore.
+func (a *distributedDataActor) prune(ctx actor.Context) {
+	if len(a.left) == 0 || !a.cluster.LeaderElection.IsLeader() {
+		return
+	}
+
+	myID := a.cluster.ActorSystem.ID
+	if _, ok := MembersToMap(a.members)[myID]; !ok {
+		return
+	}
+
+	for memberID := range a.left {
+		for key, replica := range a.replicas {
+			a.set(ctx, key, replica.Prune(memberID, myID))
+		}
+		ctx.Logger().Info("Pruned distributed data of member", slog.String("member", memberID))
+	}
+	a.left = map[string]empty{}
+}
+
+func sameType(a, b ReplicatedData) bool {
+	return a.ProtoReflect().Descriptor().FullName() == b.ProtoReflect().Descriptor().FullName()
+}
+
+func typeName(data ReplicatedData) string {
+	return string(data.ProtoReflect().Descriptor().FullName())
+}
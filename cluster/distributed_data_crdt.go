@@ -0,0 +1,374 @@
+package cluster
+
+import (
+	"sort"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var (
+	_ ReplicatedData = (*GCounter)(nil)
+	_ ReplicatedData = (*PNCounter)(nil)
+	_ ReplicatedData = (*ORSet)(nil)
+	_ ReplicatedData = (*LWWMap)(nil)
+)
+
+// NewGCounter creates an empty grow-only counter
+func NewGCounter() *GCounter {
+	return &GCounter{Counts: map[string]uint64{}}
+}
+
+// Increment adds delta to the count of the member
+func (c *GCounter) Increment(memberID string, delta uint64) *GCounter {
+	if c.Counts == nil {
+		c.Counts = map[string]uint64{}
+	}
+	c.Counts[memberID] += delta
+
+	return c
+}
+
+// Value returns the sum of the counts of all members
+func (c *GCounter) Value() uint64 {
+	var value uint64
+	for _, count := range c.Counts {
+		value += count
+	}
+
+	return value
+}
+
+func (c *GCounter) Merge(other ReplicatedData) ReplicatedData {
+	return c.merge(other.(*GCounter))
+}
+
+func (c *GCounter) merge(other *GCounter) *GCounter {
+	pruned := mergePruned(c.GetPruned(), other.GetPruned())
+	isPruned := toSet(pruned)
+
+	res := &GCounter{Counts: map[string]uint64{}, Pruned: pruned}
+	for _, counts := range []map[string]uint64{c.GetCounts(), other.GetCounts()} {
+		for memberID, count := range counts {
+			if _, ok := isPruned[memberID]; ok {
+				continue
+			}
+			if count > res.Counts[memberID] {
+				res.Counts[memberID] = count
+			}
+		}
+	}
+
+	return res
+}
+
+func (c *GCounter) Prune(removed, into string) ReplicatedData {
+	return c.prune(removed, into)
+}
+
+func (c *GCounter) prune(removed, into string) *GCounter {
+	res := NewGCounter()
+	if c != nil {
+		res = proto.Clone(c).(*GCounter)
+	}
+	if contains(res.Pruned, removed) {
+		return res
+	}
+
+	if count, ok := res.Counts[removed]; ok {
+		delete(res.Counts, removed)
+		res.Increment(into, count)
+	}
+	res.Pruned = mergePruned(res.Pruned, []string{removed})
+
+	return res
+}
+
+// NewPNCounter creates a counter with the value 0
+func NewPNCounter() *PNCounter {
+	return &PNCounter{Increments: NewGCounter(), Decrements: NewGCounter()}
+}
+
+// Increment adds delta to the counter
+func (c *PNCounter) Increment(memberID string, delta uint64) *PNCounter {
+	if c.Increments == nil {
+		c.Increments = NewGCounter()
+	}
+	c.Increments.Increment(memberID, delta)
+
+	return c
+}
+
+// Decrement subtracts delta from the counter
+func (c *PNCounter) Decrement(memberID string, delta uint64) *PNCounter {
+	if c.Decrements == nil {
+		c.Decrements = NewGCounter()
+	}
+	c.Decrements.Increment(memberID, delta)
+
+	return c
+}
+
+// Value returns the increments minus the decrements of all members
+func (c *PNCounter) Value() int64 {
+	return int64(c.GetIncrements().Value()) - int64(c.GetDecrements().Value())
+}
+
+func (c *PNCounter) Merge(other ReplicatedData) ReplicatedData {
+	o := other.(*PNCounter)
+
+	return &PNCounter{
+		Increments: c.GetIncrements().merge(o.GetIncrements()),
+		Decrements: c.GetDecrements().merge(o.GetDecrements()),
+	}
+}
+
+func (c *PNCounter) Prune(removed, into string) ReplicatedData {
+	return &PNCounter{
+		Increments: c.GetIncrements().prune(removed, into),
+		Decrements: c.GetDecrements().prune(removed, into),
+	}
+}
+
+// NewORSet creates an empty observed-remove set
+func NewORSet() *ORSet {
+	return &ORSet{Elements: map[string]*ORSetDots{}, Versions: map[string]uint64{}}
+}
+
+// Add adds the element to the set
+func (s *ORSet) Add(memberID string, element string) *ORSet {
+	if s.Elements == nil {
+		s.Elements = map[string]*ORSetDots{}
+	}
+	if s.Versions == nil {
+		s.Versions = map[string]uint64{}
+	}
+
+	s.Versions[memberID]++
+	s.Elements[element] = &ORSetDots{Dots: map[string]uint64{memberID: s.Versions[memberID]}}
+
+	return s
+}
+
+// Remove removes the element from the set, only the additions observed by the replica are removed
+func (s *ORSet) Remove(element string) *ORSet {
+	delete(s.Elements, element)
+
+	return s
+}
+
+// Contains returns true if the element is in the set
+func (s *ORSet) Contains(element string) bool {
+	_, ok := s.Elements[element]
+
+	return ok
+}
+
+// Values returns the sorted elements of the set
+func (s *ORSet) Values() []string {
+	values := make([]string, 0, len(s.Elements))
+	for element := range s.Elements {
+		values = append(values, element)
+	}
+	sort.Strings(values)
+
+	return values
+}
+
+func (s *ORSet) Merge(other ReplicatedData) ReplicatedData {
+	o := other.(*ORSet)
+	pruned := mergePruned(s.GetPruned(), o.GetPruned())
+	isPruned := toSet(pruned)
+
+	res := &ORSet{Elements: map[string]*ORSetDots{}, Versions: map[string]uint64{}, Pruned: pruned}
+	for _, versions := range []map[string]uint64{s.GetVersions(), o.GetVersions()} {
+		for memberID, version := range versions {
+			if _, ok := isPruned[memberID]; !ok && version > res.Versions[memberID] {
+				res.Versions[memberID] = version
+			}
+		}
+	}
+
+	// an addition is kept if both replicas have it, or if the other replica did not observe it yet
+	keep := func(dots map[string]uint64, otherDots map[string]uint64, otherVersions map[string]uint64, into map[string]uint64) {
+		for memberID, version := range dots {
+			if _, ok := isPruned[memberID]; ok {
+				continue
+			}
+			if otherDots[memberID] == version || version > otherVersions[memberID] {
+				if version > into[memberID] {
+					into[memberID] = version
+				}
+			}
+		}
+	}
+
+	for _, elements := range []map[string]*ORSetDots{s.GetElements(), o.GetElements()} {
+		for element := range elements {
+			if _, ok := res.Elements[element]; ok {
+				continue
+			}
+
+			dots := map[string]uint64{}
+			keep(s.GetElements()[element].GetDots(), o.GetElements()[element].GetDots(), o.GetVersions(), dots)
+			keep(o.GetElements()[element].GetDots(), s.GetElements()[element].GetDots(), s.GetVersions(), dots)
+			if len(dots) > 0 {
+				res.Elements[element] = &ORSetDots{Dots: dots}
+			}
+		}
+	}
+
+	return res
+}
+
+func (s *ORSet) Prune(removed, into string) ReplicatedData {
+	res := proto.Clone(s).(*ORSet)
+	if contains(res.Pruned, removed) {
+		return res
+	}
+
+	// the additions of the removed member become additions of the pruning member
+	var version uint64
+	for _, dots := range res.Elements {
+		if _, ok := dots.Dots[removed]; !ok {
+			continue
+		}
+		if version == 0 {
+			if res.Versions == nil {
+				res.Versions = map[string]uint64{}
+			}
+			res.Versions[into]++
+			version = res.Versions[into]
+		}
+		delete(dots.Dots, removed)
+		dots.Dots[into] = version
+	}
+	delete(res.Versions, removed)
+	res.Pruned = mergePruned(res.Pruned, []string{removed})
+
+	return res
+}
+
+// NewLWWMap creates an empty last-writer-wins map
+func NewLWWMap() *LWWMap {
+	return &LWWMap{Entries: map[string]*LWWRegister{}}
+}
+
+// Put sets the value of the key
+func (m *LWWMap) Put(memberID string, key string, value proto.Message) *LWWMap {
+	a, _ := anypb.New(value)
+	m.write(key, &LWWRegister{Value: a, MemberId: memberID})
+
+	return m
+}
+
+// Remove removes the key, a tombstone is kept so the removal wins over older writes
+func (m *LWWMap) Remove(memberID string, key string) *LWWMap {
+	if _, ok := m.Entries[key]; ok {
+		m.write(key, &LWWRegister{MemberId: memberID, Deleted: true})
+	}
+
+	return m
+}
+
+func (m *LWWMap) write(key string, register *LWWRegister) {
+	if m.Entries == nil {
+		m.Entries = map[string]*LWWRegister{}
+	}
+
+	// the write must win over the value it replaces, even when the clock of the member is behind
+	register.Timestamp = time.Now().UnixNano()
+	if current, ok := m.Entries[key]; ok && current.Timestamp >= register.Timestamp {
+		register.Timestamp = current.Timestamp + 1
+	}
+	m.Entries[key] = register
+}
+
+// Get returns the value of the key
+func (m *LWWMap) Get(key string) (proto.Message, bool) {
+	register, ok := m.Entries[key]
+	if !ok || register.Deleted {
+		return nil, false
+	}
+
+	value, err := register.Value.UnmarshalNew()
+	if err != nil {
+		return nil, false
+	}
+
+	return value, true
+}
+
+// Keys returns the sorted keys of the map
+func (m *LWWMap) Keys() []string {
+	keys := make([]string, 0, len(m.Entries))
+	for key, register := range m.Entries {
+		if !register.Deleted {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func (m *LWWMap) Merge(other ReplicatedData) ReplicatedData {
+	res := proto.Clone(m).(*LWWMap)
+	if res.Entries == nil {
+		res.Entries = map[string]*LWWRegister{}
+	}
+
+	for key, register := range other.(*LWWMap).GetEntries() {
+		current, ok := res.Entries[key]
+		if !ok || register.Timestamp > current.Timestamp ||
+			(register.Timestamp == current.Timestamp && register.MemberId > current.MemberId) {
+			res.Entries[key] = proto.Clone(register).(*LWWRegister)
+		}
+	}
+
+	return res
+}
+
+// Prune returns a copy of the map, the registers do not hold state of the members which wrote them
+func (m *LWWMap) Prune(_, _ string) ReplicatedData {
+	return proto.Clone(m).(*LWWMap)
+}
+
+// mergePruned returns the sorted union of the pruned members, the order is kept stable so equal replicas compare equal
+func mergePruned(a []string, b []string) []string {
+	set := toSet(a)
+	for _, memberID := range b {
+		set[memberID] = empty{}
+	}
+	if len(set) == 0 {
+		return nil
+	}
+
+	res := make([]string, 0, len(set))
+	for memberID := range set {
+		res = append(res, memberID)
+	}
+	sort.Strings(res)
+
+	return res
+}
+
+func toSet(values []string) map[string]empty {
+	set := make(map[string]empty, len(values))
+	for _, value := range values {
+		set[value] = empty{}
+	}
+
+	return set
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,79 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestGCounterMergeAndPrune(t *testing.T) {
+	a := NewGCounter().Increment("a", 2)
+	b := NewGCounter().Increment("b", 3)
+	b2 := proto.Clone(b).(*GCounter).Increment("b", 1)
+
+	merged := a.Merge(b).Merge(b2).(*GCounter)
+	assert.Equal(t, uint64(6), merged.Value())
+	assert.True(t, proto.Equal(merged, b2.Merge(a).Merge(b)), "merge is commutative")
+
+	// the count of b is collapsed into a, replicas which still hold the count of b do not count it twice
+	pruned := merged.Prune("b", "a").(*GCounter)
+	assert.Equal(t, uint64(6), pruned.Value())
+	assert.Equal(t, []string{"b"}, pruned.Pruned)
+	assert.Equal(t, uint64(6), pruned.Merge(b2).(*GCounter).Value())
+	assert.Equal(t, uint64(6), b2.Merge(pruned).(*GCounter).Value())
+}
+
+func TestPNCounter(t *testing.T) {
+	a := NewPNCounter().Increment("a", 5)
+	b := NewPNCounter().Decrement("b", 7)
+
+	assert.Equal(t, int64(-2), a.Merge(b).(*PNCounter).Value())
+	assert.Equal(t, int64(-2), a.Merge(b).Prune("b", "a").(*PNCounter).Value())
+}
+
+func TestORSetAddWins(t *testing.T) {
+	a := NewORSet().Add("a", "x").Add("a", "y")
+	b := NewORSet().Merge(a).(*ORSet)
+
+	// b removes x while a adds it again, the concurrent addition wins
+	b.Remove("x")
+	a.Add("a", "x")
+	assert.Equal(t, []string{"x", "y"}, a.Merge(b).(*ORSet).Values())
+
+	// removing an observed element removes it from all replicas
+	c := a.Merge(b).(*ORSet).Remove("y")
+	assert.Equal(t, []string{"x"}, c.Merge(a).(*ORSet).Values())
+	assert.Equal(t, []string{"x"}, a.Merge(c).(*ORSet).Values())
+}
+
+func TestORSetPrune(t *testing.T) {
+	b := NewORSet().Add("b", "x").Add("b", "y")
+	a := NewORSet().Merge(b).(*ORSet)
+
+	pruned := a.Prune("b", "a").(*ORSet)
+	assert.Equal(t, []string{"x", "y"}, pruned.Values())
+	assert.NotContains(t, pruned.Versions, "b")
+
+	// a stale replica of b neither restores its dots nor the removed elements
+	pruned.Remove("x")
+	merged := pruned.Merge(b).(*ORSet)
+	assert.Equal(t, []string{"y"}, merged.Values())
+	assert.NotContains(t, merged.Elements["y"].Dots, "b")
+}
+
+func TestLWWMap(t *testing.T) {
+	a := NewLWWMap().Put("a", "k", wrapperspb.String("a"))
+	b := NewLWWMap().Merge(a).(*LWWMap).Put("b", "k", wrapperspb.String("b"))
+
+	value, ok := a.Merge(b).(*LWWMap).Get("k")
+	assert.True(t, ok)
+	assert.Equal(t, "b", value.(*wrapperspb.StringValue).Value)
+
+	// the removal is newer than the value of a
+	b.Remove("b", "k")
+	_, ok = a.Merge(b).(*LWWMap).Get("k")
+	assert.False(t, ok)
+	assert.Empty(t, b.Merge(a).(*LWWMap).Keys())
+}
@@ -0,0 +1,118 @@
+package cluster
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Drain prepares the member to leave the cluster without dropping requests.
+// The member stops accepting new activations, moves its singletons to other members, waits for the pubsub
+// producers of the cluster to publish their pending messages and stops its activations once they handled the
+// requests in their mailbox. New requests to the stopped grains activate them on other members.
+// Activations still running when ctx is done or the DrainTimeout expired are stopped at once, and the error
+// of the context is returned.
+// Only the identity lookups choosing an activator member for new activations, such as the partition lookup,
+// activate the stopped grains on other members, disthash activates them on the owner of their identity.
+//
+// Shutdown does not drain the member, call Drain before it, or use ShutdownOnSignal.
+func (c *Cluster) Drain(ctx context.Context) error {
+	if !c.draining.CompareAndSwap(false, true) {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.Config.DrainTimeout)
+	defer cancel()
+
+	c.Logger().Info("Draining cluster member", slog.String("address", c.ActorSystem.Address()))
+	c.MemberList.setDraining(c.ActorSystem.ID)
+
+	// the other members select another host for the singletons once they know the member is draining, the
+	// singletons are stopped before, so they never run twice
+	if err := c.Singletons.drain(ctx); err != nil {
+		c.Logger().Warn("Singletons did not stop before the member drained", slog.Any("error", err))
+	}
+	c.Gossip.SetState(DrainingKey, &emptypb.Empty{})
+
+	if err := c.PubSub.flushProducers(ctx); err != nil {
+		c.Logger().Warn("PubSub producers were not flushed before the member drained", slog.Any("error", err))
+	}
+
+	err := c.stopActivations(ctx)
+	c.Logger().Info("Drained cluster member", slog.String("address", c.ActorSystem.Address()))
+
+	return err
+}
+
+// IsDraining returns true once the member started to drain
+func (c *Cluster) IsDraining() bool {
+	return c.draining.Load()
+}
+
+// ShutdownOnSignal drains and gracefully shuts down the member when the process receives one of the signals,
+// SIGTERM and interrupt when none are given, as sent by Kubernetes when a pod is terminated.
+// The returned channel is closed once the member is shut down.
+func (c *Cluster) ShutdownOnSignal(signals ...os.Signal) <-chan struct{} {
+	if len(signals) == 0 {
+		signals = []os.Signal{syscall.SIGTERM, os.Interrupt}
+	}
+
+	received := make(chan os.Signal, 1)
+	signal.Notify(received, signals...)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		sig := <-received
+		signal.Stop(received)
+		c.Logger().Info("Shutting down cluster member on signal", slog.String("signal", sig.String()))
+		_ = c.Drain(context.Background())
+		c.Shutdown(true)
+	}()
+
+	return done
+}
+
+// stopActivations poisons the local activations and waits until they stopped, the activations left when ctx is
+// done are stopped without handling their remaining messages
+func (c *Cluster) stopActivations(ctx context.Context) error {
+	root := c.ActorSystem.Root
+
+	var futures []*actor.Future
+	for _, kind := range c.kinds {
+		for _, pid := range kind.Activations() {
+			futures = append(futures, root.PoisonFuture(pid))
+		}
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for _, future := range futures {
+			_ = future.Wait()
+		}
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+	}
+
+	var remaining int
+	for _, kind := range c.kinds {
+		for _, pid := range kind.Activations() {
+			root.Stop(pid)
+			remaining++
+		}
+	}
+	c.Logger().Warn("Stopped the activations which did not complete before the member drained", slog.Int("activations", remaining))
+
+	return ctx.Err()
+}
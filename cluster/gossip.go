@@ -9,6 +9,9 @@ import (
 // customary type that defines a states sender callback.
 type LocalStateSender func(memberStateDelta *MemberStateDelta, member *Member)
 
+// customary type that defines a digest sender callback.
+type DigestSender func(digest *GossipDigest, member *Member)
+
 // This interface must be implemented by any value that.
 // wants to be used as a gossip state storage
 type GossipStateStorer interface {
@@ -32,10 +35,20 @@ type GossipCore interface {
 	GetMemberStateDelta(targetMemberID string) *MemberStateDelta
 }
 
+// This interface must be implemented by any value that
+// wants to exchange state digests with other members, so they
+// only send each other the entries they miss
+type GossipAntiEntropy interface {
+	SendStateAndDigests(sendStateToMember LocalStateSender, sendDigestToMember DigestSender)
+	GetDigest() *GossipDigest
+	ReceiveDigest(memberID string, digest *GossipDigest) *MemberStateDelta
+}
+
 // The Gossip interface must be implemented by any value
 // that pretends to participate with-in the Gossip protocol
 type Gossip interface {
 	GossipStateStorer
 	GossipConsensusChecker
 	GossipCore
+	GossipAntiEntropy
 }
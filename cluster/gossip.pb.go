@@ -292,6 +292,165 @@ func (x *GossipDeltaValue) GetEntries() []*GossipDeltaValue_GossipDeltaEntry {
 	return nil
 }
 
+// versions of the states known by a member, the highest sequence number of the entries of each member
+type GossipDigest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Versions map[string]int64 `protobuf:"bytes,1,rep,name=versions,proto3" json:"versions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *GossipDigest) Reset() {
+	*x = GossipDigest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gossip_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GossipDigest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GossipDigest) ProtoMessage() {}
+
+func (x *GossipDigest) ProtoReflect() protoreflect.Message {
+	mi := &file_gossip_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GossipDigest.ProtoReflect.Descriptor instead.
+func (*GossipDigest) Descriptor() ([]byte, []int) {
+	return file_gossip_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GossipDigest) GetVersions() map[string]int64 {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+// sent to exchange the digests of two members, so each member only receives the entries it misses
+type GossipDigestRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MemberId string        `protobuf:"bytes,1,opt,name=member_id,json=memberId,proto3" json:"member_id,omitempty"` //member sending the request
+	Digest   *GossipDigest `protobuf:"bytes,2,opt,name=digest,proto3" json:"digest,omitempty"`
+}
+
+func (x *GossipDigestRequest) Reset() {
+	*x = GossipDigestRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gossip_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GossipDigestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GossipDigestRequest) ProtoMessage() {}
+
+func (x *GossipDigestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gossip_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GossipDigestRequest.ProtoReflect.Descriptor instead.
+func (*GossipDigestRequest) Descriptor() ([]byte, []int) {
+	return file_gossip_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GossipDigestRequest) GetMemberId() string {
+	if x != nil {
+		return x.MemberId
+	}
+	return ""
+}
+
+func (x *GossipDigestRequest) GetDigest() *GossipDigest {
+	if x != nil {
+		return x.Digest
+	}
+	return nil
+}
+
+type GossipDigestResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	State  *GossipState  `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"` //entries newer than the digest of the request
+	Digest *GossipDigest `protobuf:"bytes,2,opt,name=digest,proto3" json:"digest,omitempty"`
+}
+
+func (x *GossipDigestResponse) Reset() {
+	*x = GossipDigestResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gossip_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GossipDigestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GossipDigestResponse) ProtoMessage() {}
+
+func (x *GossipDigestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gossip_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GossipDigestResponse.ProtoReflect.Descriptor instead.
+func (*GossipDigestResponse) Descriptor() ([]byte, []int) {
+	return file_gossip_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GossipDigestResponse) GetState() *GossipState {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *GossipDigestResponse) GetDigest() *GossipDigest {
+	if x != nil {
+		return x.Digest
+	}
+	return nil
+}
+
 type GossipState_GossipMemberState struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -303,7 +462,7 @@ type GossipState_GossipMemberState struct {
 func (x *GossipState_GossipMemberState) Reset() {
 	*x = GossipState_GossipMemberState{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gossip_proto_msgTypes[5]
+		mi := &file_gossip_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -316,7 +475,7 @@ func (x *GossipState_GossipMemberState) String() string {
 func (*GossipState_GossipMemberState) ProtoMessage() {}
 
 func (x *GossipState_GossipMemberState) ProtoReflect() protoreflect.Message {
-	mi := &file_gossip_proto_msgTypes[5]
+	mi := &file_gossip_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -353,7 +512,7 @@ type GossipDeltaValue_GossipDeltaEntry struct {
 func (x *GossipDeltaValue_GossipDeltaEntry) Reset() {
 	*x = GossipDeltaValue_GossipDeltaEntry{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gossip_proto_msgTypes[8]
+		mi := &file_gossip_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -366,7 +525,7 @@ func (x *GossipDeltaValue_GossipDeltaEntry) String() string {
 func (*GossipDeltaValue_GossipDeltaEntry) ProtoMessage() {}
 
 func (x *GossipDeltaValue_GossipDeltaEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_gossip_proto_msgTypes[8]
+	mi := &file_gossip_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -456,10 +615,32 @@ var file_gossip_proto_rawDesc = []byte{
 	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x73, 0x65,
 	0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04,
 	0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61,
-	0x42, 0x2c, 0x5a, 0x2a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x61, 0x73, 0x79, 0x6e, 0x6b, 0x72, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x61, 0x63,
-	0x74, 0x6f, 0x72, 0x2d, 0x67, 0x6f, 0x2f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x22, 0x8c, 0x01, 0x0a, 0x0c, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x44, 0x69, 0x67, 0x65, 0x73,
+	0x74, 0x12, 0x3f, 0x0a, 0x08, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x2e, 0x47, 0x6f,
+	0x73, 0x73, 0x69, 0x70, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x1a, 0x3b, 0x0a, 0x0d, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0x61, 0x0a, 0x13, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x65, 0x6d, 0x62, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x2d, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x2e, 0x47, 0x6f,
+	0x73, 0x73, 0x69, 0x70, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65,
+	0x73, 0x74, 0x22, 0x71, 0x0a, 0x14, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x44, 0x69, 0x67, 0x65,
+	0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x05, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x6c, 0x75, 0x73,
+	0x74, 0x65, 0x72, 0x2e, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52,
+	0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x2d, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72,
+	0x2e, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x52, 0x06, 0x64,
+	0x69, 0x67, 0x65, 0x73, 0x74, 0x42, 0x2c, 0x5a, 0x2a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x73, 0x79, 0x6e, 0x6b, 0x72, 0x6f, 0x6e, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x2d, 0x67, 0x6f, 0x2f, 0x63, 0x6c, 0x75, 0x73,
+	0x74, 0x65, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -474,33 +655,41 @@ func file_gossip_proto_rawDescGZIP() []byte {
 	return file_gossip_proto_rawDescData
 }
 
-var file_gossip_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_gossip_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_gossip_proto_goTypes = []interface{}{
 	(*GossipRequest)(nil),                 // 0: cluster.GossipRequest
 	(*GossipResponse)(nil),                // 1: cluster.GossipResponse
 	(*GossipState)(nil),                   // 2: cluster.GossipState
 	(*GossipKeyValue)(nil),                // 3: cluster.GossipKeyValue
 	(*GossipDeltaValue)(nil),              // 4: cluster.GossipDeltaValue
-	(*GossipState_GossipMemberState)(nil), // 5: cluster.GossipState.GossipMemberState
-	nil,                                   // 6: cluster.GossipState.MembersEntry
-	nil,                                   // 7: cluster.GossipState.GossipMemberState.ValuesEntry
-	(*GossipDeltaValue_GossipDeltaEntry)(nil), // 8: cluster.GossipDeltaValue.GossipDeltaEntry
-	(*anypb.Any)(nil),                         // 9: google.protobuf.Any
+	(*GossipDigest)(nil),                  // 5: cluster.GossipDigest
+	(*GossipDigestRequest)(nil),           // 6: cluster.GossipDigestRequest
+	(*GossipDigestResponse)(nil),          // 7: cluster.GossipDigestResponse
+	(*GossipState_GossipMemberState)(nil), // 8: cluster.GossipState.GossipMemberState
+	nil,                                   // 9: cluster.GossipState.MembersEntry
+	nil,                                   // 10: cluster.GossipState.GossipMemberState.ValuesEntry
+	(*GossipDeltaValue_GossipDeltaEntry)(nil), // 11: cluster.GossipDeltaValue.GossipDeltaEntry
+	nil,               // 12: cluster.GossipDigest.VersionsEntry
+	(*anypb.Any)(nil), // 13: google.protobuf.Any
 }
 var file_gossip_proto_depIdxs = []int32{
-	2, // 0: cluster.GossipRequest.state:type_name -> cluster.GossipState
-	2, // 1: cluster.GossipResponse.state:type_name -> cluster.GossipState
-	6, // 2: cluster.GossipState.members:type_name -> cluster.GossipState.MembersEntry
-	9, // 3: cluster.GossipKeyValue.value:type_name -> google.protobuf.Any
-	8, // 4: cluster.GossipDeltaValue.entries:type_name -> cluster.GossipDeltaValue.GossipDeltaEntry
-	7, // 5: cluster.GossipState.GossipMemberState.values:type_name -> cluster.GossipState.GossipMemberState.ValuesEntry
-	5, // 6: cluster.GossipState.MembersEntry.value:type_name -> cluster.GossipState.GossipMemberState
-	3, // 7: cluster.GossipState.GossipMemberState.ValuesEntry.value:type_name -> cluster.GossipKeyValue
-	8, // [8:8] is the sub-list for method output_type
-	8, // [8:8] is the sub-list for method input_type
-	8, // [8:8] is the sub-list for extension type_name
-	8, // [8:8] is the sub-list for extension extendee
-	0, // [0:8] is the sub-list for field type_name
+	2,  // 0: cluster.GossipRequest.state:type_name -> cluster.GossipState
+	2,  // 1: cluster.GossipResponse.state:type_name -> cluster.GossipState
+	9,  // 2: cluster.GossipState.members:type_name -> cluster.GossipState.MembersEntry
+	13, // 3: cluster.GossipKeyValue.value:type_name -> google.protobuf.Any
+	11, // 4: cluster.GossipDeltaValue.entries:type_name -> cluster.GossipDeltaValue.GossipDeltaEntry
+	12, // 5: cluster.GossipDigest.versions:type_name -> cluster.GossipDigest.VersionsEntry
+	5,  // 6: cluster.GossipDigestRequest.digest:type_name -> cluster.GossipDigest
+	2,  // 7: cluster.GossipDigestResponse.state:type_name -> cluster.GossipState
+	5,  // 8: cluster.GossipDigestResponse.digest:type_name -> cluster.GossipDigest
+	10, // 9: cluster.GossipState.GossipMemberState.values:type_name -> cluster.GossipState.GossipMemberState.ValuesEntry
+	8,  // 10: cluster.GossipState.MembersEntry.value:type_name -> cluster.GossipState.GossipMemberState
+	3,  // 11: cluster.GossipState.GossipMemberState.ValuesEntry.value:type_name -> cluster.GossipKeyValue
+	12, // [12:12] is the sub-list for method output_type
+	12, // [12:12] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_gossip_proto_init() }
@@ -570,7 +759,31 @@ func file_gossip_proto_init() {
 			}
 		}
 		file_gossip_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GossipState_GossipMemberState); i {
+			switch v := v.(*GossipDigest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gossip_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GossipDigestRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gossip_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GossipDigestResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -582,6 +795,18 @@ func file_gossip_proto_init() {
 			}
 		}
 		file_gossip_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GossipState_GossipMemberState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gossip_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GossipDeltaValue_GossipDeltaEntry); i {
 			case 0:
 				return &v.state
@@ -600,7 +825,7 @@ func file_gossip_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_gossip_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
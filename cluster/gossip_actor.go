@@ -18,6 +18,8 @@ type empty struct{}
 type GossipActor struct {
 	gossipRequestTimeout time.Duration
 	gossip               Gossip
+	myID                 string
+	stats                *gossipStats
 
 	/// Message throttler
 	throttler actor.ShouldThrottle
@@ -31,6 +33,7 @@ func NewGossipActor(requestTimeout time.Duration, myID string, getBlockedMembers
 	gossipActor := GossipActor{
 		gossipRequestTimeout: requestTimeout,
 		gossip:               informer,
+		myID:                 myID,
 	}
 
 	gossipActor.throttler = actor.NewThrottleWithLogger(logger, 3, 60*time.Second, func(logger *slog.Logger, counter int32) {
@@ -40,6 +43,17 @@ func NewGossipActor(requestTimeout time.Duration, myID string, getBlockedMembers
 	return &gossipActor
 }
 
+// configure applies the anti-entropy and message size settings of the config to
+// the informer, and records the gossip traffic in the stats
+func (ga *GossipActor) configure(config *Config, stats *gossipStats) {
+	ga.stats = stats
+	if informer, ok := ga.gossip.(*Informer); ok {
+		informer.antiEntropyInterval = config.GossipAntiEntropyInterval
+		informer.maxMessageSize = config.GossipMaxMessageSize
+		informer.stats = stats
+	}
+}
+
 // Receive method.
 func (ga *GossipActor) Receive(ctx actor.Context) {
 	switch r := ctx.Message().(type) {
@@ -51,6 +65,8 @@ func (ga *GossipActor) Receive(ctx actor.Context) {
 		ga.onGetGossipStateKey(r, ctx)
 	case *GossipRequest:
 		ga.onGossipRequest(r, ctx)
+	case *GossipDigestRequest:
+		ga.onGossipDigestRequest(r, ctx)
 	case *SendGossipStateRequest:
 		ga.onSendGossipState(ctx)
 	case *AddConsensusCheck:
@@ -88,6 +104,7 @@ func (ga *GossipActor) onGossipRequest(r *GossipRequest, ctx actor.Context) {
 	if ga.throttler() == actor.Open {
 		ctx.Logger().Debug("OnGossipRequest", slog.Any("sender", ctx.Sender()))
 	}
+	ga.stats.onReceived(r, r.State)
 	ga.ReceiveState(r.State, ctx)
 
 	if !GetCluster(ctx.ActorSystem()).MemberList.ContainsMemberID(r.MemberId) {
@@ -100,60 +117,48 @@ func (ga *GossipActor) onGossipRequest(r *GossipRequest, ctx actor.Context) {
 		return
 	}
 
-	memberState := ga.gossip.GetMemberStateDelta(r.MemberId)
-	if !memberState.HasState {
-		ctx.Logger().Warn("Got gossip request from member, but no state was found", slog.String("MemberId", r.MemberId))
-
-		// nothing to send, do not provide sender or state payload
-		ctx.Respond(&GossipResponse{})
+	ctx.Respond(&GossipResponse{})
+}
 
-		return
+// onGossipDigestRequest responds the entries the requesting member misses according to its digest, with the
+// digest of this member so the requester sends back the entries this member misses
+func (ga *GossipActor) onGossipDigestRequest(r *GossipDigestRequest, ctx actor.Context) {
+	ga.stats.onReceived(r, nil)
+	memberState := ga.gossip.ReceiveDigest(r.MemberId, r.Digest)
+
+	// the response is not acknowledged, the entries lost with it are repaired by the next digest exchanges
+	memberState.CommitOffsets()
+	res := &GossipDigestResponse{
+		State:  memberState.State,
+		Digest: ga.gossip.GetDigest(),
 	}
-
-	ctx.Respond(&GossipResponse{})
-	return
-
-	// turn off acking for now
-
-	//msg := GossipResponse{
-	//	State: memberState.State,
-	//}
-	//future := ctx.RequestFuture(ctx.Sender(), &msg, GetCluster(ctx.ActorSystem()).Config.GossipRequestTimeout)
-	//
-	//ctx.ReenterAfter(future, func(res interface{}, err error) {
-	//	if err != nil {
-	//		plog.Warn("onGossipRequest failed", log.String("MemberId", r.MemberId), log.Error(err))
-	//		return
-	//	}
-	//
-	//	if _, ok := res.(*GossipResponseAck); ok {
-	//		memberState.CommitOffsets()
-	//		return
-	//	}
-	//
-	//	m, ok := res.(proto.Message)
-	//	if !ok {
-	//		plog.Warn("onGossipRequest failed", log.String("MemberId", r.MemberId), log.Error(err))
-	//		return
-	//	}
-	//	n := string(proto.MessageName(m).Name())
-	//
-	//	plog.Error("onGossipRequest received unknown response message", log.String("type", n), log.Message(r))
-	//})
+	ga.stats.onSent(res, res.State)
+	ctx.Respond(res)
 }
 
 func (ga *GossipActor) onSetGossipStateKey(r *SetGossipStateKey, ctx actor.Context) {
 	key, message := r.Key, r.Value
 	ga.gossip.SetState(key, message)
 
+	if value, ok := ga.gossip.GetState(key)[ga.myID]; ok {
+		ctx.ActorSystem().EventStream.Publish(&localGossipUpdate{&GossipUpdate{
+			MemberID:  ga.myID,
+			Key:       key,
+			Value:     value.Value,
+			SeqNumber: value.SequenceNumber,
+		}})
+	}
+
 	if ctx.Sender() != nil {
 		ctx.Respond(&SetGossipStateResponse{})
 	}
 }
 
 func (ga *GossipActor) onSendGossipState(ctx actor.Context) {
-	ga.gossip.SendState(func(memberState *MemberStateDelta, member *Member) {
+	ga.gossip.SendStateAndDigests(func(memberState *MemberStateDelta, member *Member) {
 		ga.sendGossipForMember(member, memberState, ctx)
+	}, func(digest *GossipDigest, member *Member) {
+		ga.sendDigestForMember(member, digest, ctx)
 	})
 	ctx.Respond(&SendGossipStateResponse{})
 }
@@ -179,6 +184,7 @@ func (ga *GossipActor) sendGossipForMember(member *Member, memberStateDelta *Mem
 		MemberId: member.Id,
 		State:    memberStateDelta.State,
 	}
+	ga.stats.onSent(&msg, msg.State)
 	future := ctx.RequestFuture(pid, &msg, ga.gossipRequestTimeout)
 
 	ctx.ReenterAfter(future, func(res interface{}, err error) {
@@ -201,3 +207,38 @@ func (ga *GossipActor) sendGossipForMember(member *Member, memberStateDelta *Mem
 		}
 	})
 }
+
+func (ga *GossipActor) sendDigestForMember(member *Member, digest *GossipDigest, ctx actor.Context) {
+	pid := actor.NewPID(member.Address(), DefaultGossipActorName)
+	msg := GossipDigestRequest{
+		MemberId: ga.myID,
+		Digest:   digest,
+	}
+	ga.stats.onSent(&msg, nil)
+	future := ctx.RequestFuture(pid, &msg, ga.gossipRequestTimeout)
+
+	ctx.ReenterAfter(future, func(res interface{}, err error) {
+		if err != nil {
+			ctx.Logger().Warn("sendDigestForMember failed", slog.String("MemberId", member.Id), slog.Any("error", err))
+			return
+		}
+
+		resp, ok := res.(*GossipDigestResponse)
+		if !ok {
+			ctx.Logger().Error("sendDigestForMember received unknown response message", slog.Any("message", res))
+			return
+		}
+
+		ga.stats.onReceived(resp, resp.State)
+		ga.stats.onDigestExchanged()
+		if resp.State != nil {
+			ga.ReceiveState(resp.State, ctx)
+		}
+
+		// send back the entries the member misses
+		memberState := ga.gossip.ReceiveDigest(member.Id, resp.Digest)
+		if memberState.HasState {
+			ga.sendGossipForMember(member, memberState, ctx)
+		}
+	})
+}
@@ -0,0 +1,112 @@
+package cluster
+
+import (
+	"math"
+	"time"
+)
+
+// The informers exchange digests of their states, the version of the state of each member, so they only send each
+// other the entries they miss. A digest exchange is three-way: the requester sends its digest, the response
+// contains the entries the requester misses and the digest of the responder, then the requester sends the entries
+// the responder misses. The digests set the watermarks of the deltas gossiped afterwards.
+
+// bootstrapDigestInterval is the interval of the digest exchanges of an informer which did not exchange any digest
+// yet, when its previous exchange failed
+const bootstrapDigestInterval = time.Second
+
+// maxPendingStateChanges bounds the local state changes waiting for the convergence of the state
+const maxPendingStateChanges = 1024
+
+// stateChange is a change of the local state, pending until all members have it
+type stateChange struct {
+	seqNumber int64
+	at        time.Time
+}
+
+// returns the key of the watermark of the state of the member sent to the target member
+func gossipWatermarkKey(targetMemberID, memberID string) string {
+	return targetMemberID + "." + memberID
+}
+
+// returns the version of the state of each member known by this informer
+func (inf *Informer) GetDigest() *GossipDigest {
+	digest := &GossipDigest{Versions: make(map[string]int64, len(inf.state.Members))}
+	for memberID, memberState := range inf.state.Members {
+		var version int64
+		for _, value := range memberState.Values {
+			if value.SequenceNumber > version {
+				version = value.SequenceNumber
+			}
+		}
+		digest.Versions[memberID] = version
+	}
+
+	return digest
+}
+
+// receives the digest of a member, it sets the watermarks of the member to its versions
+// and returns the entries the member misses
+func (inf *Informer) ReceiveDigest(memberID string, digest *GossipDigest) *MemberStateDelta {
+	inf.synced[memberID] = empty{}
+	for id := range inf.state.Members {
+		inf.committedOffsets[gossipWatermarkKey(memberID, id)] = digest.GetVersions()[id]
+	}
+	inf.checkConvergence()
+
+	return inf.GetMemberStateDelta(memberID)
+}
+
+// returns true if this informer exchanged digests with the member
+func (inf *Informer) isSynced(memberID string) bool {
+	_, ok := inf.synced[memberID]
+	return ok
+}
+
+// returns true if a digest is exchanged with a random member in this round
+func (inf *Informer) antiEntropyDue() bool {
+	interval := inf.antiEntropyInterval
+	if len(inf.synced) == 0 && (interval <= 0 || interval > bootstrapDigestInterval) {
+		interval = bootstrapDigestInterval
+	}
+	if interval <= 0 {
+		return false
+	}
+
+	return time.Since(inf.lastAntiEntropy) >= interval
+}
+
+// tracks the change of the local state until all members have it
+func (inf *Informer) trackChange(seqNumber int64) {
+	if len(inf.pendingChanges) >= maxPendingStateChanges {
+		inf.pendingChanges = inf.pendingChanges[1:]
+	}
+	inf.pendingChanges = append(inf.pendingChanges, stateChange{seqNumber: seqNumber, at: time.Now()})
+}
+
+// records the convergence time of the changes of the local state which all other members have, as known from their
+// acknowledgements and digests
+func (inf *Informer) checkConvergence() {
+	if len(inf.pendingChanges) == 0 {
+		return
+	}
+
+	version := int64(math.MaxInt64)
+	for _, member := range inf.otherMembers {
+		if offset := inf.committedOffsets[gossipWatermarkKey(member.Id, inf.myID)]; offset < version {
+			version = offset
+		}
+	}
+
+	converged := 0
+	for converged < len(inf.pendingChanges) && inf.pendingChanges[converged].seqNumber <= version {
+		converged++
+	}
+	if converged == 0 {
+		return
+	}
+
+	if len(inf.otherMembers) > 0 {
+		inf.stats.onConverged(time.Since(inf.pendingChanges[converged-1].at))
+	}
+	inf.pendingChanges = inf.pendingChanges[converged:]
+}
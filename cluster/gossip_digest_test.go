@@ -0,0 +1,135 @@
+package cluster
+
+import (
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/asynkron/gofun/set"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func newTestInformer(myID string, others ...string) *Informer {
+	inf := newInformer(myID, func() set.Set[string] { return set.New[string]() }, 3, 50, slog.Default())
+	inf.stats = &gossipStats{}
+	members := []*Member{{Id: myID, Host: myID, Port: 1}}
+	for i, id := range others {
+		members = append(members, &Member{Id: id, Host: id, Port: int32(i + 2)})
+	}
+	inf.UpdateClusterTopology(&ClusterTopology{Members: members})
+	return inf
+}
+
+func remoteValue(t *testing.T, sequenceNumber int64) *GossipKeyValue {
+	value, err := anypb.New(&MemberLabels{})
+	require.NoError(t, err)
+	return &GossipKeyValue{SequenceNumber: sequenceNumber, Value: value}
+}
+
+func TestInformer_GetDigest(t *testing.T) {
+	inf := newTestInformer("member1")
+	inf.SetState("a", &MemberLabels{})
+	inf.SetState("b", &MemberLabels{})
+	inf.ReceiveState(&GossipState{Members: GossipMemberStates{
+		"member2": {Values: GossipKeyValues{"a": remoteValue(t, 7), "b": remoteValue(t, 4)}},
+	}})
+
+	digest := inf.GetDigest()
+	assert.Equal(t, inf.localSeqNumber, digest.Versions["member1"])
+	assert.Equal(t, int64(7), digest.Versions["member2"])
+}
+
+func TestInformer_ReceiveDigestReturnsTheMissingEntries(t *testing.T) {
+	inf := newTestInformer("member1", "member2")
+	inf.SetState("a", &MemberLabels{})
+	seqA := inf.localSeqNumber
+	inf.SetState("b", &MemberLabels{})
+	inf.ReceiveState(&GossipState{Members: GossipMemberStates{
+		"member3": {Values: GossipKeyValues{"c": remoteValue(t, 3)}},
+	}})
+
+	// member2 has our state up to a, and nothing of member3
+	delta := inf.ReceiveDigest("member2", &GossipDigest{Versions: map[string]int64{"member1": seqA}})
+	require.True(t, delta.HasState)
+	assert.NotContains(t, delta.State.Members["member1"].Values, "a")
+	assert.Contains(t, delta.State.Members["member1"].Values, "b")
+	assert.Contains(t, delta.State.Members["member3"].Values, "c")
+	assert.True(t, inf.isSynced("member2"))
+
+	// once member2 acknowledged the entries, it has everything
+	delta.CommitOffsets()
+	assert.False(t, inf.GetMemberStateDelta("member2").HasState)
+	assert.False(t, inf.ReceiveDigest("member2", inf.GetDigest()).HasState)
+}
+
+func TestInformer_GetMemberStateDeltaIsSizeBounded(t *testing.T) {
+	inf := newTestInformer("member1", "member2")
+	inf.maxMessageSize = 200
+	for _, key := range []string{"a", "b", "c", "d"} {
+		inf.SetState(key, &MemberLabels{Labels: map[string]string{"value": strings.Repeat(key, 100)}})
+	}
+
+	// the entries are sent in sequence order over several messages
+	var received []string
+	for i := 0; i < 10; i++ {
+		delta := inf.GetMemberStateDelta("member2")
+		if !delta.HasState {
+			break
+		}
+		memberState := delta.State.Members["member1"]
+		assert.Len(t, memberState.Values, 1, "a message holds a single entry of the size")
+		for _, key := range newerKeys(memberState, 0) {
+			received = append(received, key)
+		}
+		delta.CommitOffsets()
+	}
+
+	assert.Equal(t, []string{TopologyKey, "a", "b", "c", "d"}, received)
+}
+
+func TestInformer_SendStateAndDigests(t *testing.T) {
+	inf := newTestInformer("member1", "member2", "member3")
+	inf.antiEntropyInterval = 0
+	inf.SetState("a", &MemberLabels{})
+
+	var pushed, digested []string
+	send := func() {
+		pushed, digested = nil, nil
+		inf.SendStateAndDigests(func(_ *MemberStateDelta, member *Member) {
+			pushed = append(pushed, member.Id)
+		}, func(_ *GossipDigest, member *Member) {
+			digested = append(digested, member.Id)
+		})
+	}
+
+	// a joining informer only exchanges digests with a single member
+	send()
+	assert.Empty(t, pushed)
+	assert.Len(t, digested, 1)
+
+	// the members it exchanged digests with receive deltas, the others its digest
+	send()
+	assert.Empty(t, pushed)
+	assert.Empty(t, digested, "the bootstrap digest is sent again after an interval")
+
+	inf.ReceiveDigest("member2", &GossipDigest{})
+	send()
+	assert.Equal(t, []string{"member2"}, pushed)
+	assert.Equal(t, []string{"member3"}, digested)
+}
+
+func TestInformer_RecordsConvergenceTime(t *testing.T) {
+	inf := newTestInformer("member1", "member2", "member3")
+	inf.SetState("a", &MemberLabels{})
+
+	inf.ReceiveDigest("member2", &GossipDigest{Versions: map[string]int64{"member1": inf.localSeqNumber}})
+	assert.NotEmpty(t, inf.pendingChanges, "member3 does not have the state yet")
+
+	delta := inf.GetMemberStateDelta("member3")
+	require.True(t, delta.HasState)
+	delta.CommitOffsets()
+	assert.Empty(t, inf.pendingChanges)
+	assert.Positive(t, inf.stats.snapshot().ConvergenceTime)
+}
@@ -0,0 +1,116 @@
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/asynkron/protoactor-go/eventstream"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// GossipKey is a key of the gossip state whose values are messages of type T
+//
+//	labels := cluster.NewGossipKey[*cluster.MemberLabels](c.Gossip, cluster.LabelsKey)
+//	labels.Subscribe(func(change cluster.GossipKeyChange[*cluster.MemberLabels]) { ... })
+type GossipKey[T proto.Message] struct {
+	gossiper *Gossiper
+	key      string
+}
+
+// GossipKeyChange is a change of the value of a gossip key of a member
+type GossipKeyChange[T proto.Message] struct {
+	MemberID  string
+	Value     T
+	SeqNumber int64
+}
+
+// localGossipUpdate is published on the event stream when the state of this member changes, the GossipUpdate
+// events are only published for the state received from other members
+type localGossipUpdate struct {
+	*GossipUpdate
+}
+
+// NewGossipKey returns the key of the gossip state of the gossiper
+func NewGossipKey[T proto.Message](gossiper *Gossiper, key string) *GossipKey[T] {
+	return &GossipKey[T]{gossiper: gossiper, key: key}
+}
+
+// Key returns the name of the key
+func (k *GossipKey[T]) Key() string {
+	return k.key
+}
+
+// SetState sets the value of the key of this member
+func (k *GossipKey[T]) SetState(value T) {
+	k.gossiper.SetState(k.key, value)
+}
+
+// SetStateRequest sets the value of the key of this member, it blocks until the gossip state is updated
+func (k *GossipKey[T]) SetStateRequest(value T) error {
+	return k.gossiper.SetStateRequest(k.key, value)
+}
+
+// Get returns the value of the key of each member which has one
+func (k *GossipKey[T]) Get() (map[string]T, error) {
+	state, err := k.gossiper.GetState(k.key)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]T, len(state))
+	for memberID, keyValue := range state {
+		value, err := unpackGossipValue[T](keyValue.Value)
+		if err != nil {
+			return nil, fmt.Errorf("gossip key %s of member %s: %w", k.key, memberID, err)
+		}
+		values[memberID] = value
+	}
+
+	return values, nil
+}
+
+// Subscribe calls the handler each time the value of the key of a member changes, including this member.
+// The values which are not of type T are skipped.
+func (k *GossipKey[T]) Subscribe(handler func(change GossipKeyChange[T])) *eventstream.Subscription {
+	return k.gossiper.cluster.ActorSystem.EventStream.Subscribe(func(evt interface{}) {
+		var update *GossipUpdate
+		switch msg := evt.(type) {
+		case *GossipUpdate:
+			update = msg
+		case *localGossipUpdate:
+			update = msg.GossipUpdate
+		default:
+			return
+		}
+		if update.Key != k.key {
+			return
+		}
+
+		value, err := unpackGossipValue[T](update.Value)
+		if err != nil {
+			return
+		}
+		handler(GossipKeyChange[T]{MemberID: update.MemberID, Value: value, SeqNumber: update.SeqNumber})
+	})
+}
+
+// Unsubscribe stops the subscription returned by Subscribe
+func (k *GossipKey[T]) Unsubscribe(sub *eventstream.Subscription) {
+	k.gossiper.cluster.ActorSystem.EventStream.Unsubscribe(sub)
+}
+
+// unpackGossipValue unpacks the gossiped value into a message of type T
+func unpackGossipValue[T proto.Message](value *anypb.Any) (T, error) {
+	var zero T
+	message, err := value.UnmarshalNew()
+	if err != nil {
+		return zero, err
+	}
+
+	typed, ok := message.(T)
+	if !ok {
+		return zero, fmt.Errorf("gossiped value is a %T", message)
+	}
+
+	return typed, nil
+}
@@ -0,0 +1,22 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestUnpackGossipValue(t *testing.T) {
+	value, err := anypb.New(wrapperspb.String("hello"))
+	require.NoError(t, err)
+
+	typed, err := unpackGossipValue[*wrapperspb.StringValue](value)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", typed.Value)
+
+	_, err = unpackGossipValue[*MemberLabels](value)
+	assert.Error(t, err)
+}
@@ -0,0 +1,139 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/asynkron/protoactor-go/metrics"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/protobuf/proto"
+)
+
+// GossipStats is a snapshot of the gossip traffic of a member
+type GossipStats struct {
+	MessagesSent     int64
+	MessagesReceived int64
+	BytesSent        int64
+	BytesReceived    int64
+	EntriesSent      int64
+	EntriesReceived  int64
+	DigestExchanges  int64
+	// ConvergenceTime is the time the last converged change of the local state took to reach all members,
+	// as known from their acknowledgements and digests
+	ConvergenceTime time.Duration
+}
+
+type gossipStats struct {
+	messagesSent     atomic.Int64
+	messagesReceived atomic.Int64
+	bytesSent        atomic.Int64
+	bytesReceived    atomic.Int64
+	entriesSent      atomic.Int64
+	entriesReceived  atomic.Int64
+	digestExchanges  atomic.Int64
+	convergenceTime  atomic.Int64
+}
+
+// countEntries returns the number of entries of the state
+func countEntries(state *GossipState) int64 {
+	var count int64
+	for _, memberState := range state.GetMembers() {
+		count += int64(len(memberState.Values))
+	}
+	return count
+}
+
+func (s *gossipStats) onSent(message proto.Message, state *GossipState) {
+	if s == nil {
+		return
+	}
+	s.messagesSent.Add(1)
+	s.bytesSent.Add(int64(proto.Size(message)))
+	s.entriesSent.Add(countEntries(state))
+}
+
+func (s *gossipStats) onReceived(message proto.Message, state *GossipState) {
+	if s == nil {
+		return
+	}
+	s.messagesReceived.Add(1)
+	s.bytesReceived.Add(int64(proto.Size(message)))
+	s.entriesReceived.Add(countEntries(state))
+}
+
+func (s *gossipStats) onDigestExchanged() {
+	if s == nil {
+		return
+	}
+	s.digestExchanges.Add(1)
+}
+
+func (s *gossipStats) onConverged(d time.Duration) {
+	if s == nil {
+		return
+	}
+	s.convergenceTime.Store(int64(d))
+}
+
+func (s *gossipStats) snapshot() GossipStats {
+	return GossipStats{
+		MessagesSent:     s.messagesSent.Load(),
+		MessagesReceived: s.messagesReceived.Load(),
+		BytesSent:        s.bytesSent.Load(),
+		BytesReceived:    s.bytesReceived.Load(),
+		EntriesSent:      s.entriesSent.Load(),
+		EntriesReceived:  s.entriesReceived.Load(),
+		DigestExchanges:  s.digestExchanges.Load(),
+		ConvergenceTime:  time.Duration(s.convergenceTime.Load()),
+	}
+}
+
+// Stats returns the statistics of the gossip traffic of this member
+func (g *Gossiper) Stats() GossipStats {
+	return g.stats.snapshot()
+}
+
+func (g *Gossiper) registerMetrics() {
+	if g.cluster.ActorSystem.Config.MetricsProvider == nil {
+		return
+	}
+
+	instruments := metrics.NewGossipMetrics(g.cluster.Logger())
+	meter := otel.Meter(metrics.LibName)
+
+	registration, err := meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		stats := g.stats.snapshot()
+		attrs := metric.WithAttributes(
+			attribute.String("address", g.cluster.ActorSystem.Address()),
+		)
+		o.ObserveInt64(instruments.GossipMessagesSent, stats.MessagesSent, attrs)
+		o.ObserveInt64(instruments.GossipMessagesReceived, stats.MessagesReceived, attrs)
+		o.ObserveInt64(instruments.GossipBytesSent, stats.BytesSent, attrs)
+		o.ObserveInt64(instruments.GossipBytesReceived, stats.BytesReceived, attrs)
+		o.ObserveInt64(instruments.GossipDigestExchanges, stats.DigestExchanges, attrs)
+		o.ObserveFloat64(instruments.GossipConvergenceTime, stats.ConvergenceTime.Seconds(), attrs)
+		return nil
+	}, instruments.Observables()...)
+	if err != nil {
+		err = fmt.Errorf("failed to instrument gossip, %w", err)
+		g.cluster.Logger().Error(err.Error(), slog.Any("error", err))
+		return
+	}
+
+	g.metricsRegistration = registration
+}
+
+func (g *Gossiper) unregisterMetrics() {
+	if g.metricsRegistration == nil {
+		return
+	}
+	if err := g.metricsRegistration.Unregister(); err != nil {
+		g.cluster.Logger().Error("failed to unregister gossip metrics", slog.Any("error", err))
+	}
+	g.metricsRegistration = nil
+}
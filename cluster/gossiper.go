@@ -15,6 +15,7 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"github.com/asynkron/protoactor-go/actor"
+	"go.opentelemetry.io/otel/metric"
 	"google.golang.org/protobuf/types/known/anypb"
 )
 
@@ -49,6 +50,13 @@ type Gossiper struct {
 
 	// Message throttler
 	throttler actor.ShouldThrottle
+
+	// unix milliseconds when the gossip started, see MemberHeartbeat
+	startedAt int64
+
+	// Statistics of the gossip traffic
+	stats               *gossipStats
+	metricsRegistration metric.Registration
 }
 
 // Creates a new Gossiper value and return it back
@@ -58,6 +66,7 @@ func newGossiper(cl *Cluster, opts ...Option) (*Gossiper, error) {
 		GossipActorName: DefaultGossipActorName,
 		cluster:         cl,
 		close:           make(chan struct{}),
+		stats:           &gossipStats{},
 	}
 
 	// apply any given options
@@ -175,7 +184,7 @@ func (g *Gossiper) RegisterConsensusCheck(key string, getValue func(*anypb.Any)
 func (g *Gossiper) StartGossiping() error {
 	var err error
 	g.pid, err = g.cluster.ActorSystem.Root.SpawnNamed(actor.PropsFromProducerWithActorSystem(func(system *actor.ActorSystem) actor.Actor {
-		return NewGossipActor(
+		gossipActor := NewGossipActor(
 			g.cluster.Config.GossipRequestTimeout,
 			g.cluster.ActorSystem.ID,
 			func() set.Set[string] {
@@ -185,6 +194,8 @@ func (g *Gossiper) StartGossiping() error {
 			g.cluster.Config.GossipMaxSend,
 			system,
 		)
+		gossipActor.configure(g.cluster.Config, g.stats)
+		return gossipActor
 	}), g.GossipActorName)
 	if err != nil {
 		g.cluster.Logger().Error("Failed to start gossip actor", slog.Any("error", err))
@@ -196,6 +207,8 @@ func (g *Gossiper) StartGossiping() error {
 			g.cluster.ActorSystem.Root.Send(g.pid, topology)
 		}
 	})
+	g.registerMetrics()
+	g.startedAt = time.Now().UnixMilli()
 	g.cluster.Logger().Info("Started Cluster Gossip")
 	g.throttler = actor.NewThrottle(3, 60*time.Second, g.throttledLog)
 	go g.gossipLoop()
@@ -211,6 +224,7 @@ func (g *Gossiper) Shutdown() {
 	g.cluster.Logger().Info("Shutting down gossip")
 
 	close(g.close)
+	g.unregisterMetrics()
 
 	err := g.cluster.ActorSystem.Root.StopFuture(g.pid).Wait()
 	if err != nil {
@@ -227,6 +241,10 @@ func (g *Gossiper) gossipLoop() {
 	// we do not use sleep as sleep puts the goroutine out of the scheduler
 	// P, and we do not want our Gs to be scheduled out from the running Ms
 	ticker := time.NewTicker(g.cluster.Config.GossipInterval)
+
+	if labels := g.cluster.Labels(); len(labels) > 0 {
+		g.SetState(LabelsKey, &MemberLabels{Labels: labels})
+	}
 breakLoop:
 	for !g.cluster.ActorSystem.IsStopped() {
 		select {
@@ -239,15 +257,25 @@ breakLoop:
 			g.blockGracefullyLeft()
 
 			g.SetState(HearthbeatKey, &MemberHeartbeat{
-				// todo collect the actor statistics
-				ActorStatistics: &ActorStatistics{},
+				ActorStatistics: g.actorStatistics(),
+				StartedAt:       g.startedAt,
 			})
 			g.SendState()
 		}
 	}
 }
 
-// blockExpiredHeartbeats blocks members that have not sent a heartbeat for a long time
+// actorStatistics returns the number of activations per kind on this member
+func (g *Gossiper) actorStatistics() *ActorStatistics {
+	stats := &ActorStatistics{ActorCount: make(map[string]int64, len(g.cluster.kinds))}
+	for name, kind := range g.cluster.kinds {
+		stats.ActorCount[name] = int64(kind.Count())
+	}
+	return stats
+}
+
+// blockExpiredHeartbeats blocks members that have not sent a heartbeat for a long time, the split brain resolver
+// blocks them instead when it is enabled
 func (g *Gossiper) blockExpiredHeartbeats() {
 	if g.cluster.Config.GossipInterval == 0 {
 		return
@@ -270,6 +298,11 @@ func (g *Gossiper) blockExpiredHeartbeats() {
 		}
 	}
 
+	if g.cluster.SplitBrainResolver.enabled() {
+		g.cluster.SplitBrainResolver.onHeartbeatsExpired(blocked)
+		return
+	}
+
 	if len(blocked) > 0 {
 		g.cluster.Logger().Info("Blocking members due to expired heartbeat", slog.String("members", strings.Join(blocked, ",")))
 		blockList.Block(blocked...)
@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"github.com/asynkron/protoactor-go/actor"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
 )
 
 type GrainCallConfig struct {
@@ -11,17 +13,18 @@ type GrainCallConfig struct {
 	Timeout     time.Duration
 	RetryAction func(n int) int
 	Context     actor.SenderContext
+	// RetryPolicy overrides the policies of the kind and of the cluster, see RetryPolicy
+	RetryPolicy *RetryPolicy
+	// RequestID is sent in the RequestIDHeader of each attempt of the call, see Kind.WithDeduplication
+	RequestID string
 }
 
 type GrainCallOption func(config *GrainCallConfig)
 
-var defaultGrainCallOptions *GrainCallConfig
-
+// DefaultGrainCallConfig returns a new call config for the cluster.
+// The config is not shared between calls, as call options modify it and it holds the cluster's root context.
 func DefaultGrainCallConfig(cluster *Cluster) *GrainCallConfig {
-	if defaultGrainCallOptions == nil {
-		defaultGrainCallOptions = NewGrainCallOptions(cluster)
-	}
-	return defaultGrainCallOptions
+	return NewGrainCallOptions(cluster)
 }
 
 func NewGrainCallOptions(cluster *Cluster) *GrainCallConfig {
@@ -62,7 +65,30 @@ func WithContext(ctx actor.SenderContext) GrainCallOption {
 	}
 }
 
+// WithRetryPolicy sets the retry policy of the call, RequestFuture rejects it
+func WithRetryPolicy(policy *RetryPolicy) GrainCallOption {
+	return func(config *GrainCallConfig) {
+		config.RetryPolicy = policy
+	}
+}
+
+// WithRequestID sets the request id of the call, the retries of the call and the calls repeating the id
+// run only once on grains of kinds with deduplication
+func WithRequestID(id string) GrainCallOption {
+	return func(config *GrainCallConfig) {
+		config.RequestID = id
+	}
+}
+
+// WithIdempotency sets a new request id for the call, so its retries run only once on grains of kinds with deduplication
+func WithIdempotency() GrainCallOption {
+	return WithRequestID(uuid.NewString())
+}
+
 type ClusterInit struct {
 	Identity *ClusterIdentity
 	Cluster  *Cluster
+	// HandoffState is the state set by the previous activation through GrainHandoff.SetState,
+	// it is nil unless the grain was migrated from another member
+	HandoffState proto.Message
 }
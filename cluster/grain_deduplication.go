@@ -0,0 +1,135 @@
+package cluster
+
+import (
+	"sync"
+
+	"github.com/asynkron/protoactor-go/actor"
+)
+
+// RequestIDHeader is the message header holding the request id of a grain call, see WithRequestID
+const RequestIDHeader = "cluster-request-id"
+
+// WithDeduplication runs the calls to each identity of the kind at most once per request id.
+// The grain keeps the responses of the last windowSize request ids, a call repeating one of them gets the
+// cached response instead of running again, and a call repeating a request still running gets its response
+// once it completes. Calls without request id are not deduplicated, see WithRequestID and WithIdempotency.
+func (k *Kind) WithDeduplication(windowSize int) *Kind {
+	d := &deduplicator{windowSize: windowSize, windows: map[string]*deduplicationWindow{}}
+	k.Props = k.Props.Clone(
+		actor.WithReceiverMiddleware(d.receiverMiddleware),
+		actor.WithSenderMiddleware(d.senderMiddleware),
+	)
+
+	return k
+}
+
+// deduplicator holds the deduplication windows of the activations of a kind
+type deduplicator struct {
+	windowSize int
+
+	mu      sync.Mutex
+	windows map[string]*deduplicationWindow
+}
+
+// deduplicationEntry is a request of the window, the response is set once the grain responded
+type deduplicationEntry struct {
+	requestID string
+	sender    *actor.PID
+	waiting   []*actor.PID
+	response  interface{}
+	responded bool
+}
+
+// deduplicationWindow holds the latest requests of an activation, it is only used by the activation
+type deduplicationWindow struct {
+	entries  []*deduplicationEntry
+	byID     map[string]*deduplicationEntry
+	bySender map[string]*deduplicationEntry
+}
+
+func (d *deduplicator) window(self *actor.PID, create bool) *deduplicationWindow {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	w, ok := d.windows[self.Id]
+	if !ok && create {
+		w = &deduplicationWindow{byID: map[string]*deduplicationEntry{}, bySender: map[string]*deduplicationEntry{}}
+		d.windows[self.Id] = w
+	}
+
+	return w
+}
+
+func (d *deduplicator) receiverMiddleware(next actor.ReceiverFunc) actor.ReceiverFunc {
+	return func(c actor.ReceiverContext, envelope *actor.MessageEnvelope) {
+		if _, ok := envelope.Message.(*actor.Stopped); ok {
+			d.mu.Lock()
+			delete(d.windows, c.Self().Id)
+			d.mu.Unlock()
+			next(c, envelope)
+			return
+		}
+
+		requestID := envelope.GetHeader(RequestIDHeader)
+		if requestID == "" || envelope.Sender == nil {
+			next(c, envelope)
+			return
+		}
+
+		w := d.window(c.Self(), true)
+		if entry, ok := w.byID[requestID]; ok {
+			if entry.responded {
+				c.ActorSystem().Root.Send(envelope.Sender, entry.response)
+			} else {
+				entry.waiting = append(entry.waiting, envelope.Sender)
+			}
+			return
+		}
+
+		entry := &deduplicationEntry{requestID: requestID, sender: envelope.Sender}
+		w.add(entry, d.windowSize)
+		next(c, envelope)
+	}
+}
+
+func (d *deduplicator) senderMiddleware(next actor.SenderFunc) actor.SenderFunc {
+	return func(c actor.SenderContext, target *actor.PID, envelope *actor.MessageEnvelope) {
+		w := d.window(c.Self(), false)
+		if w == nil {
+			next(c, target, envelope)
+			return
+		}
+
+		entry, ok := w.bySender[target.String()]
+		if !ok {
+			next(c, target, envelope)
+			return
+		}
+
+		// the first message sent to the sender of a request is its response
+		delete(w.bySender, target.String())
+		entry.response = envelope.Message
+		entry.responded = true
+		next(c, target, envelope)
+
+		for _, waiting := range entry.waiting {
+			next(c, waiting, envelope)
+		}
+		entry.waiting = nil
+	}
+}
+
+func (w *deduplicationWindow) add(entry *deduplicationEntry, size int) {
+	if size > 0 && len(w.entries) >= size {
+		oldest := w.entries[0]
+		w.entries = w.entries[1:]
+		delete(w.byID, oldest.requestID)
+		if !oldest.responded {
+			delete(w.bySender, oldest.sender.String())
+		}
+	}
+
+	w.entries = append(w.entries, entry)
+	w.byID[entry.requestID] = entry
+	w.bySender[entry.sender.String()] = entry
+}
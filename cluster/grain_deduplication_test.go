@@ -0,0 +1,71 @@
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestDeduplicationReplaysResponses(t *testing.T) {
+	system := actor.NewActorSystem()
+	var runs int32
+	kind := (&Kind{Props: actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(*wrapperspb.StringValue); ok {
+			runs++
+			ctx.Respond(wrapperspb.Int32(runs))
+		}
+	})}).WithDeduplication(2)
+	pid := system.Root.Spawn(kind.Props)
+
+	request := func(requestID string) int32 {
+		res, err := requestFuture(system.Root, pid, wrapperspb.String("run"), time.Second, requestID).Result()
+		require.NoError(t, err)
+		return res.(*wrapperspb.Int32Value).Value
+	}
+
+	assert.Equal(t, int32(1), request("a"))
+	assert.Equal(t, int32(1), request("a"))
+	assert.Equal(t, int32(2), request("b"))
+	assert.Equal(t, int32(3), request(""))
+	assert.Equal(t, int32(1), request("a"))
+
+	// c evicts a from the window of two requests
+	assert.Equal(t, int32(4), request("c"))
+	assert.Equal(t, int32(2), request("b"))
+	assert.Equal(t, int32(5), request("a"))
+}
+
+func TestDeduplicationWaitsForRunningRequest(t *testing.T) {
+	system := actor.NewActorSystem()
+	release := make(chan struct{})
+	var runs int32
+	kind := (&Kind{Props: actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(*wrapperspb.StringValue); ok {
+			runs++
+			// the response is sent once released, the duplicate arrives while the request is running
+			f := actor.NewFuture(ctx.ActorSystem(), 5*time.Second)
+			go func(n int32) {
+				<-release
+				system.Root.Send(f.PID(), wrapperspb.Int32(n))
+			}(runs)
+			ctx.ReenterAfter(f, func(res interface{}, _ error) {
+				ctx.Respond(res)
+			})
+		}
+	})}).WithDeduplication(10)
+	pid := system.Root.Spawn(kind.Props)
+
+	first := requestFuture(system.Root, pid, wrapperspb.String("run"), 5*time.Second, "a")
+	second := requestFuture(system.Root, pid, wrapperspb.String("run"), 5*time.Second, "a")
+	close(release)
+
+	for _, f := range []*actor.Future{first, second} {
+		res, err := f.Result()
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), res.(*wrapperspb.Int32Value).Value)
+	}
+}
@@ -0,0 +1,119 @@
+package cluster
+
+import (
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/ctxext"
+	"google.golang.org/protobuf/proto"
+)
+
+// GrainHandoff is sent to a grain before it is moved to another member.
+// The grain can call SetState to carry its state to the new activation, where it is
+// received in ClusterInit.HandoffState. The grain is stopped once it handled the message, the messages
+// already in its mailbox are forwarded to the new activation.
+type GrainHandoff struct {
+	state proto.Message
+}
+
+// SetState sets the state passed to the new activation of the grain
+func (h *GrainHandoff) SetState(state proto.Message) {
+	h.state = state
+}
+
+// State returns the state set by the grain
+func (h *GrainHandoff) State() proto.Message {
+	return h.state
+}
+
+var handoffStateExtensionId = ctxext.NextContextExtensionID()
+
+type handoffState struct {
+	state proto.Message
+}
+
+func (h *handoffState) ExtensionID() ctxext.ContextExtensionID {
+	return handoffStateExtensionId
+}
+
+// WithHandoffState passes the state handed over by the previous activation to the grain spawned with the props
+func WithHandoffState(props *actor.Props, state proto.Message) *actor.Props {
+	if state == nil {
+		return props
+	}
+
+	return props.Clone(
+		actor.WithOnInit(func(ctx actor.Context) {
+			ctx.Set(&handoffState{state: state})
+		}))
+}
+
+func getHandoffState(ctx actor.ExtensionContext) proto.Message {
+	if h, ok := ctx.Get(handoffStateExtensionId).(*handoffState); ok {
+		return h.state
+	}
+	return nil
+}
+
+var handedOffExtensionId = ctxext.NextContextExtensionID()
+
+// handedOff marks a grain which handed off its state, it forwards the messages left in its mailbox to its new
+// activation until it stops
+type handedOff struct {
+	// target is the new activation, resolved by the first forwarded message
+	target *actor.PID
+}
+
+func (h *handedOff) ExtensionID() ctxext.ContextExtensionID {
+	return handedOffExtensionId
+}
+
+func isHandedOff(ctx actor.ExtensionContext) bool {
+	_, ok := ctx.Get(handedOffExtensionId).(*handedOff)
+	return ok
+}
+
+// handleGrainHandoff lets the grain set its handoff state, replies it to the requester and poisons the grain.
+// The messages before the poison pill are forwarded to the new activation instead of being handled, see
+// forwardHandedOff.
+func handleGrainHandoff(c actor.ReceiverContext, next actor.ReceiverFunc, envelope *actor.MessageEnvelope) {
+	next(c, envelope)
+
+	if envelope.Sender != nil {
+		c.ActorSystem().Root.Send(envelope.Sender, envelope.Message)
+	}
+	c.Set(&handedOff{})
+	c.ActorSystem().Root.Poison(c.Self())
+}
+
+// forwardHandedOff forwards a message received after the handoff to the new activation of the grain.
+// The new activation is looked up once, the lookup waits until the grain is activated again.
+func forwardHandedOff(c actor.ReceiverContext, envelope *actor.MessageEnvelope) {
+	h := c.Get(handedOffExtensionId).(*handedOff)
+	if h.target == nil {
+		h.target = resolveHandedOff(c)
+	}
+	if h.target == nil {
+		c.ActorSystem().DeadLetter.SendUserMessage(c.Self(), envelope)
+		return
+	}
+
+	c.ActorSystem().Root.Send(h.target, envelope)
+}
+
+// resolveHandedOff looks up the new activation of the handed off grain, the cached pid of the grain is removed
+// first so that it is not returned
+func resolveHandedOff(c actor.ReceiverContext) *actor.PID {
+	identity := GetClusterIdentity(c)
+	if identity == nil {
+		return nil
+	}
+
+	cluster := GetCluster(c.ActorSystem())
+	cluster.PidCache.RemoveByValue(identity.Identity, identity.Kind, c.Self())
+
+	pid := cluster.Get(identity.Identity, identity.Kind)
+	if pid == nil || pid.Equal(c.Self()) {
+		return nil
+	}
+
+	return pid
+}
@@ -1,6 +1,9 @@
 package cluster
 
 import (
+	"errors"
+	"strings"
+
 	"github.com/asynkron/protoactor-go/actor"
 )
 
@@ -25,13 +28,22 @@ type StorageLookup interface {
 
 	RemoveLock(spawnLock SpawnLock)
 
-	StoreActivation(memberID string, spawnLock *SpawnLock, pid *actor.PID)
+	// StoreActivation stores the activation spawned with the spawn lock, it fails with ErrSpawnLockLost when the
+	// lock expired or an activation is already stored for the identity
+	StoreActivation(memberID string, spawnLock *SpawnLock, pid *actor.PID) error
 
 	RemoveActivation(pid *SpawnLock)
 
+	// RemovePid removes the activation stored for the identity when it is the given pid
+	RemovePid(clusterIdentity *ClusterIdentity, pid *actor.PID)
+
 	RemoveMemberId(memberID string)
 }
 
+// ErrSpawnLockLost is returned by StorageLookup.StoreActivation when the spawn lock is no longer held, another
+// member may have activated the identity meanwhile
+var ErrSpawnLockLost = errors.New("spawn lock lost before the activation was stored")
+
 // SpawnLock contains
 type SpawnLock struct {
 	LockID          string
@@ -62,6 +74,21 @@ func newStoredActivation(pid string, memberID string) *StoredActivation {
 	return this
 }
 
+// NewStoredActivation creates a StoredActivation for a PID hosted by the given member
+func NewStoredActivation(pid *actor.PID, memberID string) *StoredActivation {
+	return newStoredActivation(pid.Address+"/"+pid.Id, memberID)
+}
+
+// PID returns the PID of the stored activation
+func (sa *StoredActivation) PID() *actor.PID {
+	parts := strings.SplitN(sa.Pid, "/", 2)
+	if len(parts) != 2 {
+		return nil
+	}
+
+	return actor.NewPID(parts[0], parts[1])
+}
+
 // GetPid contains
 type GetPid struct {
 	ClusterIdentity *ClusterIdentity
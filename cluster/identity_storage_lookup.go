@@ -1,14 +1,17 @@
 package cluster
 
 import (
-	"time"
+	"log/slog"
 
 	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/eventstream"
+	"github.com/asynkron/protoactor-go/router"
 )
 
 const (
 	placementActorName           = "placement-activator"
 	pidClusterIdentityStartIndex = len(placementActorName) + 1
+	identityStorageWorkerCount   = 50
 )
 
 // IdentityStorageLookup contains
@@ -20,6 +23,7 @@ type IdentityStorageLookup struct {
 	system         *actor.ActorSystem
 	router         *actor.PID
 	memberID       string
+	topologySub    *eventstream.Subscription
 }
 
 func newIdentityStorageLookup(storage StorageLookup) *IdentityStorageLookup {
@@ -29,6 +33,12 @@ func newIdentityStorageLookup(storage StorageLookup) *IdentityStorageLookup {
 	return this
 }
 
+// NewIdentityStorageLookup creates an IdentityLookup which keeps the activations in the given storage.
+// The storage guarantees a single activation per identity across the cluster through its spawn locks.
+func NewIdentityStorageLookup(storage StorageLookup) *IdentityStorageLookup {
+	return newIdentityStorageLookup(storage)
+}
+
 // RemoveMember from identity storage
 func (i *IdentityStorageLookup) RemoveMember(memberID string) {
 	i.Storage.RemoveMemberId(memberID)
@@ -46,20 +56,66 @@ func RemotePlacementActor(address string) *actor.PID {
 // Get returns a PID for a given ClusterIdentity
 func (id *IdentityStorageLookup) Get(clusterIdentity *ClusterIdentity) *actor.PID {
 	msg := newGetPid(clusterIdentity)
-	timeout := 5 * time.Second
 
-	res, _ := id.system.Root.RequestFuture(id.router, msg, timeout).Result()
-	response := res.(*actor.Future)
+	res, err := id.system.Root.RequestFuture(id.router, msg, id.cluster.Config.TimeoutTime).Result()
+	if err != nil {
+		id.cluster.Logger().Error("Failed to get PID from identity storage", slog.String("identity", clusterIdentity.ToShortString()), slog.Any("error", err))
+		return nil
+	}
+
+	response, ok := res.(*PidResult)
+	if !ok {
+		return nil
+	}
+
+	return response.Pid
+}
 
-	return response.PID()
+// RemovePid removes the activation of the identity from the storage when it is the given pid, a request to the
+// pid was dead-lettered. Activations are otherwise removed by the member hosting them when they terminate,
+// or by RemoveMember when the hosting member leaves the cluster.
+func (id *IdentityStorageLookup) RemovePid(clusterIdentity *ClusterIdentity, pid *actor.PID) {
+	id.Storage.RemovePid(clusterIdentity, pid)
 }
 
 func (id *IdentityStorageLookup) Setup(cluster *Cluster, kinds []string, isClient bool) {
 	id.cluster = cluster
 	id.system = cluster.ActorSystem
 	id.memberID = cluster.ActorSystem.ID
+	id.isClient = isClient
+
+	workerProps := router.NewRoundRobinPool(identityStorageWorkerCount, actor.WithProducer(func() actor.Actor {
+		return newIdentityStorageWorker(id)
+	}))
+	id.router = id.system.Root.Spawn(workerProps)
 
-	// workerProps := actor.PropsFromProducer(func() actor.Actor { return newIdentityStorageWorker(identity) })
+	if !isClient {
+		placementProps := actor.PropsFromProducer(func() actor.Actor { return newIdentityStoragePlacementActor(id) })
+		id.placementActor, _ = id.system.Root.SpawnNamed(placementProps, placementActorName)
+	}
+
+	id.topologySub = id.system.EventStream.Subscribe(func(evt interface{}) {
+		topology, ok := evt.(*ClusterTopology)
+		if !ok {
+			return
+		}
+
+		for _, m := range topology.Left {
+			id.RemoveMember(m.Id)
+		}
+	})
+}
 
-	// routerProps := identity.system.Root.(workerProps, 50);
+func (id *IdentityStorageLookup) Shutdown() {
+	id.system.EventStream.Unsubscribe(id.topologySub)
+
+	if id.placementActor != nil {
+		if err := id.system.Root.PoisonFuture(id.placementActor).Wait(); err != nil {
+			id.cluster.Logger().Error("Failed to shutdown placement actor", slog.Any("error", err))
+		}
+	}
+
+	if err := id.system.Root.PoisonFuture(id.router).Wait(); err != nil {
+		id.cluster.Logger().Error("Failed to shutdown identity storage workers", slog.Any("error", err))
+	}
 }
@@ -0,0 +1,95 @@
+package cluster
+
+import (
+	"log/slog"
+
+	"github.com/asynkron/protoactor-go/actor"
+)
+
+type storedActivationLock struct {
+	pid       *actor.PID
+	spawnLock *SpawnLock
+}
+
+// identityStoragePlacementActor spawns the activations placed on this member and
+// records them in the identity storage
+type identityStoragePlacementActor struct {
+	lookup      *IdentityStorageLookup
+	activations map[string]storedActivationLock
+}
+
+func newIdentityStoragePlacementActor(lookup *IdentityStorageLookup) *identityStoragePlacementActor {
+	return &identityStoragePlacementActor{
+		lookup:      lookup,
+		activations: map[string]storedActivationLock{},
+	}
+}
+
+func (p *identityStoragePlacementActor) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *actor.Stopping:
+		p.onStopping(ctx)
+	case *actor.Terminated:
+		p.onTerminated(msg)
+	case *ActivationRequest:
+		p.onActivationRequest(msg, ctx)
+	}
+}
+
+func (p *identityStoragePlacementActor) onActivationRequest(msg *ActivationRequest, ctx actor.Context) {
+	key := msg.ClusterIdentity.AsKey()
+	if activation, found := p.activations[key]; found {
+		ctx.Respond(&ActivationResponse{Pid: activation.pid})
+		return
+	}
+
+	clusterKind := p.lookup.cluster.GetClusterKind(msg.ClusterIdentity.Kind)
+	if clusterKind == nil {
+		ctx.Logger().Error("Unknown cluster kind", slog.String("kind", msg.ClusterIdentity.Kind))
+		ctx.Respond(&ActivationResponse{Failed: true})
+		return
+	}
+
+	props := WithClusterIdentity(clusterKind.Props, msg.ClusterIdentity)
+	pid := ctx.SpawnPrefix(props, msg.ClusterIdentity.Identity)
+
+	spawnLock := newSpawnLock(msg.RequestId, msg.ClusterIdentity)
+	if err := p.lookup.Storage.StoreActivation(p.lookup.memberID, spawnLock, pid); err != nil {
+		// another activation of the identity may be stored, this one must not be used
+		ctx.Logger().Warn("Failed to store activation, stopping it", slog.String("identity", key), slog.Any("error", err))
+		ctx.Stop(pid)
+		ctx.Respond(&ActivationResponse{Failed: true})
+		return
+	}
+	p.activations[key] = storedActivationLock{pid: pid, spawnLock: spawnLock}
+
+	ctx.Respond(&ActivationResponse{Pid: pid})
+}
+
+func (p *identityStoragePlacementActor) onTerminated(msg *actor.Terminated) {
+	for key, activation := range p.activations {
+		if !activation.pid.Equal(msg.Who) {
+			continue
+		}
+
+		delete(p.activations, key)
+		p.lookup.Storage.RemoveActivation(activation.spawnLock)
+
+		return
+	}
+}
+
+func (p *identityStoragePlacementActor) onStopping(ctx actor.Context) {
+	futures := make(map[string]*actor.Future, len(p.activations))
+
+	for key, activation := range p.activations {
+		futures[key] = ctx.PoisonFuture(activation.pid)
+	}
+
+	for key, future := range futures {
+		if err := future.Wait(); err != nil {
+			ctx.Logger().Error("Failed to poison actor", slog.String("identity", key), slog.Any("error", err))
+		}
+		p.lookup.Storage.RemoveActivation(p.activations[key].spawnLock)
+	}
+}
@@ -0,0 +1,47 @@
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lostLockStorage fails to store every activation, as if the spawn locks expired
+type lostLockStorage struct {
+	StorageLookup
+}
+
+func (s *lostLockStorage) StoreActivation(string, *SpawnLock, *actor.PID) error {
+	return ErrSpawnLockLost
+}
+
+func TestIdentityStoragePlacementActor_StopsActivationWhenLockIsLost(t *testing.T) {
+	stopped := make(chan struct{}, 1)
+	c := newClusterForTest("test-placement", nil, WithKinds(NewKind("kind", actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(*actor.Stopped); ok {
+			stopped <- struct{}{}
+		}
+	}))))
+	c.initKinds()
+
+	lookup := &IdentityStorageLookup{Storage: &lostLockStorage{}, cluster: c, memberID: "member-1"}
+	placement := c.ActorSystem.Root.Spawn(actor.PropsFromProducer(func() actor.Actor {
+		return newIdentityStoragePlacementActor(lookup)
+	}))
+
+	res, err := c.ActorSystem.Root.RequestFuture(placement, &ActivationRequest{
+		ClusterIdentity: NewClusterIdentity("a", "kind"),
+		RequestId:       "lock-1",
+	}, time.Second).Result()
+	require.NoError(t, err)
+	assert.True(t, res.(*ActivationResponse).Failed)
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("the activation was not stopped")
+	}
+}
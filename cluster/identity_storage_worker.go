@@ -1,7 +1,7 @@
 package cluster
 
 import (
-	"log"
+	"log/slog"
 
 	"github.com/asynkron/protoactor-go/actor"
 )
@@ -24,24 +24,69 @@ func newIdentityStorageWorker(storageLookup *IdentityStorageLookup) *IdentitySto
 // Receive func
 func (ids *IdentityStorageWorker) Receive(c actor.Context) {
 	m := c.Message()
-	getPid, ok := m.(GetPid)
+	getPid, ok := m.(*GetPid)
 
 	if !ok {
 		return
 	}
 
 	if c.Sender() == nil {
-		log.Println("No sender in GetPid request")
+		ids.cluster.Logger().Error("No sender in GetPid request")
 		return
 	}
 
-	existing, _ := ids.cluster.PidCache.Get(getPid.ClusterIdentity.Identity, getPid.ClusterIdentity.Kind)
+	c.Respond(newPidResult(ids.getPid(getPid.ClusterIdentity)))
+}
+
+func (ids *IdentityStorageWorker) getPid(clusterIdentity *ClusterIdentity) *actor.PID {
+	if pid := ids.livePid(ids.storage.TryGetExistingActivation(clusterIdentity)); pid != nil {
+		return pid
+	}
+
+	spawnLock := ids.storage.TryAcquireLock(clusterIdentity)
+	if spawnLock == nil {
+		// another member is spawning the activation
+		return ids.livePid(ids.storage.WaitForActivation(clusterIdentity))
+	}
+
+	return ids.spawnActivation(spawnLock)
+}
+
+// livePid returns the PID of the activation if the member hosting it is still part of the cluster
+func (ids *IdentityStorageWorker) livePid(activation *StoredActivation) *actor.PID {
+	if activation == nil || !ids.cluster.MemberList.ContainsMemberID(activation.MemberID) {
+		return nil
+	}
+
+	return activation.PID()
+}
+
+func (ids *IdentityStorageWorker) spawnActivation(spawnLock *SpawnLock) *actor.PID {
+	clusterIdentity := spawnLock.ClusterIdentity
+
+	activatorAddress := ids.cluster.MemberList.GetActivatorMember(clusterIdentity.Kind, ids.cluster.ActorSystem.Address())
+	if activatorAddress == "" {
+		ids.cluster.Logger().Warn("No activator available", slog.String("kind", clusterIdentity.Kind))
+		ids.storage.RemoveLock(*spawnLock)
+		return nil
+	}
+
+	request := &ActivationRequest{
+		ClusterIdentity: clusterIdentity,
+		RequestId:       spawnLock.LockID,
+	}
+	res, err := ids.cluster.ActorSystem.Root.RequestFuture(RemotePlacementActor(activatorAddress), request, ids.cluster.Config.TimeoutTime).Result()
+	if err != nil {
+		ids.cluster.Logger().Error("Failed to spawn activation", slog.String("identity", clusterIdentity.ToShortString()), slog.String("activator", activatorAddress), slog.Any("error", err))
+		ids.storage.RemoveLock(*spawnLock)
+		return nil
+	}
 
-	if existing != nil {
-		log.Printf("Found %s in pidcache", m.(GetPid).ClusterIdentity.ToShortString())
-		c.Respond(newPidResult(existing))
+	response, ok := res.(*ActivationResponse)
+	if !ok || response.Failed {
+		ids.storage.RemoveLock(*spawnLock)
+		return nil
 	}
 
-	return
-	// continue
+	return response.Pid
 }
@@ -0,0 +1,399 @@
+package partition
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	clustering "github.com/asynkron/protoactor-go/cluster"
+)
+
+// rebalanceCompleted is sent to the identity actor once the handovers for a topology have been collected
+type rebalanceCompleted struct {
+	topologyHash uint64
+	activations  []*clustering.Activation
+}
+
+type pendingRequest struct {
+	request *clustering.ActivationRequest
+	sender  *actor.PID
+}
+
+// identityActor owns the activation table of the identities this member is the owner of
+type identityActor struct {
+	cluster          *clustering.Cluster
+	partitionManager *Manager
+	lookup           map[string]*actor.PID
+	spawns           map[string][]*actor.PID
+	rdv              *clustering.Rendezvous
+	topologyHash     uint64
+	// hasTopology is true once a topology was received, its handovers are collected or being collected
+	hasTopology bool
+	rebalancing bool
+	waiting     []pendingRequest
+	// migrating buffers the requests for identities whose activation is being moved
+	migrating      map[string][]pendingRequest
+	migrationQueue []string
+}
+
+func newIdentityActor(c *clustering.Cluster, pm *Manager) *identityActor {
+	return &identityActor{
+		cluster:          c,
+		partitionManager: pm,
+		lookup:           map[string]*actor.PID{},
+		spawns:           map[string][]*actor.PID{},
+		migrating:        map[string][]pendingRequest{},
+		rdv:              clustering.NewRendezvous(),
+		// requests are only served once the first topology has been received
+		rebalancing: true,
+	}
+}
+
+func (a *identityActor) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *actor.Started:
+		ctx.Logger().Info("Identity actor started")
+	case *actor.Stopped:
+		ctx.Logger().Info("Identity actor stopped")
+	case *clustering.ClusterTopology:
+		a.onClusterTopology(msg, ctx)
+	case *rebalanceCompleted:
+		a.onRebalanceCompleted(msg, ctx)
+	case *clustering.ActivationRequest:
+		a.onActivationRequest(msg, ctx.Sender(), ctx)
+	case *clustering.ActivationTerminated:
+		a.onActivationTerminated(msg)
+	}
+}
+
+func (a *identityActor) onClusterTopology(msg *clustering.ClusterTopology, ctx actor.Context) {
+	// the same topology is received again while its handovers are collected, or once they are
+	if a.hasTopology && msg.TopologyHash == a.topologyHash {
+		return
+	}
+
+	a.hasTopology = true
+	a.topologyHash = msg.TopologyHash
+	a.migrationQueue = nil
+	a.rdv = clustering.NewRendezvous()
+	a.rdv.UpdateMembers(msg.Members)
+	a.rebalancing = true
+
+	members := make(map[string]struct{}, len(msg.Members))
+	for _, m := range msg.Members {
+		members[m.Address()] = struct{}{}
+	}
+
+	myAddress := a.cluster.ActorSystem.Address()
+	for key, pid := range a.lookup {
+		// activations of members which left are gone, moved identities are handed over by their hosts
+		if _, ok := members[pid.Address]; !ok {
+			delete(a.lookup, key)
+			continue
+		}
+		if a.ownerOf(key) != myAddress {
+			delete(a.lookup, key)
+		}
+	}
+
+	ctx.Logger().Info("Identity actor rebalancing", slog.Uint64("topology-hash", msg.TopologyHash), slog.Int("owned", len(a.lookup)))
+
+	go a.collectHandovers(ctx.Self(), msg)
+}
+
+// collectHandovers waits for the members to agree on the topology and then asks every member
+// for the activations this member now owns
+func (a *identityActor) collectHandovers(self *actor.PID, topology *clustering.ClusterTopology) {
+	timeout := a.partitionManager.config.HandoverTimeout
+	deadline := time.Now().Add(timeout)
+	system := a.cluster.ActorSystem
+
+	for {
+		hash, ok := a.cluster.MemberList.TopologyConsensus(context.Background())
+		if ok && hash == topology.TopologyHash {
+			break
+		}
+		if time.Now().After(deadline) {
+			system.Logger().Warn("No topology consensus before handover", slog.Uint64("topology-hash", topology.TopologyHash))
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	request := &clustering.IdentityHandoverRequest{
+		CurrentTopology: &clustering.IdentityHandoverRequest_Topology{
+			TopologyHash: topology.TopologyHash,
+			Members:      topology.Members,
+		},
+		Address: system.Address(),
+	}
+
+	var (
+		mu          sync.Mutex
+		wg          sync.WaitGroup
+		activations = make([]*clustering.Activation, 0)
+	)
+
+	for _, m := range topology.Members {
+		wg.Add(1)
+		go func(address string) {
+			defer wg.Done()
+
+			res, err := system.Root.RequestFuture(a.partitionManager.PidOfPlacementActor(address), request, timeout).Result()
+			if err != nil {
+				system.Logger().Warn("Failed to get identity handover", slog.String("address", address), slog.Any("error", err))
+				return
+			}
+			handover, ok := res.(*clustering.IdentityHandover)
+			if !ok {
+				return
+			}
+
+			mu.Lock()
+			activations = append(activations, handover.Actors...)
+			mu.Unlock()
+		}(m.Address())
+	}
+	wg.Wait()
+
+	system.Root.Send(self, &rebalanceCompleted{
+		topologyHash: topology.TopologyHash,
+		activations:  activations,
+	})
+}
+
+func (a *identityActor) onRebalanceCompleted(msg *rebalanceCompleted, ctx actor.Context) {
+	if msg.topologyHash != a.topologyHash {
+		// a newer topology arrived in the meantime, its own handover is in progress
+		return
+	}
+
+	// the identities owned before the topology changed are kept in the lookup, the ones handed over are new to
+	// this member
+	handedOver := make([]string, 0)
+	myAddress := a.cluster.ActorSystem.Address()
+	for _, activation := range msg.activations {
+		key := activation.ClusterIdentity.AsKey()
+		if a.ownerOf(key) != myAddress {
+			continue
+		}
+
+		existing, found := a.lookup[key]
+		if !found {
+			a.lookup[key] = activation.Pid
+			handedOver = append(handedOver, key)
+			continue
+		}
+		if existing.Equal(activation.Pid) {
+			continue
+		}
+
+		ctx.Logger().Warn("Stopping duplicate activation", slog.String("identity", key), slog.Any("pid", activation.Pid), slog.Any("kept", existing))
+		ctx.Poison(activation.Pid)
+	}
+
+	a.rebalancing = false
+	ctx.Logger().Info("Identity actor rebalanced", slog.Uint64("topology-hash", a.topologyHash), slog.Int("owned", len(a.lookup)))
+
+	waiting := a.waiting
+	a.waiting = nil
+	for _, w := range waiting {
+		a.onActivationRequest(w.request, w.sender, ctx)
+	}
+
+	a.scheduleMigrations(handedOver, ctx)
+}
+
+func (a *identityActor) onActivationRequest(msg *clustering.ActivationRequest, sender *actor.PID, ctx actor.Context) {
+	if msg.TopologyHash != 0 && msg.TopologyHash != a.topologyHash {
+		ctx.Send(sender, &clustering.ActivationResponse{Failed: true, TopologyHash: a.topologyHash})
+		return
+	}
+
+	if a.rebalancing {
+		a.waiting = append(a.waiting, pendingRequest{request: msg, sender: sender})
+		return
+	}
+
+	key := msg.ClusterIdentity.AsKey()
+	if pending, found := a.migrating[key]; found {
+		a.migrating[key] = append(pending, pendingRequest{request: msg, sender: sender})
+		return
+	}
+
+	if pid, found := a.lookup[key]; found {
+		ctx.Send(sender, &clustering.ActivationResponse{Pid: pid, TopologyHash: a.topologyHash})
+		return
+	}
+
+	if a.ownerOf(key) != a.cluster.ActorSystem.Address() {
+		ctx.Send(sender, &clustering.ActivationResponse{Failed: true, TopologyHash: a.topologyHash})
+		return
+	}
+
+	// an activation is already being spawned, answer all requesters once it is done
+	if senders, found := a.spawns[key]; found {
+		a.spawns[key] = append(senders, sender)
+		return
+	}
+
+	activatorAddress := a.cluster.MemberList.GetActivatorMember(msg.ClusterIdentity.Kind, sender.GetAddress())
+	if activatorAddress == "" {
+		ctx.Send(sender, &clustering.ActivationResponse{Failed: true, TopologyHash: a.topologyHash})
+		return
+	}
+
+	a.spawns[key] = []*actor.PID{sender}
+	request := &clustering.ActivationRequest{
+		ClusterIdentity: msg.ClusterIdentity,
+		TopologyHash:    a.topologyHash,
+	}
+	future := ctx.RequestFuture(a.partitionManager.PidOfPlacementActor(activatorAddress), request, a.cluster.Config.TimeoutTime)
+	ctx.ReenterAfter(future, func(res interface{}, err error) {
+		a.onActivationSpawned(key, res, err, ctx)
+	})
+}
+
+func (a *identityActor) onActivationSpawned(key string, res interface{}, err error, ctx actor.Context) {
+	senders := a.spawns[key]
+	delete(a.spawns, key)
+
+	response := &clustering.ActivationResponse{Failed: true, TopologyHash: a.topologyHash}
+	if typed, ok := res.(*clustering.ActivationResponse); ok && err == nil && !typed.Failed && typed.Pid != nil {
+		response = &clustering.ActivationResponse{Pid: typed.Pid, TopologyHash: a.topologyHash}
+
+		// the activation is also reported by its host during a handover, no need to keep it if the identity moved
+		if a.ownerOf(key) == a.cluster.ActorSystem.Address() {
+			if _, found := a.lookup[key]; !found {
+				a.lookup[key] = typed.Pid
+			}
+			response.Pid = a.lookup[key]
+		}
+	} else if err != nil {
+		ctx.Logger().Error("Failed to spawn activation", slog.String("identity", key), slog.Any("error", err))
+	}
+
+	for _, sender := range senders {
+		ctx.Send(sender, response)
+	}
+}
+
+func (a *identityActor) onActivationTerminated(msg *clustering.ActivationTerminated) {
+	if msg.ClusterIdentity == nil {
+		return
+	}
+
+	key := msg.ClusterIdentity.AsKey()
+	if pid, found := a.lookup[key]; found && pid.Equal(msg.Pid) {
+		delete(a.lookup, key)
+	}
+}
+
+// scheduleMigrations queues the selected activations handed over to this member which it does not host.
+// Only the identities whose owner changed are moved, the activations of the identities which kept their owner
+// stay on the member the member strategy placed them on.
+func (a *identityActor) scheduleMigrations(handedOver []string, ctx actor.Context) {
+	if a.partitionManager.config.RebalanceSelector == nil {
+		return
+	}
+
+	myAddress := a.cluster.ActorSystem.Address()
+	a.migrationQueue = a.migrationQueue[:0]
+	for _, key := range handedOver {
+		pid, found := a.lookup[key]
+		if !found || pid.Address == myAddress {
+			continue
+		}
+		if _, found := a.migrating[key]; found {
+			continue
+		}
+		if a.partitionManager.config.RebalanceSelector(identityFromKey(key)) {
+			a.migrationQueue = append(a.migrationQueue, key)
+		}
+	}
+
+	if len(a.migrationQueue) > 0 {
+		ctx.Logger().Info("Moving activations to their owner", slog.Int("activations", len(a.migrationQueue)))
+	}
+
+	a.startMigrations(ctx)
+}
+
+func (a *identityActor) startMigrations(ctx actor.Context) {
+	for len(a.migrating) < a.partitionManager.config.MaxConcurrentMigrations && len(a.migrationQueue) > 0 {
+		key := a.migrationQueue[0]
+		a.migrationQueue = a.migrationQueue[1:]
+
+		pid, found := a.lookup[key]
+		if !found || pid.Address == a.cluster.ActorSystem.Address() {
+			continue
+		}
+
+		a.migrate(key, pid, ctx)
+	}
+}
+
+// migrate deactivates the activation on its current member and activates it again on this member,
+// requests for the identity are buffered until the new activation is known
+func (a *identityActor) migrate(key string, pid *actor.PID, ctx actor.Context) {
+	a.migrating[key] = make([]pendingRequest, 0)
+	clusterIdentity := identityFromKey(key)
+
+	request := &clustering.ActivationHandoffRequest{
+		ClusterIdentity: clusterIdentity,
+		Pid:             pid,
+	}
+	future := ctx.RequestFuture(a.partitionManager.PidOfPlacementActor(pid.Address), request, a.cluster.Config.TimeoutTime)
+	ctx.ReenterAfter(future, func(res interface{}, err error) {
+		response, ok := res.(*clustering.ActivationHandoffResponse)
+		if err != nil || !ok || response.Failed {
+			ctx.Logger().Warn("Failed to hand off activation", slog.String("identity", key), slog.Any("pid", pid), slog.Any("error", err))
+			a.completeMigration(key, ctx)
+			return
+		}
+
+		if current, found := a.lookup[key]; found && current.Equal(pid) {
+			delete(a.lookup, key)
+		}
+
+		activation := &clustering.ActivationRequest{
+			ClusterIdentity: clusterIdentity,
+			TopologyHash:    a.topologyHash,
+			HandoffState:    response.State,
+		}
+		future := ctx.RequestFuture(a.partitionManager.placementActor, activation, a.cluster.Config.TimeoutTime)
+		ctx.ReenterAfter(future, func(res interface{}, err error) {
+			if response, ok := res.(*clustering.ActivationResponse); ok && err == nil && !response.Failed && response.Pid != nil {
+				if _, found := a.lookup[key]; !found && a.ownerOf(key) == a.cluster.ActorSystem.Address() {
+					a.lookup[key] = response.Pid
+				}
+			} else {
+				ctx.Logger().Error("Failed to activate moved grain", slog.String("identity", key), slog.Any("error", err))
+			}
+			a.completeMigration(key, ctx)
+		})
+	})
+}
+
+func (a *identityActor) completeMigration(key string, ctx actor.Context) {
+	pending := a.migrating[key]
+	delete(a.migrating, key)
+
+	for _, p := range pending {
+		a.onActivationRequest(p.request, p.sender, ctx)
+	}
+
+	a.startMigrations(ctx)
+}
+
+func identityFromKey(key string) *clustering.ClusterIdentity {
+	parts := strings.SplitN(key, "/", 2)
+	return clustering.NewClusterIdentity(parts[1], parts[0])
+}
+
+func (a *identityActor) ownerOf(key string) string {
+	return a.rdv.GetByIdentity(key)
+}
@@ -0,0 +1,23 @@
+package partition
+
+import (
+	"testing"
+
+	clustering "github.com/asynkron/protoactor-go/cluster"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdentityActor_IgnoresTopologyBeingRebalanced(t *testing.T) {
+	a := &identityActor{
+		hasTopology:    true,
+		topologyHash:   42,
+		rebalancing:    true,
+		migrationQueue: []string{"identity"},
+	}
+
+	// no second collection of the handovers is started, the rebalance state is kept
+	a.onClusterTopology(&clustering.ClusterTopology{TopologyHash: 42}, nil)
+
+	assert.True(t, a.rebalancing)
+	assert.Equal(t, []string{"identity"}, a.migrationQueue)
+}
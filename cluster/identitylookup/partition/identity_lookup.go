@@ -0,0 +1,93 @@
+package partition
+
+import (
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/cluster"
+)
+
+// Config configures the partition identity lookup
+type Config struct {
+	// HandoverTimeout bounds the time a new owner waits for topology consensus and
+	// for the activation tables of the other members before it serves requests again
+	HandoverTimeout time.Duration
+	// RebalanceSelector selects the activations moved to their new owner when a topology change assigns their
+	// identity to another member, rebalancing is disabled when it is nil
+	RebalanceSelector func(clusterIdentity *cluster.ClusterIdentity) bool
+	// MaxConcurrentMigrations limits the number of activations an owner moves at the same time
+	MaxConcurrentMigrations int
+}
+
+type ConfigOption func(config *Config)
+
+// RebalanceAll selects every activation for rebalancing
+func RebalanceAll(*cluster.ClusterIdentity) bool {
+	return true
+}
+
+// WithRebalancing moves the activations selected by selector to the new owner of their identity whenever a
+// topology change assigns the identity to another member, such as a joining member. The activations of the
+// identities which keep their owner stay on the member the member strategy placed them on. Grains receive a cluster.GrainHandoff before they are stopped and
+// can pass their state to the new activation through it.
+func WithRebalancing(selector func(clusterIdentity *cluster.ClusterIdentity) bool) ConfigOption {
+	return func(config *Config) {
+		config.RebalanceSelector = selector
+	}
+}
+
+// WithMaxConcurrentMigrations sets the number of activations an owner moves at the same time
+func WithMaxConcurrentMigrations(max int) ConfigOption {
+	return func(config *Config) {
+		config.MaxConcurrentMigrations = max
+	}
+}
+
+// WithHandoverTimeout sets the maximum duration of a handover after a topology change
+func WithHandoverTimeout(timeout time.Duration) ConfigOption {
+	return func(config *Config) {
+		config.HandoverTimeout = timeout
+	}
+}
+
+// IdentityLookup assigns every cluster identity to an owner member using rendezvous hashing.
+// The owner keeps the activation table of its identities and makes sure there is at most one
+// activation per identity. When the topology changes, the new owners collect the activations
+// they are now responsible for from all members before serving requests again.
+type IdentityLookup struct {
+	config           *Config
+	partitionManager *Manager
+}
+
+func (p *IdentityLookup) Get(clusterIdentity *cluster.ClusterIdentity) *actor.PID {
+	return p.partitionManager.Get(clusterIdentity)
+}
+
+func (p *IdentityLookup) RemovePid(clusterIdentity *cluster.ClusterIdentity, pid *actor.PID) {
+	activationTerminated := &cluster.ActivationTerminated{
+		Pid:             pid,
+		ClusterIdentity: clusterIdentity,
+	}
+	p.partitionManager.cluster.MemberList.BroadcastEvent(activationTerminated, true)
+}
+
+func (p *IdentityLookup) Setup(cluster *cluster.Cluster, kinds []string, isClient bool) {
+	p.partitionManager = newPartitionManager(cluster, p.config)
+	p.partitionManager.Start(isClient)
+}
+
+func (p *IdentityLookup) Shutdown() {
+	p.partitionManager.Stop()
+}
+
+func New(opts ...ConfigOption) cluster.IdentityLookup {
+	config := &Config{
+		HandoverTimeout:         10 * time.Second,
+		MaxConcurrentMigrations: 8,
+	}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	return &IdentityLookup{config: config}
+}
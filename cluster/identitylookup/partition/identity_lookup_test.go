@@ -0,0 +1,262 @@
+package partition
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/cluster"
+	"github.com/asynkron/protoactor-go/cluster/cluster_test_tool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const testKind = "partition-test"
+
+type IdentityLookupTestSuite struct {
+	suite.Suite
+	fixture *cluster_test_tool.BaseClusterFixture
+	spawned atomic.Int32
+}
+
+func (suite *IdentityLookupTestSuite) SetupTest() {
+	suite.spawned.Store(0)
+	suite.fixture = cluster_test_tool.NewBaseInMemoryClusterFixture(2,
+		cluster_test_tool.WithGetIdentityLookup(func(string) cluster.IdentityLookup {
+			return New(WithHandoverTimeout(5 * time.Second))
+		}),
+		cluster_test_tool.WithGetClusterKinds(func() []*cluster.Kind {
+			return []*cluster.Kind{
+				cluster.NewKind(testKind, actor.PropsFromFunc(func(ctx actor.Context) {
+					if _, ok := ctx.Message().(*actor.Started); ok {
+						suite.spawned.Add(1)
+					}
+				})),
+			}
+		}),
+	)
+	suite.fixture.Initialize()
+}
+
+func (suite *IdentityLookupTestSuite) TearDownTest() {
+	suite.fixture.ShutDown()
+}
+
+func (suite *IdentityLookupTestSuite) get(c *cluster.Cluster, identity string) *actor.PID {
+	var pid *actor.PID
+	cluster_test_tool.WaitUntil(suite.T(), func() bool {
+		pid = c.Get(identity, testKind)
+		return pid != nil
+	}, "identity was not activated", cluster_test_tool.DefaultWaitTimeout)
+	return pid
+}
+
+func (suite *IdentityLookupTestSuite) TestSingleActivationPerIdentity() {
+	members := suite.fixture.GetMembers()
+
+	for i := 0; i < 10; i++ {
+		identity := fmt.Sprintf("identity-%d", i)
+		first := suite.get(members[0], identity)
+		second := suite.get(members[1], identity)
+		assert.True(suite.T(), first.Equal(second), "members resolved different activations for %s", identity)
+	}
+
+	assert.Equal(suite.T(), int32(10), suite.spawned.Load())
+}
+
+func (suite *IdentityLookupTestSuite) TestActivationsAreHandedOverWhenTopologyChanges() {
+	members := suite.fixture.GetMembers()
+
+	pids := make(map[string]*actor.PID)
+	for i := 0; i < 20; i++ {
+		identity := fmt.Sprintf("identity-%d", i)
+		pids[identity] = suite.get(members[0], identity)
+	}
+
+	joined := suite.fixture.SpawnNode()
+	cluster_test_tool.WaitUntil(suite.T(), func() bool {
+		return joined.MemberList.Length() == 3 && members[0].MemberList.Length() == 3
+	}, "member did not join", cluster_test_tool.DefaultWaitTimeout)
+
+	for identity, pid := range pids {
+		assert.True(suite.T(), pid.Equal(suite.get(joined, identity)), "identity %s was activated again", identity)
+	}
+
+	assert.Equal(suite.T(), int32(20), suite.spawned.Load())
+}
+
+func TestIdentityLookup(t *testing.T) {
+	suite.Run(t, new(IdentityLookupTestSuite))
+}
+
+// counterGrain counts the values it receives and hands its count over when it is moved
+type counterGrain struct {
+	count int64
+}
+
+func (g *counterGrain) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *cluster.ClusterInit:
+		if state, ok := msg.HandoffState.(*wrapperspb.Int64Value); ok {
+			g.count = state.Value
+		}
+	case *cluster.GrainHandoff:
+		msg.SetState(wrapperspb.Int64(g.count))
+	case *wrapperspb.Int64Value:
+		g.count += msg.Value
+		ctx.Respond(wrapperspb.Int64(g.count))
+	}
+}
+
+func TestRebalancingMovesActivationsWithState(t *testing.T) {
+	fixture := cluster_test_tool.NewBaseInMemoryClusterFixture(2,
+		cluster_test_tool.WithGetIdentityLookup(func(string) cluster.IdentityLookup {
+			return New(WithRebalancing(RebalanceAll))
+		}),
+		cluster_test_tool.WithGetClusterKinds(func() []*cluster.Kind {
+			return []*cluster.Kind{
+				cluster.NewKind(testKind, actor.PropsFromProducer(func() actor.Actor { return &counterGrain{} })),
+			}
+		}),
+	)
+	fixture.Initialize()
+	defer fixture.ShutDown()
+
+	members := fixture.GetMembers()
+	add := func(c *cluster.Cluster, identity string) int64 {
+		res, err := c.Request(identity, testKind, wrapperspb.Int64(1))
+		require.NoError(t, err)
+		return res.(*wrapperspb.Int64Value).Value
+	}
+
+	identities := make([]string, 0)
+	activations := make(map[string]*actor.PID)
+	for i := 0; i < 20; i++ {
+		identity := fmt.Sprintf("identity-%d", i)
+		identities = append(identities, identity)
+		add(members[0], identity)
+		add(members[1], identity)
+		activations[identity] = members[0].Get(identity, testKind)
+	}
+
+	joined := fixture.SpawnNode()
+
+	rdv := cluster.NewRendezvous()
+	owners := func() map[string]bool {
+		owned := make(map[string]bool)
+		for _, identity := range identities {
+			owned[identity] = rdv.GetByClusterIdentity(cluster.NewClusterIdentity(identity, testKind)) == joined.ActorSystem.Address()
+		}
+		return owned
+	}
+	cluster_test_tool.WaitUntil(t, func() bool {
+		if joined.MemberList.Length() != 3 {
+			return false
+		}
+		rdv.UpdateMembers(joined.MemberList.Members().Members())
+
+		for identity, ownedByJoined := range owners() {
+			pid := joined.Get(identity, testKind)
+			if pid == nil || ownedByJoined && pid.Address != joined.ActorSystem.Address() {
+				return false
+			}
+		}
+		return true
+	}, "activations were not moved to their new owner", 10*time.Second)
+
+	moved := 0
+	for identity, ownedByJoined := range owners() {
+		if ownedByJoined {
+			moved++
+		} else {
+			// the identities which kept their owner are not moved
+			assert.True(t, activations[identity].Equal(joined.Get(identity, testKind)), "%s was moved", identity)
+		}
+		assert.Equal(t, int64(3), add(members[0], identity), "state of %s was not handed over", identity)
+	}
+	assert.Greater(t, moved, 0)
+}
+
+// heldHandoffGrain is a counterGrain which reports its handoff, the first one reported completes once released
+type heldHandoffGrain struct {
+	counterGrain
+	handoffs chan<- *actor.PID
+	release  <-chan struct{}
+}
+
+func (g *heldHandoffGrain) Receive(ctx actor.Context) {
+	if _, ok := ctx.Message().(*cluster.GrainHandoff); ok {
+		select {
+		case g.handoffs <- ctx.Self():
+			<-g.release
+		default:
+		}
+	}
+	g.counterGrain.Receive(ctx)
+}
+
+func TestRebalancingForwardsTheMessagesOfTheHandedOffActivation(t *testing.T) {
+	handoffs := make(chan *actor.PID, 1)
+	release := make(chan struct{})
+	fixture := cluster_test_tool.NewBaseInMemoryClusterFixture(2,
+		cluster_test_tool.WithGetIdentityLookup(func(string) cluster.IdentityLookup {
+			return New(WithRebalancing(RebalanceAll))
+		}),
+		cluster_test_tool.WithGetClusterKinds(func() []*cluster.Kind {
+			return []*cluster.Kind{
+				cluster.NewKind(testKind, actor.PropsFromProducer(func() actor.Actor {
+					return &heldHandoffGrain{handoffs: handoffs, release: release}
+				})),
+			}
+		}),
+	)
+	fixture.Initialize()
+	defer fixture.ShutDown()
+
+	members := fixture.GetMembers()
+	member := members[0]
+	identities := make(map[string]string)
+	for i := 0; i < 20; i++ {
+		identity := fmt.Sprintf("identity-%d", i)
+		// the members cache the activations they call
+		for _, m := range members {
+			_, err := m.Request(identity, testKind, wrapperspb.Int64(0))
+			require.NoError(t, err)
+		}
+		pid, _ := member.PidCache.Get(identity, testKind)
+		require.NotNil(t, pid)
+		identities[pid.String()] = identity
+	}
+
+	fixture.SpawnNode()
+
+	var handedOff *actor.PID
+	select {
+	case handedOff = <-handoffs:
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "no activation was handed off")
+	}
+	// the messages are queued after the handoff, the moved grain receives them
+	for i := 0; i < 5; i++ {
+		member.ActorSystem.Root.Send(handedOff, wrapperspb.Int64(1))
+	}
+	close(release)
+
+	identity := identities[handedOff.String()]
+	cluster_test_tool.WaitUntil(t, func() bool {
+		for _, m := range members {
+			if cached, _ := m.PidCache.Get(identity, testKind); handedOff.Equal(cached) {
+				return false
+			}
+		}
+		return true
+	}, "the members still cache the handed off activation", 10*time.Second)
+	cluster_test_tool.WaitUntil(t, func() bool {
+		res, err := member.Request(identity, testKind, wrapperspb.Int64(0))
+		return err == nil && res.(*wrapperspb.Int64Value).Value == 5
+	}, "the messages received during the handoff were not forwarded", 10*time.Second)
+}
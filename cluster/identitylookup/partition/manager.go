@@ -0,0 +1,138 @@
+package partition
+
+import (
+	"log/slog"
+	"sync"
+
+	"github.com/asynkron/protoactor-go/actor"
+	clustering "github.com/asynkron/protoactor-go/cluster"
+	"github.com/asynkron/protoactor-go/eventstream"
+)
+
+const (
+	PartitionIdentityActorName  = "partition-identity"
+	PartitionPlacementActorName = "partition-placement"
+)
+
+type Manager struct {
+	cluster        *clustering.Cluster
+	config         *Config
+	topologySub    *eventstream.Subscription
+	terminatedSub  *eventstream.Subscription
+	identityActor  *actor.PID
+	placementActor *actor.PID
+
+	mu           sync.RWMutex
+	rdv          *clustering.Rendezvous
+	topologyHash uint64
+}
+
+func newPartitionManager(c *clustering.Cluster, config *Config) *Manager {
+	return &Manager{
+		cluster: c,
+		config:  config,
+		rdv:     clustering.NewRendezvous(),
+	}
+}
+
+func (pm *Manager) Start(isClient bool) {
+	pm.cluster.Logger().Info("Started partition manager")
+	system := pm.cluster.ActorSystem
+
+	if !isClient {
+		placementProps := actor.PropsFromProducer(func() actor.Actor { return newPlacementActor(pm.cluster) })
+		pm.placementActor, _ = system.Root.SpawnNamed(placementProps, PartitionPlacementActorName)
+		pm.cluster.Logger().Info("Started partition placement actor")
+
+		identityProps := actor.PropsFromProducer(func() actor.Actor { return newIdentityActor(pm.cluster, pm) })
+		pm.identityActor, _ = system.Root.SpawnNamed(identityProps, PartitionIdentityActorName)
+		pm.cluster.Logger().Info("Started partition identity actor")
+
+		pm.terminatedSub = system.EventStream.
+			Subscribe(func(ev interface{}) {
+				if terminated, ok := ev.(*clustering.ActivationTerminated); ok {
+					system.Root.Send(pm.identityActor, terminated)
+				}
+			})
+	}
+
+	pm.topologySub = system.EventStream.
+		Subscribe(func(ev interface{}) {
+			if topology, ok := ev.(*clustering.ClusterTopology); ok {
+				pm.onClusterTopology(topology)
+			}
+		})
+}
+
+func (pm *Manager) Stop() {
+	system := pm.cluster.ActorSystem
+	system.EventStream.Unsubscribe(pm.topologySub)
+
+	if pm.identityActor == nil {
+		pm.cluster.Logger().Info("Stopped PartitionManager")
+		return
+	}
+
+	system.EventStream.Unsubscribe(pm.terminatedSub)
+
+	if err := system.Root.PoisonFuture(pm.identityActor).Wait(); err != nil {
+		pm.cluster.Logger().Error("Failed to shutdown partition identity actor", slog.Any("error", err))
+	}
+
+	if err := system.Root.PoisonFuture(pm.placementActor).Wait(); err != nil {
+		pm.cluster.Logger().Error("Failed to shutdown partition placement actor", slog.Any("error", err))
+	}
+
+	pm.cluster.Logger().Info("Stopped PartitionManager")
+}
+
+func (pm *Manager) PidOfIdentityActor(addr string) *actor.PID {
+	return actor.NewPID(addr, PartitionIdentityActorName)
+}
+
+func (pm *Manager) PidOfPlacementActor(addr string) *actor.PID {
+	return actor.NewPID(addr, PartitionPlacementActorName)
+}
+
+func (pm *Manager) onClusterTopology(tplg *clustering.ClusterTopology) {
+	pm.cluster.Logger().Info("onClusterTopology", slog.Uint64("topology-hash", tplg.TopologyHash))
+
+	rdv := clustering.NewRendezvous()
+	rdv.UpdateMembers(tplg.Members)
+
+	pm.mu.Lock()
+	pm.rdv = rdv
+	pm.topologyHash = tplg.TopologyHash
+	pm.mu.Unlock()
+
+	if pm.identityActor != nil {
+		pm.cluster.ActorSystem.Root.Send(pm.identityActor, tplg)
+	}
+}
+
+func (pm *Manager) Get(identity *clustering.ClusterIdentity) *actor.PID {
+	pm.mu.RLock()
+	ownerAddress := pm.rdv.GetByClusterIdentity(identity)
+	topologyHash := pm.topologyHash
+	pm.mu.RUnlock()
+
+	if ownerAddress == "" {
+		return nil
+	}
+
+	request := &clustering.ActivationRequest{
+		ClusterIdentity: identity,
+		TopologyHash:    topologyHash,
+	}
+	future := pm.cluster.ActorSystem.Root.RequestFuture(pm.PidOfIdentityActor(ownerAddress), request, pm.cluster.Config.TimeoutTime)
+	res, err := future.Result()
+	if err != nil {
+		return nil
+	}
+	typed, ok := res.(*clustering.ActivationResponse)
+	if !ok || typed.Failed {
+		// the owner is on another topology or is still receiving handovers, the caller retries
+		return nil
+	}
+	return typed.Pid
+}
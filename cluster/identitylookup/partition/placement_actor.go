@@ -0,0 +1,173 @@
+package partition
+
+import (
+	"log/slog"
+
+	"github.com/asynkron/protoactor-go/actor"
+	clustering "github.com/asynkron/protoactor-go/cluster"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// placementActor hosts the activations spawned on this member and hands them over to their owners
+type placementActor struct {
+	cluster *clustering.Cluster
+	actors  map[string]*clustering.Activation
+}
+
+func newPlacementActor(c *clustering.Cluster) *placementActor {
+	return &placementActor{
+		cluster: c,
+		actors:  map[string]*clustering.Activation{},
+	}
+}
+
+func (p *placementActor) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *actor.Started:
+		ctx.Logger().Info("Placement actor started")
+	case *actor.Stopping:
+		ctx.Logger().Info("Placement actor stopping")
+		p.onStopping(ctx)
+	case *actor.Stopped:
+		ctx.Logger().Info("Placement actor stopped")
+	case *actor.Terminated:
+		p.onTerminated(msg)
+	case *clustering.ActivationRequest:
+		p.onActivationRequest(msg, ctx)
+	case *clustering.IdentityHandoverRequest:
+		p.onIdentityHandoverRequest(msg, ctx)
+	case *clustering.ActivationHandoffRequest:
+		p.onActivationHandoffRequest(msg, ctx)
+	default:
+		ctx.Logger().Error("Invalid message", slog.Any("message", msg), slog.Any("sender", ctx.Sender()))
+	}
+}
+
+func (p *placementActor) onTerminated(msg *actor.Terminated) {
+	for key, activation := range p.actors {
+		if !activation.Pid.Equal(msg.Who) {
+			continue
+		}
+
+		delete(p.actors, key)
+		activationTerminated := &clustering.ActivationTerminated{
+			Pid:             activation.Pid,
+			ClusterIdentity: activation.ClusterIdentity,
+		}
+		p.cluster.MemberList.BroadcastEvent(activationTerminated, true)
+
+		return
+	}
+}
+
+func (p *placementActor) onStopping(ctx actor.Context) {
+	futures := make(map[string]*actor.Future, len(p.actors))
+
+	for key, activation := range p.actors {
+		futures[key] = ctx.PoisonFuture(activation.Pid)
+	}
+
+	for key, future := range futures {
+		err := future.Wait()
+		if err != nil {
+			ctx.Logger().Error("Failed to poison actor", slog.String("identity", key), slog.Any("error", err))
+		}
+	}
+}
+
+func (p *placementActor) onActivationRequest(msg *clustering.ActivationRequest, ctx actor.Context) {
+	key := msg.ClusterIdentity.AsKey()
+	if activation, found := p.actors[key]; found {
+		ctx.Respond(&clustering.ActivationResponse{Pid: activation.Pid})
+		return
+	}
+
+	clusterKind := p.cluster.GetClusterKind(msg.ClusterIdentity.Kind)
+	if clusterKind == nil {
+		ctx.Logger().Error("Unknown cluster kind", slog.String("kind", msg.ClusterIdentity.Kind))
+		ctx.Respond(&clustering.ActivationResponse{Failed: true})
+		return
+	}
+
+	props := clustering.WithClusterIdentity(clusterKind.Props, msg.ClusterIdentity)
+	if msg.HandoffState != nil {
+		state, err := msg.HandoffState.UnmarshalNew()
+		if err != nil {
+			ctx.Logger().Error("Failed to unpack handoff state", slog.String("identity", key), slog.Any("error", err))
+		} else {
+			props = clustering.WithHandoffState(props, state)
+		}
+	}
+	pid := ctx.SpawnPrefix(props, msg.ClusterIdentity.Identity)
+
+	p.actors[key] = &clustering.Activation{
+		Pid:             pid,
+		ClusterIdentity: msg.ClusterIdentity,
+	}
+
+	ctx.Respond(&clustering.ActivationResponse{Pid: pid})
+}
+
+// onIdentityHandoverRequest returns the activations hosted by this member that the requester owns in the given topology
+func (p *placementActor) onIdentityHandoverRequest(msg *clustering.IdentityHandoverRequest, ctx actor.Context) {
+	rdv := clustering.NewRendezvous()
+	rdv.UpdateMembers(msg.CurrentTopology.Members)
+
+	response := &clustering.IdentityHandover{
+		Actors:       make([]*clustering.Activation, 0),
+		Final:        true,
+		TopologyHash: msg.CurrentTopology.TopologyHash,
+	}
+
+	for _, activation := range p.actors {
+		if rdv.GetByClusterIdentity(activation.ClusterIdentity) != msg.Address {
+			response.Skipped++
+			continue
+		}
+
+		response.Actors = append(response.Actors, activation)
+		response.Sent++
+	}
+
+	ctx.Respond(response)
+}
+
+// onActivationHandoffRequest deactivates a grain that is moved to another member and returns its handoff state
+func (p *placementActor) onActivationHandoffRequest(msg *clustering.ActivationHandoffRequest, ctx actor.Context) {
+	key := msg.ClusterIdentity.AsKey()
+	activation, found := p.actors[key]
+	if !found || !activation.Pid.Equal(msg.Pid) {
+		ctx.Respond(&clustering.ActivationHandoffResponse{Failed: true})
+		return
+	}
+
+	// the activation is no longer reported in identity handovers
+	delete(p.actors, key)
+
+	future := ctx.RequestFuture(activation.Pid, &clustering.GrainHandoff{}, p.cluster.Config.TimeoutTime)
+	ctx.ReenterAfter(future, func(res interface{}, err error) {
+		if err != nil {
+			ctx.Logger().Warn("Grain did not complete handoff", slog.String("identity", key), slog.Any("error", err))
+			p.actors[key] = activation
+			ctx.Respond(&clustering.ActivationHandoffResponse{Failed: true})
+			return
+		}
+
+		// the activation is no longer in the actors once it terminates, the caches of the members drop it now
+		p.cluster.MemberList.BroadcastEvent(&clustering.ActivationTerminated{
+			Pid:             activation.Pid,
+			ClusterIdentity: activation.ClusterIdentity,
+		}, true)
+
+		response := &clustering.ActivationHandoffResponse{}
+		if handoff, ok := res.(*clustering.GrainHandoff); ok && handoff.State() != nil {
+			state, err := anypb.New(handoff.State())
+			if err != nil {
+				ctx.Logger().Error("Failed to pack handoff state", slog.String("identity", key), slog.Any("error", err))
+			} else {
+				response.State = state
+			}
+		}
+		ctx.Respond(response)
+	})
+}
@@ -0,0 +1,140 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var identityBucket = []byte("identities")
+
+// BoltStore is a KeyValueStore persisted in an embedded bbolt database file.
+// bbolt locks the file, so a store can only be opened by a single process at a time;
+// it suits clusters whose members run in one process or a single member deployment
+// that must keep its activations across restarts.
+type BoltStore struct {
+	db *bolt.DB
+}
+
+var _ KeyValueStore = (*BoltStore)(nil)
+
+// NewBoltStore opens or creates the bbolt database at path
+func NewBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(identityBucket)
+		return err
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+func (s *BoltStore) Get(key string) ([]byte, bool, error) {
+	var (
+		value []byte
+		found bool
+	)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		v, ok := getLive(tx.Bucket(identityBucket), key)
+		if ok {
+			value = append([]byte(nil), v...)
+			found = true
+		}
+		return nil
+	})
+	return value, found, err
+}
+
+func (s *BoltStore) Set(key string, value []byte, ttl time.Duration) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(identityBucket).Put([]byte(key), encodeEntry(value, ttl))
+	})
+}
+
+func (s *BoltStore) SetIfAbsent(key string, value []byte, ttl time.Duration) (bool, error) {
+	stored := false
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(identityBucket)
+		if _, ok := getLive(b, key); ok {
+			return nil
+		}
+		stored = true
+		return b.Put([]byte(key), encodeEntry(value, ttl))
+	})
+	return stored, err
+}
+
+func (s *BoltStore) Delete(key string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(identityBucket).Delete([]byte(key))
+	})
+}
+
+func (s *BoltStore) DeleteIfEqual(key string, value []byte) (bool, error) {
+	deleted := false
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(identityBucket)
+		v, ok := getLive(b, key)
+		if !ok || !bytes.Equal(v, value) {
+			return nil
+		}
+		deleted = true
+		return b.Delete([]byte(key))
+	})
+	return deleted, err
+}
+
+func (s *BoltStore) Keys(prefix string) ([]string, error) {
+	keys := make([]string, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(identityBucket).Cursor()
+		p := []byte(prefix)
+		for k, v := c.Seek(p); k != nil && bytes.HasPrefix(k, p); k, v = c.Next() {
+			if _, ok := decodeEntry(v); ok {
+				keys = append(keys, string(k))
+			}
+		}
+		return nil
+	})
+	return keys, err
+}
+
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
+
+// entries are stored as the expiry in unix nanoseconds followed by the value
+func encodeEntry(value []byte, ttl time.Duration) []byte {
+	entry := make([]byte, 8+len(value))
+	binary.BigEndian.PutUint64(entry, uint64(expiresAt(ttl)))
+	copy(entry[8:], value)
+	return entry
+}
+
+func decodeEntry(entry []byte) ([]byte, bool) {
+	if len(entry) < 8 {
+		return nil, false
+	}
+	if expired(int64(binary.BigEndian.Uint64(entry))) {
+		return nil, false
+	}
+	return entry[8:], true
+}
+
+func getLive(b *bolt.Bucket, key string) ([]byte, bool) {
+	entry := b.Get([]byte(key))
+	if entry == nil {
+		return nil, false
+	}
+	return decodeEntry(entry)
+}
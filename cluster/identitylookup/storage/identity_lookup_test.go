@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/cluster"
+	"github.com/asynkron/protoactor-go/cluster/cluster_test_tool"
+	"github.com/stretchr/testify/assert"
+)
+
+const testKind = "storage-test"
+
+func TestIdentityLookupSingleActivation(t *testing.T) {
+	var spawned atomic.Int32
+	store := NewMemoryStore()
+
+	fixture := cluster_test_tool.NewBaseInMemoryClusterFixture(3,
+		cluster_test_tool.WithGetIdentityLookup(func(string) cluster.IdentityLookup {
+			return New(store)
+		}),
+		cluster_test_tool.WithGetClusterKinds(func() []*cluster.Kind {
+			return []*cluster.Kind{
+				cluster.NewKind(testKind, actor.PropsFromFunc(func(ctx actor.Context) {
+					if _, ok := ctx.Message().(*actor.Started); ok {
+						spawned.Add(1)
+					}
+				})),
+			}
+		}),
+	)
+	fixture.Initialize()
+	defer fixture.ShutDown()
+
+	members := fixture.GetMembers()
+	for i := 0; i < 10; i++ {
+		identity := fmt.Sprintf("identity-%d", i)
+		var pid *actor.PID
+		for _, m := range members {
+			p := m.Get(identity, testKind)
+			if assert.NotNil(t, p) && pid != nil {
+				assert.True(t, pid.Equal(p), "members resolved different activations for %s", identity)
+			}
+			pid = p
+		}
+	}
+
+	assert.Equal(t, int32(10), spawned.Load())
+
+	// the activations of a member that leaves are removed from the storage
+	leaving := members[2]
+	leavingID := leaving.ActorSystem.ID
+	fixture.RemoveNode(leaving, true)
+
+	cluster_test_tool.WaitUntil(t, func() bool {
+		keys, _ := store.Keys(memberKey(leavingID, ""))
+		return len(keys) == 0
+	}, "activations of the leaving member were not removed", cluster_test_tool.DefaultWaitTimeout)
+}
@@ -0,0 +1,33 @@
+package storage
+
+import "time"
+
+// KeyValueStore is the storage the identity lookup keeps its locks and activations in.
+// A ttl of zero means the entry never expires, expired entries behave as if they were deleted.
+type KeyValueStore interface {
+	// Get returns the value stored under key and whether it exists
+	Get(key string) ([]byte, bool, error)
+	// Set stores the value under key
+	Set(key string, value []byte, ttl time.Duration) error
+	// SetIfAbsent stores the value under key unless the key exists, it reports whether the value was stored
+	SetIfAbsent(key string, value []byte, ttl time.Duration) (bool, error)
+	// Delete removes the key
+	Delete(key string) error
+	// DeleteIfEqual removes the key if it holds the given value, it reports whether the key was removed
+	DeleteIfEqual(key string, value []byte) (bool, error)
+	// Keys returns all keys starting with prefix
+	Keys(prefix string) ([]string, error)
+	// Close releases the resources held by the store
+	Close() error
+}
+
+func expiresAt(ttl time.Duration) int64 {
+	if ttl <= 0 {
+		return 0
+	}
+	return time.Now().Add(ttl).UnixNano()
+}
+
+func expired(expiresAt int64) bool {
+	return expiresAt > 0 && expiresAt <= time.Now().UnixNano()
+}
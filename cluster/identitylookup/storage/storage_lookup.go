@@ -0,0 +1,280 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/cluster"
+	"github.com/google/uuid"
+)
+
+const (
+	lockPrefix       = "lock/"
+	activationPrefix = "activation/"
+	memberPrefix     = "member/"
+)
+
+// Config configures the storage lookup
+type Config struct {
+	// LockTTL is the time after which a spawn lock expires, it releases identities
+	// locked by members that crashed before storing the activation
+	LockTTL time.Duration
+	// WaitTimeout is the maximum time to wait for an activation spawned by another member
+	WaitTimeout time.Duration
+	// PollInterval is the interval used to check for an activation spawned by another member
+	PollInterval time.Duration
+	Logger       *slog.Logger
+}
+
+type ConfigOption func(config *Config)
+
+// WithLockTTL sets the time after which a spawn lock expires
+func WithLockTTL(ttl time.Duration) ConfigOption {
+	return func(config *Config) {
+		config.LockTTL = ttl
+	}
+}
+
+// WithWaitTimeout sets the maximum time to wait for an activation spawned by another member
+func WithWaitTimeout(timeout time.Duration) ConfigOption {
+	return func(config *Config) {
+		config.WaitTimeout = timeout
+	}
+}
+
+// WithPollInterval sets the interval used to check for an activation spawned by another member
+func WithPollInterval(interval time.Duration) ConfigOption {
+	return func(config *Config) {
+		config.PollInterval = interval
+	}
+}
+
+// WithLogger sets the logger used to report storage errors
+func WithLogger(logger *slog.Logger) ConfigOption {
+	return func(config *Config) {
+		config.Logger = logger
+	}
+}
+
+type storedActivation struct {
+	Address  string `json:"address"`
+	ID       string `json:"id"`
+	MemberID string `json:"member_id"`
+	LockID   string `json:"lock_id"`
+}
+
+// Lookup implements cluster.StorageLookup on top of a KeyValueStore
+type Lookup struct {
+	store  KeyValueStore
+	config *Config
+}
+
+var _ cluster.StorageLookup = (*Lookup)(nil)
+
+// NewLookup creates a cluster.StorageLookup which keeps the spawn locks and activations in store
+func NewLookup(store KeyValueStore, opts ...ConfigOption) *Lookup {
+	config := &Config{
+		LockTTL:      10 * time.Second,
+		WaitTimeout:  5 * time.Second,
+		PollInterval: 50 * time.Millisecond,
+		Logger:       slog.Default(),
+	}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	return &Lookup{
+		store:  store,
+		config: config,
+	}
+}
+
+// New creates an identity lookup which guarantees a single activation per identity across the
+// cluster, as long as all members share the same store.
+// NewMemoryStore and NewBoltStore can only be shared by the members running in the same process,
+// a cluster whose members run in several processes needs a KeyValueStore they all connect to.
+func New(store KeyValueStore, opts ...ConfigOption) cluster.IdentityLookup {
+	return cluster.NewIdentityStorageLookup(NewLookup(store, opts...))
+}
+
+func (l *Lookup) TryGetExistingActivation(clusterIdentity *cluster.ClusterIdentity) *cluster.StoredActivation {
+	activation := l.getActivation(clusterIdentity.AsKey())
+	if activation == nil {
+		return nil
+	}
+
+	return cluster.NewStoredActivation(actor.NewPID(activation.Address, activation.ID), activation.MemberID)
+}
+
+func (l *Lookup) TryAcquireLock(clusterIdentity *cluster.ClusterIdentity) *cluster.SpawnLock {
+	lockID := uuid.NewString()
+	acquired, err := l.store.SetIfAbsent(lockPrefix+clusterIdentity.AsKey(), []byte(lockID), l.config.LockTTL)
+	if err != nil {
+		l.config.Logger.Error("Failed to acquire spawn lock", slog.String("identity", clusterIdentity.AsKey()), slog.Any("error", err))
+		return nil
+	}
+	if !acquired {
+		return nil
+	}
+
+	return &cluster.SpawnLock{
+		LockID:          lockID,
+		ClusterIdentity: clusterIdentity,
+	}
+}
+
+func (l *Lookup) WaitForActivation(clusterIdentity *cluster.ClusterIdentity) *cluster.StoredActivation {
+	deadline := time.Now().Add(l.config.WaitTimeout)
+	lockKey := lockPrefix + clusterIdentity.AsKey()
+
+	for {
+		if activation := l.TryGetExistingActivation(clusterIdentity); activation != nil {
+			return activation
+		}
+
+		// the spawning member released or lost the lock without storing an activation
+		if _, locked, err := l.store.Get(lockKey); err == nil && !locked {
+			return l.TryGetExistingActivation(clusterIdentity)
+		}
+
+		if time.Now().After(deadline) {
+			return nil
+		}
+		time.Sleep(l.config.PollInterval)
+	}
+}
+
+func (l *Lookup) RemoveLock(spawnLock cluster.SpawnLock) {
+	if _, err := l.store.DeleteIfEqual(lockPrefix+spawnLock.ClusterIdentity.AsKey(), []byte(spawnLock.LockID)); err != nil {
+		l.config.Logger.Error("Failed to remove spawn lock", slog.String("identity", spawnLock.ClusterIdentity.AsKey()), slog.Any("error", err))
+	}
+}
+
+func (l *Lookup) StoreActivation(memberID string, spawnLock *cluster.SpawnLock, pid *actor.PID) error {
+	key := spawnLock.ClusterIdentity.AsKey()
+
+	lockID, locked, err := l.store.Get(lockPrefix + key)
+	if err != nil {
+		return fmt.Errorf("get spawn lock of %s: %w", key, err)
+	}
+	if !locked || string(lockID) != spawnLock.LockID {
+		// the lock expired or belongs to another member, which may activate the identity
+		return cluster.ErrSpawnLockLost
+	}
+
+	value, err := json.Marshal(&storedActivation{
+		Address:  pid.Address,
+		ID:       pid.Id,
+		MemberID: memberID,
+		LockID:   spawnLock.LockID,
+	})
+	if err != nil {
+		return fmt.Errorf("encode activation of %s: %w", key, err)
+	}
+
+	// the lock may still expire before the activation is stored, an activation stored meanwhile is never replaced
+	stored, err := l.store.SetIfAbsent(activationPrefix+key, value, 0)
+	if err != nil {
+		return fmt.Errorf("store activation of %s: %w", key, err)
+	}
+	if !stored {
+		if activation := l.getActivation(key); activation == nil || activation.LockID != spawnLock.LockID {
+			return cluster.ErrSpawnLockLost
+		}
+	}
+	if err := l.store.Set(memberKey(memberID, key), []byte(spawnLock.LockID), 0); err != nil {
+		l.config.Logger.Error("Failed to index activation", slog.String("identity", key), slog.Any("error", err))
+	}
+
+	l.RemoveLock(*spawnLock)
+
+	return nil
+}
+
+// RemoveActivation removes the activation stored with the given spawn lock, an activation
+// stored with another lock is kept, as it replaced the removed one
+func (l *Lookup) RemoveActivation(spawnLock *cluster.SpawnLock) {
+	key := spawnLock.ClusterIdentity.AsKey()
+
+	activation := l.getActivation(key)
+	if activation == nil || activation.LockID != spawnLock.LockID {
+		return
+	}
+
+	l.removeActivation(key, activation)
+}
+
+// RemovePid removes the activation stored for the identity when it is the given pid, an activation
+// stored for another pid is kept
+func (l *Lookup) RemovePid(clusterIdentity *cluster.ClusterIdentity, pid *actor.PID) {
+	key := clusterIdentity.AsKey()
+
+	activation := l.getActivation(key)
+	if activation == nil || activation.Address != pid.Address || activation.ID != pid.Id {
+		return
+	}
+
+	l.removeActivation(key, activation)
+}
+
+// RemoveMemberId removes all activations hosted by the given member
+func (l *Lookup) RemoveMemberId(memberID string) {
+	prefix := memberKey(memberID, "")
+	keys, err := l.store.Keys(prefix)
+	if err != nil {
+		l.config.Logger.Error("Failed to list member activations", slog.String("member", memberID), slog.Any("error", err))
+		return
+	}
+
+	for _, k := range keys {
+		key := strings.TrimPrefix(k, prefix)
+		if activation := l.getActivation(key); activation != nil && activation.MemberID == memberID {
+			l.removeActivation(key, activation)
+			continue
+		}
+		if err := l.store.Delete(k); err != nil {
+			l.config.Logger.Error("Failed to remove activation index", slog.String("identity", key), slog.Any("error", err))
+		}
+	}
+}
+
+func (l *Lookup) getActivation(key string) *storedActivation {
+	value, found, err := l.store.Get(activationPrefix + key)
+	if err != nil {
+		l.config.Logger.Error("Failed to get activation", slog.String("identity", key), slog.Any("error", err))
+		return nil
+	}
+	if !found {
+		return nil
+	}
+
+	var activation storedActivation
+	if err := json.Unmarshal(value, &activation); err != nil {
+		l.config.Logger.Error("Failed to decode activation", slog.String("identity", key), slog.Any("error", err))
+		return nil
+	}
+
+	return &activation
+}
+
+func (l *Lookup) removeActivation(key string, activation *storedActivation) {
+	value, err := json.Marshal(activation)
+	if err != nil {
+		return
+	}
+	// compare with the stored value so a concurrently stored activation is kept
+	if _, err := l.store.DeleteIfEqual(activationPrefix+key, value); err != nil {
+		l.config.Logger.Error("Failed to remove activation", slog.String("identity", key), slog.Any("error", err))
+	}
+	if err := l.store.Delete(memberKey(activation.MemberID, key)); err != nil {
+		l.config.Logger.Error("Failed to remove activation index", slog.String("identity", key), slog.Any("error", err))
+	}
+}
+
+func memberKey(memberID, key string) string {
+	return memberPrefix + memberID + "/" + key
+}
@@ -0,0 +1,226 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/cluster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stores(t *testing.T) map[string]KeyValueStore {
+	bolt, err := NewBoltStore(filepath.Join(t.TempDir(), "identities.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = bolt.Close() })
+
+	return map[string]KeyValueStore{
+		"memory": NewMemoryStore(),
+		"bolt":   bolt,
+	}
+}
+
+func TestKeyValueStore(t *testing.T) {
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			stored, err := store.SetIfAbsent("a/1", []byte("x"), 0)
+			require.NoError(t, err)
+			assert.True(t, stored)
+
+			stored, err = store.SetIfAbsent("a/1", []byte("y"), 0)
+			require.NoError(t, err)
+			assert.False(t, stored)
+
+			require.NoError(t, store.Set("a/2", []byte("z"), 0))
+			require.NoError(t, store.Set("b/1", []byte("z"), 0))
+
+			keys, err := store.Keys("a/")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"a/1", "a/2"}, keys)
+
+			deleted, err := store.DeleteIfEqual("a/1", []byte("y"))
+			require.NoError(t, err)
+			assert.False(t, deleted)
+
+			deleted, err = store.DeleteIfEqual("a/1", []byte("x"))
+			require.NoError(t, err)
+			assert.True(t, deleted)
+
+			_, found, err := store.Get("a/1")
+			require.NoError(t, err)
+			assert.False(t, found)
+		})
+	}
+}
+
+func TestKeyValueStoreExpiresEntries(t *testing.T) {
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, store.Set("ttl", []byte("x"), 20*time.Millisecond))
+
+			_, found, _ := store.Get("ttl")
+			assert.True(t, found)
+
+			time.Sleep(40 * time.Millisecond)
+
+			_, found, _ = store.Get("ttl")
+			assert.False(t, found)
+
+			stored, err := store.SetIfAbsent("ttl", []byte("y"), 0)
+			require.NoError(t, err)
+			assert.True(t, stored)
+		})
+	}
+}
+
+func TestLookupSpawnLock(t *testing.T) {
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			lookup := NewLookup(store, WithLockTTL(50*time.Millisecond))
+			identity := cluster.NewClusterIdentity("a", "kind")
+
+			lock := lookup.TryAcquireLock(identity)
+			require.NotNil(t, lock)
+			assert.Nil(t, lookup.TryAcquireLock(identity), "lock must be exclusive")
+
+			// an expired lock can be acquired again
+			time.Sleep(100 * time.Millisecond)
+			lock = lookup.TryAcquireLock(identity)
+			require.NotNil(t, lock)
+
+			lookup.RemoveLock(*lock)
+			assert.NotNil(t, lookup.TryAcquireLock(identity))
+		})
+	}
+}
+
+func TestLookupStoresActivations(t *testing.T) {
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			lookup := NewLookup(store)
+			identity := cluster.NewClusterIdentity("a", "kind")
+			pid := actor.NewPID("localhost:1", "placement-activator/a$1")
+
+			lock := lookup.TryAcquireLock(identity)
+			require.NotNil(t, lock)
+			require.NoError(t, lookup.StoreActivation("member-1", lock, pid))
+
+			activation := lookup.TryGetExistingActivation(identity)
+			require.NotNil(t, activation)
+			assert.Equal(t, "member-1", activation.MemberID)
+			assert.True(t, pid.Equal(activation.PID()))
+
+			// the lock is released once the activation is stored
+			other := lookup.TryAcquireLock(identity)
+			require.NotNil(t, other)
+
+			// removing with another lock keeps the activation
+			lookup.RemoveActivation(other)
+			assert.NotNil(t, lookup.TryGetExistingActivation(identity))
+
+			lookup.RemoveActivation(lock)
+			assert.Nil(t, lookup.TryGetExistingActivation(identity))
+		})
+	}
+}
+
+func TestLookupStoreActivationWithLostLock(t *testing.T) {
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			lookup := NewLookup(store, WithLockTTL(50*time.Millisecond))
+			identity := cluster.NewClusterIdentity("a", "kind")
+
+			expired := lookup.TryAcquireLock(identity)
+			require.NotNil(t, expired)
+			time.Sleep(100 * time.Millisecond)
+
+			// another member acquires the expired lock and stores its activation
+			other := lookup.TryAcquireLock(identity)
+			require.NotNil(t, other)
+			require.NoError(t, lookup.StoreActivation("member-2", other, actor.NewPID("localhost:2", "a")))
+
+			err := lookup.StoreActivation("member-1", expired, actor.NewPID("localhost:1", "a"))
+			assert.ErrorIs(t, err, cluster.ErrSpawnLockLost)
+
+			activation := lookup.TryGetExistingActivation(identity)
+			require.NotNil(t, activation)
+			assert.Equal(t, "member-2", activation.MemberID)
+
+			// an activation stored while the lock is held is not replaced either
+			held := lookup.TryAcquireLock(identity)
+			require.NotNil(t, held)
+			assert.ErrorIs(t, lookup.StoreActivation("member-1", held, actor.NewPID("localhost:1", "a")), cluster.ErrSpawnLockLost)
+			assert.Equal(t, "member-2", lookup.TryGetExistingActivation(identity).MemberID)
+		})
+	}
+}
+
+func TestLookupRemoveMemberId(t *testing.T) {
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			lookup := NewLookup(store)
+
+			activate := func(identity, memberID string) {
+				ci := cluster.NewClusterIdentity(identity, "kind")
+				lock := lookup.TryAcquireLock(ci)
+				require.NotNil(t, lock)
+				require.NoError(t, lookup.StoreActivation(memberID, lock, actor.NewPID(memberID, identity)))
+			}
+			activate("a", "member-1")
+			activate("b", "member-1")
+			activate("c", "member-2")
+
+			lookup.RemoveMemberId("member-1")
+
+			assert.Nil(t, lookup.TryGetExistingActivation(cluster.NewClusterIdentity("a", "kind")))
+			assert.Nil(t, lookup.TryGetExistingActivation(cluster.NewClusterIdentity("b", "kind")))
+			assert.NotNil(t, lookup.TryGetExistingActivation(cluster.NewClusterIdentity("c", "kind")))
+		})
+	}
+}
+
+func TestLookupRemovePid(t *testing.T) {
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			lookup := NewLookup(store)
+			identity := cluster.NewClusterIdentity("a", "kind")
+			pid := actor.NewPID("localhost:1", "a")
+
+			lock := lookup.TryAcquireLock(identity)
+			require.NotNil(t, lock)
+			require.NoError(t, lookup.StoreActivation("member-1", lock, pid))
+
+			// the activation of another pid is kept
+			lookup.RemovePid(identity, actor.NewPID("localhost:2", "a"))
+			assert.NotNil(t, lookup.TryGetExistingActivation(identity))
+
+			lookup.RemovePid(identity, pid)
+			assert.Nil(t, lookup.TryGetExistingActivation(identity))
+
+			// the identity can be activated again
+			lock = lookup.TryAcquireLock(identity)
+			require.NotNil(t, lock)
+			assert.NoError(t, lookup.StoreActivation("member-2", lock, actor.NewPID("localhost:2", "a")))
+		})
+	}
+}
+
+func TestLookupWaitForActivation(t *testing.T) {
+	lookup := NewLookup(NewMemoryStore(), WithWaitTimeout(time.Second), WithPollInterval(10*time.Millisecond))
+	identity := cluster.NewClusterIdentity("a", "kind")
+	pid := actor.NewPID("localhost:1", "a")
+
+	lock := lookup.TryAcquireLock(identity)
+	require.NotNil(t, lock)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		assert.NoError(t, lookup.StoreActivation("member-1", lock, pid))
+	}()
+
+	activation := lookup.WaitForActivation(identity)
+	require.NotNil(t, activation)
+	assert.True(t, pid.Equal(activation.PID()))
+}
@@ -3,10 +3,9 @@
 package cluster
 
 import (
-	"fmt"
 	"log/slog"
 	"math/rand"
-	"reflect"
+	"sort"
 	"time"
 
 	"github.com/asynkron/gofun/set"
@@ -18,6 +17,14 @@ const (
 	TopologyKey       string = "topology"
 	HearthbeatKey     string = "heathbeat"
 	GracefullyLeftKey string = "left"
+	LabelsKey         string = "labels"
+	SingletonsKey     string = "singletons"
+	LeaderKey         string = "leader"
+	DrainingKey       string = "draining"
+	// TopicPatternsKey is the gossip key of the pubsub topic patterns with subscribers hosted by the member
+	TopicPatternsKey string = "pubsub-patterns"
+	// DistributedDataKeyPrefix prefixes the key of each replica gossiped by DistributedData
+	DistributedDataKeyPrefix string = "ddata/"
 )
 
 // create and seed a pseudo random numbers generator
@@ -37,6 +44,14 @@ type Informer struct {
 	gossipMaxSend     int
 	throttler         actor.ShouldThrottle
 	logger            *slog.Logger
+
+	// anti-entropy, see gossip_digest.go
+	synced              map[string]empty
+	antiEntropyInterval time.Duration
+	lastAntiEntropy     time.Time
+	maxMessageSize      int
+	pendingChanges      []stateChange
+	stats               *gossipStats
 }
 
 // makes sure Informer complies with the Gossip interface
@@ -58,6 +73,7 @@ func newInformer(myID string, getBlockedMembers func() set.Set[string], fanOut i
 		gossipFanOut:      fanOut,
 		gossipMaxSend:     maxSend,
 		logger:            logger,
+		synced:            map[string]empty{},
 	}
 	informer.throttler = actor.NewThrottle(3, 60*time.Second, informer.throttledLog)
 	return &informer
@@ -77,13 +93,22 @@ func (inf *Informer) UpdateClusterTopology(topology *ClusterTopology) {
 	for _, member := range topology.Members {
 		active[member.Id] = empty{}
 	}
+	inf.activeMemberIDs = active
+
+	for memberID := range inf.synced {
+		if _, ok := active[memberID]; !ok {
+			delete(inf.synced, memberID)
+		}
+	}
 
 	inf.SetState(TopologyKey, topology)
+	inf.checkConvergence()
 }
 
 // sets new update key state using the given proto message
 func (inf *Informer) SetState(key string, message proto.Message) {
 	inf.localSeqNumber = setKey(inf.state, key, message, inf.myID, inf.localSeqNumber)
+	inf.trackChange(inf.localSeqNumber)
 
 	//if inf.throttler() == actor.Open {
 	//	sequenceNumbers := map[string]uint64{}
@@ -108,6 +133,14 @@ func (inf *Informer) SetState(key string, message proto.Message) {
 // from the slice of other members known by this informer until gossipFanOut
 // number of sent has been reached
 func (inf *Informer) SendState(sendStateToMember LocalStateSender) {
+	inf.SendStateAndDigests(sendStateToMember, nil)
+}
+
+// sends this informer local state to remote informers like SendState, except
+// to the members it did not exchange digests with yet, it sends them its digest
+// instead. A digest is also exchanged with a random member at each anti-entropy
+// interval, and at once when this informer did not exchange any digest yet
+func (inf *Informer) SendStateAndDigests(sendStateToMember LocalStateSender, sendDigestToMember DigestSender) {
 	// inf.purgeBannedMembers()  // TODO
 	for _, member := range inf.otherMembers {
 		ensureMemberStateExists(inf.state, member.Id)
@@ -122,16 +155,45 @@ func (inf *Informer) SendState(sendStateToMember LocalStateSender) {
 		otherMembers[i], otherMembers[j] = otherMembers[j], otherMembers[i]
 	})
 
+	var digest *GossipDigest
+	getDigest := func() *GossipDigest {
+		if digest == nil {
+			digest = inf.GetDigest()
+		}
+		return digest
+	}
+
+	var antiEntropyMemberID string
+	if sendDigestToMember != nil && len(otherMembers) > 0 && inf.antiEntropyDue() {
+		inf.lastAntiEntropy = time.Now()
+		antiEntropyMemberID = otherMembers[0].Id
+		sendDigestToMember(getDigest(), otherMembers[0])
+	}
+
+	// a joining informer receives the state of the cluster from a single member
+	if sendDigestToMember != nil && len(inf.synced) == 0 {
+		return
+	}
+
 	fanOutCount := 0
 	for _, member := range otherMembers {
-		memberState := inf.GetMemberStateDelta(member.Id)
-		if !memberState.HasState {
-			// nothing has change, skip it
+		if member.Id == antiEntropyMemberID {
 			continue
 		}
 
-		// fire and forget, we handle results in ReenterAfter
-		sendStateToMember(memberState, member)
+		if sendDigestToMember != nil && !inf.isSynced(member.Id) {
+			// the member may have most of the state already, the digests tell which entries it misses
+			sendDigestToMember(getDigest(), member)
+		} else {
+			memberState := inf.GetMemberStateDelta(member.Id)
+			if !memberState.HasState {
+				// nothing has change, skip it
+				continue
+			}
+
+			// fire and forget, we handle results in ReenterAfter
+			sendStateToMember(memberState, member)
+		}
 		fanOutCount++
 
 		// we reached our limit, break
@@ -147,8 +209,8 @@ func (inf *Informer) GetMemberStateDelta(targetMemberID string) *MemberStateDelt
 	// newState will old the final new state to be sent
 	newState := GossipState{Members: make(map[string]*GossipState_GossipMemberState)}
 
-	// hashmaps in Go are random by nature so no need to randomize state.Members
-	pendingOffsets := inf.committedOffsets
+	// the offsets are committed once the target member acknowledged the state
+	pendingOffsets := make(map[string]int64)
 
 	// create a new map with gossipMaxSend entries max
 	members := make(map[string]*GossipState_GossipMemberState)
@@ -175,31 +237,46 @@ func (inf *Informer) GetMemberStateDelta(targetMemberID string) *MemberStateDelt
 		}
 	}
 
+	// our own state is sent first
+	memberIDs := make([]string, 0, len(members))
+	for memberID := range members {
+		if memberID != inf.myID {
+			memberIDs = append(memberIDs, memberID)
+		}
+	}
+	if _, ok := members[inf.myID]; ok {
+		memberIDs = append([]string{inf.myID}, memberIDs...)
+	}
+
 	// now we iterate over our subset of members and proceed to send them if applicable
-	for memberID, memberState := range members {
+	size := 0
+	for _, memberID := range memberIDs {
+		memberState := members[memberID]
 
 		// create an empty state
 		newMemberState := GossipState_GossipMemberState{
 			Values: make(map[string]*GossipKeyValue),
 		}
 
-		watermarkKey := fmt.Sprintf("%s.%s", targetMemberID, memberID)
+		watermarkKey := gossipWatermarkKey(targetMemberID, memberID)
 
 		// get the water mark
 		watermark := inf.committedOffsets[watermarkKey]
 		newWatermark := watermark
 
-		// for each value in member state
-		for key, value := range memberState.Values {
-
-			if value.SequenceNumber <= watermark {
-				continue
-			}
-
-			if value.SequenceNumber > newWatermark {
-				newWatermark = value.SequenceNumber
+		// the values are sent in sequence order, so when the message is full
+		// the watermark still covers all the values up to the last one sent
+		full := false
+		for _, key := range newerKeys(memberState, watermark) {
+			value := memberState.Values[key]
+			valueSize := len(key) + proto.Size(value)
+			if inf.maxMessageSize > 0 && size > 0 && size+valueSize > inf.maxMessageSize {
+				full = true
+				break
 			}
 
+			size += valueSize
+			newWatermark = value.SequenceNumber
 			newMemberState.Values[key] = value
 		}
 
@@ -208,12 +285,15 @@ func (inf *Informer) GetMemberStateDelta(targetMemberID string) *MemberStateDelt
 			newState.Members[memberID] = &newMemberState
 			pendingOffsets[watermarkKey] = newWatermark
 		}
+
+		if full {
+			break
+		}
 	}
 
-	hasState := reflect.DeepEqual(inf.committedOffsets, pendingOffsets)
 	memberState := &MemberStateDelta{
 		TargetMemberID: targetMemberID,
-		HasState:       hasState,
+		HasState:       len(newState.Members) > 0,
 		State:          &newState,
 		CommitOffsets: func() {
 			inf.commitPendingOffsets(pendingOffsets)
@@ -287,6 +367,22 @@ func (inf *Informer) commitPendingOffsets(offsets map[string]int64) {
 			inf.committedOffsets[key] = seqNumber
 		}
 	}
+	inf.checkConvergence()
+}
+
+// returns the keys of the values of the member state newer than the watermark, in sequence order
+func newerKeys(memberState *GossipMemberState, watermark int64) []string {
+	keys := make([]string, 0, len(memberState.Values))
+	for key, value := range memberState.Values {
+		if value.SequenceNumber > watermark {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return memberState.Values[keys[i]].SequenceNumber < memberState.Values[keys[j]].SequenceNumber
+	})
+
+	return keys
 }
 
 func (inf *Informer) throttledLog(counter int32) {
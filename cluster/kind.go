@@ -1,6 +1,7 @@
 package cluster
 
 import (
+	"sync"
 	"sync/atomic"
 
 	"github.com/asynkron/protoactor-go/actor"
@@ -11,6 +12,10 @@ type Kind struct {
 	Kind            string
 	Props           *actor.Props
 	StrategyBuilder func(*Cluster) MemberStrategy
+	Singleton       bool
+	RetryPolicy     *RetryPolicy
+	// Placement holds the labels a member must carry to host the kind, see WithPlacement
+	Placement map[string]string
 }
 
 // NewKind creates a new instance of a kind
@@ -28,24 +33,49 @@ func (k *Kind) WithMemberStrategy(strategyBuilder func(*Cluster) MemberStrategy)
 	k.StrategyBuilder = strategyBuilder
 }
 
+// AsSingleton declares the kind as a cluster singleton, see Singletons
+func (k *Kind) AsSingleton() *Kind {
+	k.Singleton = true
+	return k
+}
+
+// WithRetryPolicy sets the retry policy of the calls to the kind, see RetryPolicy
+func (k *Kind) WithRetryPolicy(policy *RetryPolicy) *Kind {
+	k.RetryPolicy = policy
+	return k
+}
+
+// WithPlacement only places the kind on the members carrying all the labels, e.g. role=worker and zone=eu-1.
+// The other members do not host the kind, they still call its grains.
+func (k *Kind) WithPlacement(labels map[string]string) *Kind {
+	k.Placement = labels
+	return k
+}
+
 func (k *Kind) Build(cluster *Cluster) *ActivatedKind {
 	var strategy MemberStrategy = nil
 	if k.StrategyBuilder != nil {
 		strategy = k.StrategyBuilder(cluster)
+		if ks, ok := strategy.(kindMemberStrategy); ok {
+			ks.setKind(k.Kind)
+		}
 	}
 
 	return &ActivatedKind{
-		Kind:     k.Kind,
-		Props:    k.Props,
-		Strategy: strategy,
+		Kind:      k.Kind,
+		Props:     k.Props,
+		Strategy:  strategy,
+		Singleton: k.Singleton,
 	}
 }
 
 type ActivatedKind struct {
-	Kind     string
-	Props    *actor.Props
-	Strategy MemberStrategy
-	count    int32
+	Kind        string
+	Props       *actor.Props
+	Strategy    MemberStrategy
+	Singleton   bool
+	count       int32
+	activations sync.Map
 }
 
 func (ak *ActivatedKind) Inc() {
@@ -55,3 +85,29 @@ func (ak *ActivatedKind) Inc() {
 func (ak *ActivatedKind) Dev() {
 	atomic.AddInt32(&ak.count, -1)
 }
+
+// Count returns the number of activations of the kind on this member
+func (ak *ActivatedKind) Count() int32 {
+	return atomic.LoadInt32(&ak.count)
+}
+
+// Activations returns the activations of the kind on this member
+func (ak *ActivatedKind) Activations() []*actor.PID {
+	var res []*actor.PID
+	ak.activations.Range(func(_, value interface{}) bool {
+		res = append(res, value.(*actor.PID))
+		return true
+	})
+
+	return res
+}
+
+func (ak *ActivatedKind) activated(pid *actor.PID) {
+	ak.activations.Store(pid.Id, pid)
+	ak.Inc()
+}
+
+func (ak *ActivatedKind) deactivated(pid *actor.PID) {
+	ak.activations.Delete(pid.Id)
+	ak.Dev()
+}
@@ -0,0 +1,91 @@
+// Package kvstore provides persistent implementations of cluster.KeyValueStore for protobuf values,
+// such as the subscriptions of the pubsub topics, and of cluster.TopicLogStore for the durable topics.
+package kvstore
+
+import (
+	"time"
+
+	"github.com/asynkron/protoactor-go/cluster"
+	bolt "go.etcd.io/bbolt"
+	"golang.org/x/net/context"
+	"google.golang.org/protobuf/proto"
+)
+
+// BoltStore is a cluster.KeyValueStore persisted in an embedded bbolt database file.
+// bbolt locks the file, so a store can only be opened by a single process at a time;
+// it suits clusters whose members run in one process or a single member deployment
+// that must keep its values across restarts.
+type BoltStore[T proto.Message] struct {
+	db     *bolt.DB
+	bucket []byte
+}
+
+var _ cluster.KeyValueStore[*cluster.Subscribers] = (*BoltStore[*cluster.Subscribers])(nil)
+
+// NewBoltStore opens or creates the bbolt database at path and keeps the values in the bucket
+func NewBoltStore[T proto.Message](path string, bucket string) (*BoltStore[T], error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists([]byte(bucket))
+		return err
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return &BoltStore[T]{db: db, bucket: []byte(bucket)}, nil
+}
+
+func (s *BoltStore[T]) Set(_ context.Context, key string, value T) error {
+	data, err := proto.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(s.bucket).Put([]byte(key), data)
+	})
+}
+
+func (s *BoltStore[T]) Get(_ context.Context, key string) (T, error) {
+	var data []byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		if v := tx.Bucket(s.bucket).Get([]byte(key)); v != nil {
+			data = append([]byte(nil), v...)
+		}
+		return nil
+	})
+	if err != nil || data == nil {
+		var none T
+		return none, err
+	}
+
+	return unmarshal[T](data)
+}
+
+func (s *BoltStore[T]) Clear(_ context.Context, key string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(s.bucket).Delete([]byte(key))
+	})
+}
+
+// Close closes the database file
+func (s *BoltStore[T]) Close() error {
+	return s.db.Close()
+}
+
+// unmarshal creates a value of the message type T from its protobuf encoding
+func unmarshal[T proto.Message](data []byte) (T, error) {
+	var none T
+	value := none.ProtoReflect().Type().New().Interface().(T)
+	if err := proto.Unmarshal(data, value); err != nil {
+		return none, err
+	}
+
+	return value, nil
+}
@@ -0,0 +1,76 @@
+package kvstore
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/cluster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/protobuf/proto"
+)
+
+func testSubscribers(ids ...string) *cluster.Subscribers {
+	subscribers := &cluster.Subscribers{}
+	for _, id := range ids {
+		subscribers.Subscribers = append(subscribers.Subscribers, &cluster.SubscriberIdentity{
+			Identity: &cluster.SubscriberIdentity_Pid{Pid: actor.NewPID("address", id)},
+		})
+	}
+	return subscribers
+}
+
+// testStore checks the behavior shared by the stores
+func testStore(t *testing.T, store cluster.KeyValueStore[*cluster.Subscribers]) {
+	ctx := context.Background()
+
+	value, err := store.Get(ctx, "missing")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	require.NoError(t, store.Set(ctx, "topic", testSubscribers("a", "b")))
+	require.NoError(t, store.Set(ctx, "other", testSubscribers("c")))
+	value, err = store.Get(ctx, "topic")
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(testSubscribers("a", "b"), value))
+
+	require.NoError(t, store.Set(ctx, "topic", testSubscribers("b")))
+	value, err = store.Get(ctx, "topic")
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(testSubscribers("b"), value))
+
+	require.NoError(t, store.Clear(ctx, "topic"))
+	value, err = store.Get(ctx, "topic")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	value, err = store.Get(ctx, "other")
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(testSubscribers("c"), value))
+}
+
+func TestBoltStore(t *testing.T) {
+	store, err := NewBoltStore[*cluster.Subscribers](filepath.Join(t.TempDir(), "kv.db"), "subscriptions")
+	require.NoError(t, err)
+	defer store.Close()
+
+	testStore(t, store)
+}
+
+func TestBoltStoreKeepsValuesAcrossRestarts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kv.db")
+	store, err := NewBoltStore[*cluster.Subscribers](path, "subscriptions")
+	require.NoError(t, err)
+	require.NoError(t, store.Set(context.Background(), "topic", testSubscribers("a")))
+	require.NoError(t, store.Close())
+
+	store, err = NewBoltStore[*cluster.Subscribers](path, "subscriptions")
+	require.NoError(t, err)
+	defer store.Close()
+
+	value, err := store.Get(context.Background(), "topic")
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(testSubscribers("a"), value))
+}
@@ -0,0 +1,223 @@
+package kvstore
+
+import (
+	"context"
+	"encoding/binary"
+	"time"
+
+	"github.com/asynkron/protoactor-go/cluster"
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	entriesBucket = []byte("entries")
+	offsetsBucket = []byte("offsets")
+)
+
+// BoltTopicLogStore is a cluster.TopicLogStore persisted in an embedded bbolt database file, the logs of the
+// durable topics and the offsets of their subscribers are kept across restarts.
+// As with BoltStore, the file can only be opened by a single process at a time.
+type BoltTopicLogStore struct {
+	db     *bolt.DB
+	bucket []byte
+}
+
+var _ cluster.TopicLogStore = (*BoltTopicLogStore)(nil)
+
+// NewBoltTopicLogStore opens or creates the bbolt database at path and keeps the logs in the bucket
+func NewBoltTopicLogStore(path string, bucket string) (*BoltTopicLogStore, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists([]byte(bucket))
+		return err
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return &BoltTopicLogStore{db: db, bucket: []byte(bucket)}, nil
+}
+
+// topic returns the bucket of the topic holding its entries and offsets buckets, nil if nothing was stored for
+// the topic and the transaction is read-only
+func (s *BoltTopicLogStore) topic(tx *bolt.Tx, topic string) (*bolt.Bucket, error) {
+	root := tx.Bucket(s.bucket)
+	if !tx.Writable() {
+		return root.Bucket([]byte(topic)), nil
+	}
+
+	b, err := root.CreateBucketIfNotExists([]byte(topic))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := b.CreateBucketIfNotExists(entriesBucket); err != nil {
+		return nil, err
+	}
+	if _, err := b.CreateBucketIfNotExists(offsetsBucket); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+func (s *BoltTopicLogStore) Append(_ context.Context, topic string, batch *cluster.PubSubBatchTransport) (uint64, error) {
+	data, err := proto.Marshal(batch)
+	if err != nil {
+		return 0, err
+	}
+
+	var offset uint64
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		b, err := s.topic(tx, topic)
+		if err != nil {
+			return err
+		}
+
+		// the sequence of the bucket is kept when the entries are truncated, so offsets are never reused
+		entries := b.Bucket(entriesBucket)
+		if offset, err = entries.NextSequence(); err != nil {
+			return err
+		}
+
+		return entries.Put(offsetKey(offset), data)
+	})
+
+	return offset, err
+}
+
+func (s *BoltTopicLogStore) Read(_ context.Context, topic string, from uint64, limit int) ([]*cluster.TopicLogEntry, error) {
+	var entries []*cluster.TopicLogEntry
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b, _ := s.topic(tx, topic)
+		if b == nil {
+			return nil
+		}
+
+		cursor := b.Bucket(entriesBucket).Cursor()
+		for k, v := cursor.Seek(offsetKey(from)); k != nil && len(entries) < limit; k, v = cursor.Next() {
+			batch, err := unmarshal[*cluster.PubSubBatchTransport](v)
+			if err != nil {
+				return err
+			}
+			entries = append(entries, &cluster.TopicLogEntry{Offset: binary.BigEndian.Uint64(k), Batch: batch})
+		}
+
+		return nil
+	})
+
+	return entries, err
+}
+
+func (s *BoltTopicLogStore) LastOffset(_ context.Context, topic string) (uint64, error) {
+	var offset uint64
+	err := s.db.View(func(tx *bolt.Tx) error {
+		if b, _ := s.topic(tx, topic); b != nil {
+			offset = b.Bucket(entriesBucket).Sequence()
+		}
+		return nil
+	})
+
+	return offset, err
+}
+
+func (s *BoltTopicLogStore) Truncate(_ context.Context, topic string, before uint64) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b, err := s.topic(tx, topic)
+		if err != nil {
+			return err
+		}
+
+		// the keys are collected first, deleting with the cursor while iterating skips entries
+		entries := b.Bucket(entriesBucket)
+		var truncated [][]byte
+		cursor := entries.Cursor()
+		for k, _ := cursor.First(); k != nil && binary.BigEndian.Uint64(k) < before; k, _ = cursor.Next() {
+			truncated = append(truncated, append([]byte(nil), k...))
+		}
+
+		for _, k := range truncated {
+			if err := entries.Delete(k); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *BoltTopicLogStore) Offsets(_ context.Context, topic string) ([]*cluster.TopicOffset, error) {
+	offsets := make([]*cluster.TopicOffset, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b, _ := s.topic(tx, topic)
+		if b == nil {
+			return nil
+		}
+
+		return b.Bucket(offsetsBucket).ForEach(func(_, v []byte) error {
+			offset, err := unmarshal[*cluster.TopicOffset](v)
+			if err != nil {
+				return err
+			}
+			offsets = append(offsets, offset)
+			return nil
+		})
+	})
+
+	return offsets, err
+}
+
+func (s *BoltTopicLogStore) Commit(_ context.Context, topic string, offset *cluster.TopicOffset) error {
+	data, err := proto.Marshal(offset)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b, err := s.topic(tx, topic)
+		if err != nil {
+			return err
+		}
+
+		return b.Bucket(offsetsBucket).Put(subscriberKey(offset.Subscriber), data)
+	})
+}
+
+func (s *BoltTopicLogStore) RemoveOffset(_ context.Context, topic string, subscriber *cluster.SubscriberIdentity) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b, err := s.topic(tx, topic)
+		if err != nil {
+			return err
+		}
+
+		return b.Bucket(offsetsBucket).Delete(subscriberKey(subscriber))
+	})
+}
+
+// Close closes the database file
+func (s *BoltTopicLogStore) Close() error {
+	return s.db.Close()
+}
+
+// offsetKey encodes the offset so the keys sort in the order of the log
+func offsetKey(offset uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, offset)
+
+	return key
+}
+
+// subscriberKey identifies the subscriber by the address and id of its PID, or by the kind and identity of its grain
+func subscriberKey(subscriber *cluster.SubscriberIdentity) []byte {
+	if pid := subscriber.GetPid(); pid != nil {
+		return []byte("pid/" + pid.Address + "/" + pid.Id)
+	}
+
+	ci := subscriber.GetClusterIdentity()
+	return []byte("grain/" + ci.Kind + "/" + ci.Identity)
+}
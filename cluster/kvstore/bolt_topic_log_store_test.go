@@ -0,0 +1,111 @@
+package kvstore
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/cluster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoltTopicLogStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewBoltTopicLogStore(filepath.Join(t.TempDir(), "log.db"), "topics")
+	require.NoError(t, err)
+	defer store.Close()
+
+	for i := 0; i < 5; i++ {
+		offset, err := store.Append(ctx, "topic", &cluster.PubSubBatchTransport{TypeNames: []string{"type"}})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(i+1), offset)
+	}
+
+	last, err := store.LastOffset(ctx, "other")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), last)
+
+	entries, err := store.Read(ctx, "topic", 2, 2)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, uint64(2), entries[0].Offset)
+	assert.Equal(t, uint64(3), entries[1].Offset)
+	assert.Equal(t, []string{"type"}, entries[0].Batch.TypeNames)
+
+	// the offsets continue after the log is truncated
+	require.NoError(t, store.Truncate(ctx, "topic", 4))
+	entries, err = store.Read(ctx, "topic", 1, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, uint64(4), entries[0].Offset)
+	assert.Equal(t, uint64(5), entries[1].Offset)
+
+	offset, err := store.Append(ctx, "topic", &cluster.PubSubBatchTransport{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(6), offset)
+}
+
+func TestBoltTopicLogStoreOffsets(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewBoltTopicLogStore(filepath.Join(t.TempDir(), "log.db"), "topics")
+	require.NoError(t, err)
+	defer store.Close()
+
+	pid := &cluster.SubscriberIdentity{Identity: &cluster.SubscriberIdentity_Pid{Pid: actor.NewPID("address", "id")}}
+	grain := &cluster.SubscriberIdentity{Identity: &cluster.SubscriberIdentity_ClusterIdentity{ClusterIdentity: cluster.NewClusterIdentity("identity", "kind")}}
+
+	require.NoError(t, store.Commit(ctx, "topic", &cluster.TopicOffset{Subscriber: pid, Offset: 1}))
+	require.NoError(t, store.Commit(ctx, "topic", &cluster.TopicOffset{Subscriber: grain, Offset: 2}))
+	require.NoError(t, store.Commit(ctx, "topic", &cluster.TopicOffset{Subscriber: grain, Offset: 3}))
+
+	offsets, err := store.Offsets(ctx, "topic")
+	require.NoError(t, err)
+	require.Len(t, offsets, 2)
+
+	require.NoError(t, store.RemoveOffset(ctx, "topic", pid))
+	offsets, err = store.Offsets(ctx, "topic")
+	require.NoError(t, err)
+	require.Len(t, offsets, 1)
+	assert.Equal(t, uint64(3), offsets[0].Offset)
+	assert.Equal(t, "identity", offsets[0].Subscriber.GetClusterIdentity().Identity)
+
+	offsets, err = store.Offsets(ctx, "other")
+	require.NoError(t, err)
+	assert.Empty(t, offsets)
+}
+
+func TestBoltTopicLogStoreKeepsLogsAcrossRestarts(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "log.db")
+	grain := &cluster.SubscriberIdentity{Identity: &cluster.SubscriberIdentity_ClusterIdentity{ClusterIdentity: cluster.NewClusterIdentity("identity", "kind")}}
+
+	store, err := NewBoltTopicLogStore(path, "topics")
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		_, err := store.Append(ctx, "topic", &cluster.PubSubBatchTransport{})
+		require.NoError(t, err)
+	}
+	require.NoError(t, store.Commit(ctx, "topic", &cluster.TopicOffset{Subscriber: grain, Offset: 2}))
+	require.NoError(t, store.Truncate(ctx, "topic", 3))
+	require.NoError(t, store.Close())
+
+	store, err = NewBoltTopicLogStore(path, "topics")
+	require.NoError(t, err)
+	defer store.Close()
+
+	last, err := store.LastOffset(ctx, "topic")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), last)
+
+	entries, err := store.Read(ctx, "topic", 1, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, uint64(3), entries[0].Offset)
+
+	offsets, err := store.Offsets(ctx, "topic")
+	require.NoError(t, err)
+	require.Len(t, offsets, 1)
+	assert.Equal(t, uint64(2), offsets[0].Offset)
+}
@@ -0,0 +1,110 @@
+package kvstore
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/asynkron/protoactor-go/cluster"
+	"golang.org/x/net/context"
+	"google.golang.org/protobuf/proto"
+)
+
+// SQLStore is a cluster.KeyValueStore persisted in a table of a SQL database, shared by all members.
+// The store works with any database/sql driver, the table is created beforehand with a key column and a binary
+// value column, such as
+//
+//	CREATE TABLE pubsub_subscriptions (store_key VARCHAR(255) PRIMARY KEY, store_value BLOB NOT NULL)
+//
+// with BYTEA instead of BLOB on PostgreSQL.
+type SQLStore[T proto.Message] struct {
+	db          *sql.DB
+	table       string
+	placeholder func(n int) string
+}
+
+var _ cluster.KeyValueStore[*cluster.Subscribers] = (*SQLStore[*cluster.Subscribers])(nil)
+
+// SQLStoreOption configures a SQLStore
+type SQLStoreOption func(*sqlStoreConfig)
+
+type sqlStoreConfig struct {
+	placeholder func(n int) string
+}
+
+// WithPlaceholder sets the placeholder of the nth parameter of the statements, starting at 1.
+// Default is "?" as used by MySQL and SQLite, see DollarPlaceholder for PostgreSQL.
+func WithPlaceholder(placeholder func(n int) string) SQLStoreOption {
+	return func(c *sqlStoreConfig) {
+		c.placeholder = placeholder
+	}
+}
+
+// QuestionPlaceholder is the placeholder of MySQL and SQLite
+func QuestionPlaceholder(int) string { return "?" }
+
+// DollarPlaceholder is the placeholder of PostgreSQL
+func DollarPlaceholder(n int) string { return fmt.Sprintf("$%d", n) }
+
+var tableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+
+// NewSQLStore creates a store keeping the values in the table of the database
+func NewSQLStore[T proto.Message](db *sql.DB, table string, opts ...SQLStoreOption) (*SQLStore[T], error) {
+	if !tableName.MatchString(table) {
+		return nil, fmt.Errorf("invalid table name %q", table)
+	}
+
+	config := &sqlStoreConfig{placeholder: QuestionPlaceholder}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	return &SQLStore[T]{db: db, table: table, placeholder: config.placeholder}, nil
+}
+
+// Set replaces the value of the key, it deletes and inserts the row in a transaction since the upsert
+// statements differ between databases
+func (s *SQLStore[T]) Set(ctx context.Context, key string, value T) error {
+	data, err := proto.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE store_key = %s", s.table, s.placeholder(1)), key); err != nil {
+		return err
+	}
+	insert := fmt.Sprintf("INSERT INTO %s (store_key, store_value) VALUES (%s, %s)", s.table, s.placeholder(1), s.placeholder(2))
+	if _, err := tx.ExecContext(ctx, insert, key, data); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *SQLStore[T]) Get(ctx context.Context, key string) (T, error) {
+	var (
+		none T
+		data []byte
+	)
+	row := s.db.QueryRowContext(ctx, fmt.Sprintf("SELECT store_value FROM %s WHERE store_key = %s", s.table, s.placeholder(1)), key)
+	if err := row.Scan(&data); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return none, nil
+		}
+		return none, err
+	}
+
+	return unmarshal[T](data)
+}
+
+func (s *SQLStore[T]) Clear(ctx context.Context, key string) error {
+	_, err := s.db.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE store_key = %s", s.table, s.placeholder(1)), key)
+	return err
+}
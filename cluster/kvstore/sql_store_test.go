@@ -0,0 +1,122 @@
+package kvstore
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/asynkron/protoactor-go/cluster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+// fakeDatabase is a database/sql driver keeping the rows of the statements of SQLStore in a map
+type fakeDatabase struct {
+	mu         sync.Mutex
+	rows       map[string][]byte
+	statements []string
+}
+
+func (d *fakeDatabase) Connect(context.Context) (driver.Conn, error) { return &fakeConn{db: d}, nil }
+func (d *fakeDatabase) Driver() driver.Driver                        { return nil }
+
+type fakeConn struct{ db *fakeDatabase }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) { return &fakeStmt{db: c.db, query: query}, nil }
+func (c *fakeConn) Close() error                              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)                 { return c, nil }
+func (c *fakeConn) Commit() error                             { return nil }
+func (c *fakeConn) Rollback() error                           { return nil }
+
+type fakeStmt struct {
+	db    *fakeDatabase
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	s.db.statements = append(s.db.statements, s.query)
+	switch {
+	case strings.HasPrefix(s.query, "DELETE FROM"):
+		delete(s.db.rows, args[0].(string))
+	case strings.HasPrefix(s.query, "INSERT INTO"):
+		s.db.rows[args[0].(string)] = args[1].([]byte)
+	default:
+		return nil, fmt.Errorf("unexpected statement %s", s.query)
+	}
+
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	s.db.statements = append(s.db.statements, s.query)
+	if !strings.HasPrefix(s.query, "SELECT store_value FROM") {
+		return nil, fmt.Errorf("unexpected query %s", s.query)
+	}
+
+	value, ok := s.db.rows[args[0].(string)]
+	return &fakeRows{value: value, done: !ok}, nil
+}
+
+type fakeRows struct {
+	value []byte
+	done  bool
+}
+
+func (r *fakeRows) Columns() []string { return []string{"store_value"} }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = r.value
+	return nil
+}
+
+func TestSQLStore(t *testing.T) {
+	database := &fakeDatabase{rows: make(map[string][]byte)}
+	db := sql.OpenDB(database)
+	defer db.Close()
+
+	store, err := NewSQLStore[*cluster.Subscribers](db, "pubsub_subscriptions")
+	require.NoError(t, err)
+
+	testStore(t, store)
+	assert.Contains(t, database.statements, "SELECT store_value FROM pubsub_subscriptions WHERE store_key = ?")
+	assert.Contains(t, database.statements, "INSERT INTO pubsub_subscriptions (store_key, store_value) VALUES (?, ?)")
+}
+
+func TestSQLStorePlaceholders(t *testing.T) {
+	database := &fakeDatabase{rows: make(map[string][]byte)}
+	db := sql.OpenDB(database)
+	defer db.Close()
+
+	store, err := NewSQLStore[*cluster.Subscribers](db, "subscriptions", WithPlaceholder(DollarPlaceholder))
+	require.NoError(t, err)
+	require.NoError(t, store.Set(context.Background(), "topic", testSubscribers("a")))
+
+	assert.Equal(t, []string{
+		"DELETE FROM subscriptions WHERE store_key = $1",
+		"INSERT INTO subscriptions (store_key, store_value) VALUES ($1, $2)",
+	}, database.statements)
+}
+
+func TestSQLStoreRejectsInvalidTableNames(t *testing.T) {
+	_, err := NewSQLStore[*cluster.Subscribers](nil, "subscriptions; DROP TABLE users")
+	assert.Error(t, err)
+}
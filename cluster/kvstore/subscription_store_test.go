@@ -0,0 +1,56 @@
+package kvstore
+
+import (
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/cluster"
+	"github.com/asynkron/protoactor-go/cluster/cluster_test_tool"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+const subscriberKind = "kvstore-subscriber"
+
+func TestSubscriptionsSurviveClusterRestart(t *testing.T) {
+	const topic = "persisted-topic"
+	store, err := NewBoltStore[*cluster.Subscribers](filepath.Join(t.TempDir(), "kv.db"), "subscriptions")
+	require.NoError(t, err)
+	defer store.Close()
+
+	var received atomic.Int32
+	newFixture := func() *cluster_test_tool.BaseClusterFixture {
+		fixture := cluster_test_tool.NewBaseInMemoryClusterFixture(1,
+			cluster_test_tool.WithGetClusterKinds(func() []*cluster.Kind {
+				return []*cluster.Kind{cluster.NewKind(subscriberKind, actor.PropsFromFunc(func(ctx actor.Context) {
+					if _, ok := ctx.Message().(*cluster_test_tool.DataPublished); ok {
+						received.Add(1)
+					}
+				}))}
+			}),
+			cluster_test_tool.WithClusterConfigure(func(c *cluster.Config) *cluster.Config {
+				cluster.WithPubSubSubscriptionStore(store)(c)
+				return c
+			}),
+		)
+		fixture.Initialize()
+		return fixture
+	}
+
+	fixture := newFixture()
+	_, err = fixture.GetMembers()[0].SubscribeByClusterIdentity(topic, cluster.NewClusterIdentity("subscriber", subscriberKind))
+	require.NoError(t, err)
+	fixture.ShutDown()
+
+	// the topic actor of the new cluster restores the subscription from the store
+	fixture = newFixture()
+	defer fixture.ShutDown()
+	_, err = fixture.GetMembers()[0].Publisher().Publish(context.Background(), topic, &cluster_test_tool.DataPublished{Data: 1})
+	require.NoError(t, err)
+
+	cluster_test_tool.WaitUntil(t, func() bool {
+		return received.Load() == 1
+	}, "the subscriber did not receive the message after the restart", cluster_test_tool.DefaultWaitTimeout)
+}
@@ -0,0 +1,286 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/eventstream"
+	"github.com/asynkron/protoactor-go/scheduler"
+	murmur32 "github.com/twmb/murmur3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const LeaderElectionActorName = "leader-election"
+
+// LeaderSelector selects a new leader when there is no leader or the leader left the cluster
+type LeaderSelector func(members Members) *Member
+
+// LowestIDLeaderSelector selects the member with the lowest id
+func LowestIDLeaderSelector(members Members) *Member {
+	var leader *Member
+	for _, member := range members {
+		if leader == nil || member.Id < leader.Id {
+			leader = member
+		}
+	}
+
+	return leader
+}
+
+// LeaderChanged is published on the EventStream when the members agreed on a new leader
+type LeaderChanged struct {
+	Leader *Member
+	// Token is the fencing token of the leadership, it increases with every new leadership
+	Token uint64
+	// IsLeader is true on the member which became the leader
+	IsLeader bool
+}
+
+// LeaderElection elects a leader from the gossip state of the members.
+// Every member gossips a vote for the leader and the term of its leadership. Members keep voting for the
+// current leader while it is in the cluster, otherwise they vote for the member chosen by the LeaderSelector
+// in the next term. A leader is elected once all members reached consensus on their votes.
+//
+// The term is used as fencing token: a leader passes its Token along with its actions and receivers
+// reject tokens which fail ValidateToken, so a leader cannot act after it lost its leadership.
+type LeaderElection struct {
+	cluster   *Cluster
+	pid       *actor.PID
+	sub       *eventstream.Subscription
+	consensus ConsensusHandler
+
+	mu     sync.RWMutex
+	leader *Member
+	term   uint64
+	vote   *LeaderVote
+}
+
+func newLeaderElection(c *Cluster) *LeaderElection {
+	return &LeaderElection{cluster: c}
+}
+
+// Start the leader election of the member
+func (le *LeaderElection) Start() {
+	system := le.cluster.ActorSystem
+	le.consensus = le.cluster.Gossip.RegisterConsensusCheck(LeaderKey, func(any *anypb.Any) interface{} {
+		vote := &LeaderVote{}
+		if err := any.UnmarshalTo(vote); err != nil {
+			le.cluster.Logger().Error("could not unpack leader vote", slog.Any("error", err))
+			return nil
+		}
+		return voteKey(vote)
+	})
+
+	props := actor.PropsFromProducer(func() actor.Actor { return &leaderElectionActor{election: le, votes: map[string]*LeaderVote{}} })
+
+	var err error
+	le.pid, err = system.Root.SpawnNamed(props, LeaderElectionActorName)
+	if err != nil {
+		panic(err) // let it crash
+	}
+
+	le.sub = system.EventStream.Subscribe(func(evt interface{}) {
+		switch msg := evt.(type) {
+		case *ClusterTopology:
+			system.Root.Send(le.pid, msg)
+		case *GossipUpdate:
+			if msg.Key == LeaderKey {
+				system.Root.Send(le.pid, msg)
+			}
+		}
+	})
+	le.cluster.Logger().Info("Started Cluster LeaderElection")
+}
+
+// Leader returns the elected leader and the fencing token of its leadership, the leader is nil until one is elected
+func (le *LeaderElection) Leader() (*Member, uint64) {
+	le.mu.RLock()
+	defer le.mu.RUnlock()
+
+	return le.leader, le.term
+}
+
+// IsLeader returns true if this member is the elected leader and did not vote for another leader since
+func (le *LeaderElection) IsLeader() bool {
+	le.mu.RLock()
+	defer le.mu.RUnlock()
+
+	return le.isLeader()
+}
+
+// Token returns the fencing token of this member's leadership, ok is false if this member is not the leader
+func (le *LeaderElection) Token() (token uint64, ok bool) {
+	le.mu.RLock()
+	defer le.mu.RUnlock()
+
+	if !le.isLeader() {
+		return 0, false
+	}
+
+	return le.term, true
+}
+
+// ValidateToken returns true if token is the fencing token of the current leadership
+func (le *LeaderElection) ValidateToken(token uint64) bool {
+	le.mu.RLock()
+	defer le.mu.RUnlock()
+
+	if le.leader == nil || token != le.term {
+		return false
+	}
+
+	// a newer term is pending
+	return le.vote == nil || le.vote.Term == le.term
+}
+
+func (le *LeaderElection) isLeader() bool {
+	if le.leader == nil || le.vote == nil {
+		return false
+	}
+
+	return le.leader.Id == le.cluster.ActorSystem.ID && le.vote.LeaderId == le.leader.Id && le.vote.Term == le.term
+}
+
+func (le *LeaderElection) setVote(vote *LeaderVote) {
+	le.mu.Lock()
+	defer le.mu.Unlock()
+
+	le.vote = vote
+}
+
+// setLeader updates the elected leader and returns false if it did not change
+func (le *LeaderElection) setLeader(leader *Member, term uint64) bool {
+	le.mu.Lock()
+	defer le.mu.Unlock()
+
+	if le.leader != nil && le.leader.Id == leader.Id && le.term == term {
+		return false
+	}
+
+	le.leader = leader
+	le.term = term
+
+	return true
+}
+
+type leaderElectionTick struct{}
+
+type leaderElectionActor struct {
+	election *LeaderElection
+	members  Members
+	votes    map[string]*LeaderVote
+	cancel   scheduler.CancelFunc
+}
+
+func (a *leaderElectionActor) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *actor.Started:
+		interval := a.election.cluster.Config.GossipInterval
+		a.cancel = scheduler.NewTimerScheduler(ctx).SendRepeatedly(interval, interval, ctx.Self(), &leaderElectionTick{})
+		a.members = a.election.cluster.MemberList.Members().Members()
+		a.updateVote()
+	case *actor.Stopping:
+		a.cancel()
+	case *ClusterTopology:
+		a.members = msg.Members
+		for _, member := range msg.Left {
+			delete(a.votes, member.Id)
+		}
+		a.updateVote()
+	case *GossipUpdate:
+		vote := &LeaderVote{}
+		if err := msg.Value.UnmarshalTo(vote); err != nil {
+			ctx.Logger().Warn("Could not unpack leader vote", slog.String("member", msg.MemberID), slog.Any("error", err))
+			return
+		}
+		a.votes[msg.MemberID] = vote
+		a.updateVote()
+	case *leaderElectionTick:
+		a.checkConsensus(ctx)
+	}
+}
+
+func (a *leaderElectionActor) updateVote() {
+	myID := a.election.cluster.ActorSystem.ID
+	vote := nextLeaderVote(a.votes, a.members, a.election.cluster.Config.LeaderSelector)
+	if vote == nil || proto.Equal(vote, a.votes[myID]) {
+		return
+	}
+
+	a.votes[myID] = vote
+	a.election.setVote(vote)
+	a.election.cluster.Gossip.SetState(LeaderKey, vote)
+}
+
+func (a *leaderElectionActor) checkConsensus(ctx actor.Context) {
+	vote := a.votes[a.election.cluster.ActorSystem.ID]
+	if vote == nil {
+		return
+	}
+
+	value, ok := a.election.consensus.TryGetConsensus(context.Background())
+	if !ok {
+		return
+	}
+
+	if hash, _ := value.(uint64); hash != voteKey(vote) {
+		return
+	}
+
+	leader := MembersToMap(a.members)[vote.LeaderId]
+	if leader == nil || !a.election.setLeader(leader, vote.Term) {
+		return
+	}
+
+	isLeader := a.election.IsLeader()
+	ctx.Logger().Info("Leader elected", slog.String("leader", leader.Address()), slog.Uint64("term", vote.Term), slog.Bool("isLeader", isLeader))
+	ctx.ActorSystem().EventStream.Publish(&LeaderChanged{
+		Leader:   leader,
+		Token:    vote.Term,
+		IsLeader: isLeader,
+	})
+}
+
+// nextLeaderVote keeps the vote for the leader of the highest term while it is a member,
+// otherwise it votes for the member chosen by the selector in the next term
+func nextLeaderVote(votes map[string]*LeaderVote, members Members, selector LeaderSelector) *LeaderVote {
+	memberIDs := MembersToMap(members)
+
+	var latest *LeaderVote
+	for memberID, vote := range votes {
+		if _, ok := memberIDs[memberID]; !ok {
+			continue
+		}
+
+		if latest == nil || vote.Term > latest.Term || (vote.Term == latest.Term && vote.LeaderId < latest.LeaderId) {
+			latest = vote
+		}
+	}
+
+	if latest != nil {
+		if _, ok := memberIDs[latest.LeaderId]; ok {
+			return &LeaderVote{LeaderId: latest.LeaderId, Term: latest.Term}
+		}
+	}
+
+	leader := selector(members)
+	if leader == nil {
+		return nil
+	}
+
+	term := uint64(1)
+	if latest != nil {
+		term = latest.Term + 1
+	}
+
+	return &LeaderVote{LeaderId: leader.Id, Term: term}
+}
+
+// voteKey hashes the vote into the uint64 value compared by the consensus check
+func voteKey(vote *LeaderVote) uint64 {
+	return murmur32.Sum64([]byte(fmt.Sprintf("%s/%d", vote.LeaderId, vote.Term)))
+}
@@ -0,0 +1,31 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextLeaderVote(t *testing.T) {
+	members := newMembersForTest(3)
+
+	// the first vote goes to the selected member
+	vote := nextLeaderVote(map[string]*LeaderVote{}, members, LowestIDLeaderSelector)
+	assert.Equal(t, &LeaderVote{LeaderId: members[0].Id, Term: 1}, vote)
+
+	// members keep voting for the leader of the latest term
+	votes := map[string]*LeaderVote{
+		members[0].Id: {LeaderId: members[0].Id, Term: 1},
+		members[1].Id: {LeaderId: members[2].Id, Term: 4},
+	}
+	vote = nextLeaderVote(votes, members, LowestIDLeaderSelector)
+	assert.Equal(t, &LeaderVote{LeaderId: members[2].Id, Term: 4}, vote)
+
+	// a new term starts when the leader left
+	vote = nextLeaderVote(votes, members[:2], LowestIDLeaderSelector)
+	assert.Equal(t, &LeaderVote{LeaderId: members[0].Id, Term: 5}, vote)
+
+	// votes of members which left are ignored
+	vote = nextLeaderVote(votes, Members{members[0], members[2]}, LowestIDLeaderSelector)
+	assert.Equal(t, &LeaderVote{LeaderId: members[0].Id, Term: 1}, vote)
+}
@@ -1,13 +1,57 @@
 package cluster
 
 import (
+	"errors"
+	"fmt"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
 
+	"github.com/asynkron/protoactor-go/remote"
 	murmur32 "github.com/twmb/murmur3"
 )
 
+// Well-known member labels, see WithMemberLabels
+const (
+	// RoleLabel is the role of the member in the cluster, e.g. "worker" or "frontend"
+	RoleLabel = "role"
+	// ZoneLabel is the availability zone or region of the member
+	ZoneLabel = "zone"
+	// VersionLabel is the version of the application running on the member
+	VersionLabel = "version"
+)
+
+// ErrInvalidLabel is the error of the member labels the cluster providers can not carry, see ValidateLabel
+var ErrInvalidLabel = errors.New("invalid member label")
+
+var (
+	labelNamePattern   = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`)
+	labelPrefixPattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+)
+
+// ValidateLabel returns an ErrInvalidLabel if the label does not follow the syntax of the Kubernetes labels, the
+// syntax every cluster provider carries. The key is a name with an optional DNS subdomain prefix, such as
+// "topology.kubernetes.io/zone", the name and the value are at most 63 letters, digits, '-', '_' or '.', starting
+// and ending with a letter or a digit. The value may be empty.
+func ValidateLabel(key, value string) error {
+	name := key
+	if prefix, suffix, hasPrefix := strings.Cut(key, "/"); hasPrefix {
+		if len(prefix) > 253 || !labelPrefixPattern.MatchString(prefix) {
+			return fmt.Errorf("%w: the prefix of the key %q must be a DNS subdomain", ErrInvalidLabel, key)
+		}
+		name = suffix
+	}
+	if len(name) > 63 || !labelNamePattern.MatchString(name) {
+		return fmt.Errorf("%w: the name of the key %q must be at most 63 letters, digits, '-', '_' or '.'", ErrInvalidLabel, key)
+	}
+	if len(value) > 63 || (value != "" && !labelNamePattern.MatchString(value)) {
+		return fmt.Errorf("%w: the value %q of the key %q must be at most 63 letters, digits, '-', '_' or '.'", ErrInvalidLabel, value, key)
+	}
+
+	return nil
+}
+
 type Members []*Member
 
 func (m *Members) ToSet() *MemberSet {
@@ -24,6 +68,21 @@ func (m *Member) HasKind(kind string) bool {
 	return false
 }
 
+// Label returns the value of the label of the member, or "" if it does not carry it
+func (m *Member) Label(key string) string {
+	return m.Labels[key]
+}
+
+// Role returns the value of the RoleLabel of the member
+func (m *Member) Role() string {
+	return m.Label(RoleLabel)
+}
+
+// HasLabels returns true if the member carries all the labels of the selector with the same values
+func (m *Member) HasLabels(selector map[string]string) bool {
+	return remote.HasLabels(m.Labels, selector)
+}
+
 // Address return a "host:port".
 // Member defined by protos.proto
 func (m *Member) Address() string {
@@ -19,6 +19,15 @@ type MemberList struct {
 	mutex                sync.RWMutex
 	members              *MemberSet
 	memberStrategyByKind map[string]MemberStrategy
+	// draining holds the ids of the members which do not accept new activations, see Cluster.Drain
+	draining map[string]empty
+	// providerMembers are the members last reported by the cluster provider, including the blocked members
+	providerMembers Members
+	gossipMu        sync.RWMutex
+	// gossipedLabels are the labels gossiped by the members, for the cluster providers which do not report them
+	gossipedLabels map[string]map[string]string
+	// actorCounts are the activation counts per kind gossiped in the heartbeats of the members
+	actorCounts map[string]map[string]int64
 
 	eventSteam        *eventstream.EventStream
 	topologyConsensus ConsensusHandler
@@ -29,11 +38,36 @@ func NewMemberList(cluster *Cluster) *MemberList {
 		cluster:              cluster,
 		members:              emptyMemberSet,
 		memberStrategyByKind: make(map[string]MemberStrategy),
+		draining:             make(map[string]empty),
+		gossipedLabels:       make(map[string]map[string]string),
+		actorCounts:          make(map[string]map[string]int64),
 		eventSteam:           cluster.ActorSystem.EventStream,
 	}
 	memberList.eventSteam.Subscribe(func(evt interface{}) {
 		switch t := evt.(type) {
 		case *GossipUpdate:
+			if t.Key == DrainingKey {
+				memberList.setDraining(t.MemberID)
+				break
+			}
+			if t.Key == LabelsKey {
+				var labels MemberLabels
+				if err := t.Value.UnmarshalTo(&labels); err != nil {
+					cluster.Logger().Warn("could not unpack member labels", slog.Any("error", err))
+					break
+				}
+				memberList.setGossipedLabels(t.MemberID, labels.Labels)
+				break
+			}
+			if t.Key == HearthbeatKey {
+				var heartbeat MemberHeartbeat
+				if err := t.Value.UnmarshalTo(&heartbeat); err != nil {
+					cluster.Logger().Warn("could not unpack heartbeat", slog.Any("error", err))
+					break
+				}
+				memberList.setActorCounts(t.MemberID, heartbeat.GetActorStatistics().GetActorCount())
+				break
+			}
 			if t.Key != "topology" {
 				break
 			}
@@ -48,6 +82,14 @@ func NewMemberList(cluster *Cluster) *MemberList {
 			}
 			blocked := topology.Blocked
 			memberList.cluster.Remote.BlockList().Block(blocked...)
+
+			// the members blocked by the other member leave the topology, e.g. the members downed by its split brain resolver
+			for _, id := range blocked {
+				if memberList.ContainsMemberID(id) {
+					memberList.refreshTopology()
+					break
+				}
+			}
 		}
 	})
 
@@ -111,12 +153,138 @@ func (ml *MemberList) GetActivatorMember(kind string, requestSourceAddress strin
 
 	var res string
 	if memberStrategy, ok := ml.memberStrategyByKind[kind]; ok {
-		res = memberStrategy.GetActivator(requestSourceAddress)
+		res = ml.activatorOf(memberStrategy, requestSourceAddress)
+	}
+
+	return res
+}
+
+// activatorOf returns the activator chosen by the strategy, draining members are skipped unless all members are draining
+func (ml *MemberList) activatorOf(strategy MemberStrategy, requestSourceAddress string) string {
+	res := strategy.GetActivator(requestSourceAddress)
+	if len(ml.draining) == 0 {
+		return res
+	}
+
+	members := strategy.GetAllMembers()
+	isDraining := func(address string) bool {
+		for _, m := range members {
+			if m.Address() == address {
+				_, ok := ml.draining[m.Id]
+				return ok
+			}
+		}
+		return false
+	}
+
+	for i := 0; i < len(members) && isDraining(res); i++ {
+		res = strategy.GetActivator(requestSourceAddress)
+	}
+	if !isDraining(res) {
+		return res
+	}
+
+	// the strategy keeps choosing draining members, fall back to any other member
+	for _, m := range members {
+		if _, ok := ml.draining[m.Id]; !ok {
+			return m.Address()
+		}
 	}
 
 	return res
 }
 
+// IsDraining returns true if the member is draining and does not accept new activations
+func (ml *MemberList) IsDraining(memberID string) bool {
+	ml.mutex.RLock()
+	defer ml.mutex.RUnlock()
+
+	_, ok := ml.draining[memberID]
+	return ok
+}
+
+func (ml *MemberList) setDraining(memberID string) {
+	ml.mutex.Lock()
+	defer ml.mutex.Unlock()
+
+	ml.draining[memberID] = empty{}
+}
+
+// Labels returns the labels of the member, the labels reported by the cluster provider merged with the labels
+// gossiped by the member. It returns nil if the member is not part of the topology.
+func (ml *MemberList) Labels(memberID string) map[string]string {
+	member := ml.members.GetMemberById(memberID)
+	if member == nil {
+		return nil
+	}
+
+	return ml.labelsOf(member)
+}
+
+// MembersWithLabels returns the members of the topology carrying all the labels of the selector,
+// e.g. {"role": "worker", "zone": "eu-1"}
+func (ml *MemberList) MembersWithLabels(selector map[string]string) Members {
+	var res Members
+	for _, member := range ml.members.Members() {
+		if remote.HasLabels(ml.labelsOf(member), selector) {
+			res = append(res, member)
+		}
+	}
+
+	return res
+}
+
+func (ml *MemberList) labelsOf(member *Member) map[string]string {
+	if member.Id == ml.cluster.ActorSystem.ID {
+		return ml.cluster.Labels()
+	}
+
+	ml.gossipMu.RLock()
+	defer ml.gossipMu.RUnlock()
+
+	gossiped := ml.gossipedLabels[member.Id]
+	labels := make(map[string]string, len(member.Labels)+len(gossiped))
+	for k, v := range member.Labels {
+		labels[k] = v
+	}
+	for k, v := range gossiped {
+		labels[k] = v
+	}
+
+	return labels
+}
+
+func (ml *MemberList) setGossipedLabels(memberID string, labels map[string]string) {
+	ml.gossipMu.Lock()
+	defer ml.gossipMu.Unlock()
+
+	ml.gossipedLabels[memberID] = labels
+}
+
+// activationCount returns the activations of the kind on the member, as gossiped in its last heartbeat
+func (ml *MemberList) activationCount(memberID, kind string) int64 {
+	ml.gossipMu.RLock()
+	defer ml.gossipMu.RUnlock()
+
+	return ml.actorCounts[memberID][kind]
+}
+
+func (ml *MemberList) setActorCounts(memberID string, counts map[string]int64) {
+	ml.gossipMu.Lock()
+	defer ml.gossipMu.Unlock()
+
+	ml.actorCounts[memberID] = counts
+}
+
+// forgetGossipedState drops the state gossiped by a member which left
+func (ml *MemberList) forgetGossipedState(memberID string) {
+	ml.gossipMu.Lock()
+	defer ml.gossipMu.Unlock()
+
+	delete(ml.gossipedLabels, memberID)
+	delete(ml.actorCounts, memberID)
+}
+
 func (ml *MemberList) Length() int {
 	return ml.members.Len()
 }
@@ -134,6 +302,7 @@ func (ml *MemberList) UpdateClusterTopology(members Members) {
 	// then makes a delta between new and old members
 	// notifying the cluster accordingly which members left or joined
 
+	ml.providerMembers = members
 	topology, done, active, joined, left := ml.getTopologyChanges(members)
 	if done {
 		return
@@ -150,6 +319,8 @@ func (ml *MemberList) UpdateClusterTopology(members Members) {
 	for _, m := range left.Members() {
 		ml.memberLeave(m)
 		ml.TerminateMember(m)
+		delete(ml.draining, m.Id)
+		ml.forgetGossipedState(m.Id)
 	}
 
 	// notify that these members joined
@@ -168,6 +339,16 @@ func (ml *MemberList) UpdateClusterTopology(members Members) {
 		slog.Int("membersFromProvider", len(members)))
 }
 
+// refreshTopology updates the topology with the members last reported by the cluster provider, so the members
+// blocked since leave the topology
+func (ml *MemberList) refreshTopology() {
+	ml.mutex.RLock()
+	members := ml.providerMembers
+	ml.mutex.RUnlock()
+
+	ml.UpdateClusterTopology(members)
+}
+
 func (ml *MemberList) memberJoin(joiningMember *Member) {
 	ml.cluster.Logger().Info("member joined", slog.String("member", joiningMember.Id))
 
@@ -212,6 +393,7 @@ func (ml *MemberList) getTopologyChanges(members Members) (topology *ClusterTopo
 		Members:      active.Members(),
 		Left:         left.Members(),
 		Joined:       joined.Members(),
+		Blocked:      blocked,
 	}
 
 	return topology, false, active, joined, left
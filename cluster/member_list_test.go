@@ -284,3 +284,61 @@ func TestMemberList_newMemberStrategies(t *testing.T) {
 		a.Equal(v, len(obj.memberStrategyByKind["kind2"].GetAllMembers()))
 	}
 }
+
+func TestMemberList_GetActivatorMemberSkipsDrainingMembers(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	c := newClusterForTest("test-memberlist", nil)
+	obj := NewMemberList(c)
+	members := newMembersForTest(3, "kind")
+	obj.UpdateClusterTopology(members)
+
+	obj.setDraining(members[0].Id)
+	for i := 0; i < 10; i++ {
+		a.NotEqual(members[0].Address(), obj.GetActivatorMember("kind", ""))
+	}
+
+	// draining members are still chosen when all members are draining
+	obj.setDraining(members[1].Id)
+	obj.setDraining(members[2].Id)
+	a.NotEmpty(obj.GetActivatorMember("kind", ""))
+}
+
+func TestMemberList_Labels(t *testing.T) {
+	a := assert.New(t)
+
+	c := newClusterForTest("test-memberlist", nil, WithMemberLabels(map[string]string{RoleLabel: "frontend"}))
+	obj := c.MemberList
+	members := newMembersForTest(3, "kind")
+	members[0].Labels = map[string]string{RoleLabel: "worker", ZoneLabel: "eu-1"}
+	members[1].Labels = map[string]string{RoleLabel: "worker", ZoneLabel: "us-1"}
+	self := &Member{Id: c.ActorSystem.ID, Host: "127.0.0.1", Port: 10, Kinds: []string{"kind"}}
+	obj.UpdateClusterTopology(append(members, self))
+
+	a.Equal("worker", members[0].Role())
+	a.True(members[0].HasLabels(map[string]string{ZoneLabel: "eu-1"}))
+	a.False(members[1].HasLabels(map[string]string{ZoneLabel: "eu-1"}))
+
+	// the labels are reported by the cluster provider, or gossiped when it does not report them
+	a.Equal(map[string]string{RoleLabel: "worker", ZoneLabel: "eu-1"}, obj.Labels(members[0].Id))
+	a.Empty(obj.Labels(members[2].Id))
+	publishGossip(t, c, members[2].Id, LabelsKey, &MemberLabels{Labels: map[string]string{RoleLabel: "worker", ZoneLabel: "eu-1"}})
+	a.Equal(map[string]string{RoleLabel: "worker", ZoneLabel: "eu-1"}, obj.Labels(members[2].Id))
+	a.Equal(map[string]string{RoleLabel: "frontend"}, obj.Labels(self.Id))
+	a.Nil(obj.Labels("unknown"))
+
+	a.ElementsMatch(Members{members[0], members[2]}, obj.MembersWithLabels(map[string]string{RoleLabel: "worker", ZoneLabel: "eu-1"}))
+	a.ElementsMatch(Members{members[0], members[1], members[2]}, obj.MembersWithLabels(map[string]string{RoleLabel: "worker"}))
+	a.ElementsMatch(Members{self}, obj.MembersWithLabels(map[string]string{RoleLabel: "frontend"}))
+	a.Len(obj.MembersWithLabels(nil), 4)
+
+	// the gossiped labels and actor counts of the members leaving the topology are forgotten
+	publishGossip(t, c, members[2].Id, HearthbeatKey, &MemberHeartbeat{
+		ActorStatistics: &ActorStatistics{ActorCount: map[string]int64{"kind": 3}},
+	})
+	a.Equal(int64(3), obj.activationCount(members[2].Id, "kind"))
+	obj.UpdateClusterTopology(Members{members[0], members[1], self})
+	a.NotContains(obj.gossipedLabels, members[2].Id)
+	a.NotContains(obj.actorCounts, members[2].Id)
+}
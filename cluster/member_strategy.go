@@ -8,6 +8,12 @@ type MemberStrategy interface {
 	GetActivator(senderAddress string) string
 }
 
+// kindMemberStrategy is a strategy which depends on the kind it places, Kind.Build sets the kind
+type kindMemberStrategy interface {
+	MemberStrategy
+	setKind(kind string)
+}
+
 type simpleMemberStrategy struct {
 	members Members
 	rr      *SimpleRoundRobin
@@ -15,6 +21,10 @@ type simpleMemberStrategy struct {
 }
 
 func newDefaultMemberStrategy(cluster *Cluster, kind string) MemberStrategy {
+	return newSimpleMemberStrategy()
+}
+
+func newSimpleMemberStrategy() *simpleMemberStrategy {
 	ms := &simpleMemberStrategy{members: make(Members, 0)}
 	ms.rr = NewSimpleRoundRobin(MemberStrategy(ms))
 	ms.rdv = NewRendezvous()
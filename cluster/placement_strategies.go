@@ -0,0 +1,148 @@
+package cluster
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/asynkron/protoactor-go/remote"
+)
+
+// LocalAffinityStrategy activates grains on the member of the requester when it hosts the kind,
+// requests from other members are spread round-robin
+func LocalAffinityStrategy() func(*Cluster) MemberStrategy {
+	return func(c *Cluster) MemberStrategy {
+		return &localAffinityStrategy{simpleMemberStrategy: newSimpleMemberStrategy()}
+	}
+}
+
+type localAffinityStrategy struct {
+	*simpleMemberStrategy
+}
+
+func (m *localAffinityStrategy) GetActivator(senderAddress string) string {
+	for _, member := range m.members {
+		if member.Address() == senderAddress {
+			return senderAddress
+		}
+	}
+
+	return m.rr.GetByRoundRobin()
+}
+
+// LeastLoadedStrategy activates grains of the kind on the member hosting the fewest activations of it.
+// The activation counts of the other members are taken from their gossiped heartbeats.
+func LeastLoadedStrategy() func(*Cluster) MemberStrategy {
+	return func(c *Cluster) MemberStrategy {
+		return &leastLoadedStrategy{
+			simpleMemberStrategy: newSimpleMemberStrategy(),
+			cluster:              c,
+			assigned:             make(map[string]int64),
+			gossiped:             make(map[string]int64),
+		}
+	}
+}
+
+type leastLoadedStrategy struct {
+	*simpleMemberStrategy
+	cluster *Cluster
+	// kind is set by Kind.Build
+	kind string
+
+	mu sync.Mutex
+	// assigned counts the activations placed on a member since its last gossiped count changed,
+	// it avoids placing all activations on the same member between two heartbeats
+	assigned map[string]int64
+	gossiped map[string]int64
+}
+
+func (m *leastLoadedStrategy) setKind(kind string) {
+	m.kind = kind
+}
+
+func (m *leastLoadedStrategy) RemoveMember(member *Member) {
+	m.simpleMemberStrategy.RemoveMember(member)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.assigned, member.Id)
+	delete(m.gossiped, member.Id)
+}
+
+func (m *leastLoadedStrategy) GetActivator(_ string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var (
+		selected *Member
+		minCount int64
+	)
+	for _, member := range m.members {
+		count := m.activationCount(member)
+		if selected == nil || count < minCount {
+			selected = member
+			minCount = count
+		}
+	}
+
+	if selected == nil {
+		return ""
+	}
+
+	m.assigned[selected.Id]++
+	return selected.Address()
+}
+
+func (m *leastLoadedStrategy) activationCount(member *Member) int64 {
+	var count int64
+	if member.Id == m.cluster.ActorSystem.ID {
+		if kind, ok := m.cluster.TryGetClusterKind(m.kind); ok {
+			count = int64(kind.Count())
+		}
+	} else {
+		count = m.cluster.MemberList.activationCount(member.Id, m.kind)
+	}
+
+	if m.gossiped[member.Id] != count {
+		m.gossiped[member.Id] = count
+		m.assigned[member.Id] = 0
+	}
+
+	return count + m.assigned[member.Id]
+}
+
+// LabelStrategy only activates grains on members carrying all the given labels, the
+// matching members are used round-robin. Members advertise their labels through
+// WithMemberLabels, see MemberList.Labels.
+func LabelStrategy(labels map[string]string) func(*Cluster) MemberStrategy {
+	return func(c *Cluster) MemberStrategy {
+		return &labelStrategy{
+			simpleMemberStrategy: newSimpleMemberStrategy(),
+			cluster:              c,
+			labels:               labels,
+		}
+	}
+}
+
+type labelStrategy struct {
+	*simpleMemberStrategy
+	cluster *Cluster
+	labels  map[string]string
+	val     int32
+}
+
+func (m *labelStrategy) GetActivator(_ string) string {
+	candidates := make(Members, 0, len(m.members))
+	for _, member := range m.members {
+		if remote.HasLabels(m.cluster.MemberList.labelsOf(member), m.labels) {
+			candidates = append(candidates, member)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	i := atomic.AddInt32(&m.val, 1)
+	return candidates[int(uint32(i))%len(candidates)].Address()
+}
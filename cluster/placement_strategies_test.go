@@ -0,0 +1,98 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func publishGossip(t *testing.T, c *Cluster, memberID, key string, value proto.Message) {
+	packed, err := anypb.New(value)
+	assert.NoError(t, err)
+	c.ActorSystem.EventStream.Publish(&GossipUpdate{MemberID: memberID, Key: key, Value: packed})
+}
+
+func newStrategyForTest(c *Cluster, builder func(*Cluster) MemberStrategy, members Members) MemberStrategy {
+	strategy := builder(c)
+	for _, m := range members {
+		strategy.AddMember(m)
+	}
+	return strategy
+}
+
+func TestLocalAffinityStrategy(t *testing.T) {
+	c := newClusterForTest("test-local-affinity", nil)
+	members := newMembersForTest(3)
+	strategy := newStrategyForTest(c, LocalAffinityStrategy(), members)
+
+	for _, m := range members {
+		assert.Equal(t, m.Address(), strategy.GetActivator(m.Address()))
+	}
+
+	// requests from clients are spread over the members
+	assert.Contains(t, addresses(members), strategy.GetActivator("client:1"))
+}
+
+func TestLeastLoadedStrategy(t *testing.T) {
+	c := newClusterForTest("test-least-loaded", nil)
+	members := newMembersForTest(3)
+	c.Config.Kinds["kind"] = NewKind("kind", actor.PropsFromFunc(func(ctx actor.Context) {}))
+	c.Config.Kinds["kind"].WithMemberStrategy(LeastLoadedStrategy())
+	strategy := c.Config.Kinds["kind"].Build(c).Strategy
+	for _, m := range members {
+		strategy.AddMember(m)
+	}
+	c.MemberList.UpdateClusterTopology(members)
+
+	counts := []int64{5, 1, 3}
+	for i, m := range members {
+		publishGossip(t, c, m.Id, HearthbeatKey, &MemberHeartbeat{
+			ActorStatistics: &ActorStatistics{ActorCount: map[string]int64{"kind": counts[i], "other": 0}},
+		})
+	}
+
+	placed := make(map[string]int)
+	for i := 0; i < 4; i++ {
+		placed[strategy.GetActivator("")]++
+	}
+	assert.Equal(t, 3, placed[members[1].Address()])
+	assert.Equal(t, 1, placed[members[2].Address()])
+
+	// a new heartbeat replaces the locally assigned activations
+	publishGossip(t, c, members[0].Id, HearthbeatKey, &MemberHeartbeat{
+		ActorStatistics: &ActorStatistics{ActorCount: map[string]int64{"kind": 0}},
+	})
+	assert.Equal(t, members[0].Address(), strategy.GetActivator(""))
+}
+
+func TestLabelStrategy(t *testing.T) {
+	c := newClusterForTest("test-labels", nil)
+	members := newMembersForTest(3)
+
+	eu := newStrategyForTest(c, LabelStrategy(map[string]string{"zone": "eu"}), members)
+	gpu := newStrategyForTest(c, LabelStrategy(map[string]string{"zone": "eu", "gpu": "true"}), members)
+	none := newStrategyForTest(c, LabelStrategy(map[string]string{"zone": "ap"}), members)
+
+	publishGossip(t, c, members[0].Id, LabelsKey, &MemberLabels{Labels: map[string]string{"zone": "eu", "gpu": "true"}})
+	publishGossip(t, c, members[1].Id, LabelsKey, &MemberLabels{Labels: map[string]string{"zone": "us"}})
+	publishGossip(t, c, members[2].Id, LabelsKey, &MemberLabels{Labels: map[string]string{"zone": "eu"}})
+
+	for i := 0; i < 10; i++ {
+		assert.Contains(t, []string{members[0].Address(), members[2].Address()}, eu.GetActivator(""))
+	}
+
+	assert.Equal(t, members[0].Address(), gpu.GetActivator(""))
+
+	assert.Equal(t, "", none.GetActivator(""))
+}
+
+func addresses(members Members) []string {
+	res := make([]string, 0, len(members))
+	for _, m := range members {
+		res = append(res, m.Address())
+	}
+	return res
+}
@@ -1,9 +1,12 @@
 package cluster
 
 import (
+	"context"
+	"sync"
 	"time"
 
 	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/eventstream"
 	"github.com/asynkron/protoactor-go/extensions"
 )
 
@@ -13,11 +16,23 @@ var pubsubExtensionID = extensions.NextExtensionID()
 
 type PubSub struct {
 	cluster *Cluster
+
+	mu             sync.Mutex
+	producers      map[*BatchingProducer]empty
+	hostedPatterns map[string]empty    // topic patterns with subscribers hosted by the member
+	memberPatterns map[string][]string // topic patterns with subscribers hosted by the other members
+	matcher        *TopicMatcher
+	sub            *eventstream.Subscription
+	delivery       *actor.PID
 }
 
 func NewPubSub(cluster *Cluster) *PubSub {
 	p := &PubSub{
-		cluster: cluster,
+		cluster:        cluster,
+		producers:      map[*BatchingProducer]empty{},
+		hostedPatterns: map[string]empty{},
+		memberPatterns: map[string][]string{},
+		matcher:        NewTopicMatcher(),
 	}
 	cluster.ActorSystem.Extensions.Register(p)
 	return p
@@ -28,13 +43,59 @@ func (p *PubSub) Start() {
 	props := actor.PropsFromProducer(func() actor.Actor {
 		return NewPubSubMemberDeliveryActor(p.cluster.Config.PubSubConfig.SubscriberTimeout, p.cluster.Logger())
 	})
-	_, err := p.cluster.ActorSystem.Root.SpawnNamed(props, PubSubDeliveryName)
+	var err error
+	p.delivery, err = p.cluster.ActorSystem.Root.SpawnNamed(props, PubSubDeliveryName)
 	if err != nil {
 		panic(err) // let it crash
 	}
+
+	p.sub = p.cluster.ActorSystem.EventStream.Subscribe(func(evt interface{}) {
+		switch msg := evt.(type) {
+		case *GossipUpdate:
+			if msg.Key == TopicPatternsKey {
+				p.onTopicPatternsGossiped(msg)
+			}
+		case *ClusterTopology:
+			if len(msg.Left) > 0 {
+				p.onMembersLeft(msg.Left)
+			}
+		}
+	})
 	p.cluster.Logger().Info("Started Cluster PubSub")
 }
 
+// trackProducer keeps the producer until it is disposed, so it is flushed when the member drains
+func (p *PubSub) trackProducer(producer *BatchingProducer) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.producers[producer] = empty{}
+	producer.onDispose = func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		delete(p.producers, producer)
+	}
+}
+
+// flushProducers waits until the producers of the cluster published their messages
+func (p *PubSub) flushProducers(ctx context.Context) error {
+	p.mu.Lock()
+	producers := make([]*BatchingProducer, 0, len(p.producers))
+	for producer := range p.producers {
+		producers = append(producers, producer)
+	}
+	p.mu.Unlock()
+
+	for _, producer := range producers {
+		if err := producer.Flush(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (p *PubSub) ExtensionID() extensions.ExtensionID {
 	return pubsubExtensionID
 }
@@ -45,11 +106,39 @@ type PubSubConfig struct {
 	// This value gets rounded to seconds for optimization of cancellation token creation. Note that internally,
 	// cluster request is used to deliver messages to ClusterIdentity subscribers.
 	SubscriberTimeout time.Duration
+
+	// SubscriptionStore persists the subscribers of the topics, so they are restored when a topic actor is activated
+	// again on any member. The subscriptions are lost with their topic actor when it is nil.
+	SubscriptionStore KeyValueStore[*Subscribers]
+
+	// LogStore persists the batches published to the durable topics and the offsets of their subscribers.
+	// Topics are not durable when it is nil.
+	LogStore TopicLogStore
+
+	// DurableTopic selects the durable topics, all topics are durable when it is nil and LogStore is set.
+	DurableTopic func(topic string) bool
+
+	// PartitionedTopics are the number of partitions of the partitioned topics, see PartitionIdentity.
+	PartitionedTopics map[string]int
+
+	// RedeliveryInterval is the delay before a batch of a durable topic is delivered again to a subscriber
+	// which failed to acknowledge it. Default is 1s.
+	RedeliveryInterval time.Duration
+
+	// DeliveryRetryPolicy returns the retry policy of the deliveries to a subscriber of a topic. The batches are
+	// not delivered again when it is nil or returns nil, except to the subscribers of durable topics which retry
+	// every RedeliveryInterval until they succeed.
+	DeliveryRetryPolicy func(topic string, subscriber *SubscriberIdentity) *RetryPolicy
+
+	// DeadLetterTopic receives a PubSubDeadLetter for each message which was not delivered to a subscriber after
+	// the retries of its policy. The messages are dropped when it is empty.
+	DeadLetterTopic string
 }
 
 func newPubSubConfig() *PubSubConfig {
 	return &PubSubConfig{
-		SubscriberTimeout: 5 * time.Second,
+		SubscriberTimeout:  5 * time.Second,
+		RedeliveryInterval: time.Second,
 	}
 }
 
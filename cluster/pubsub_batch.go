@@ -18,6 +18,37 @@ var (
 
 type PubSubBatch struct {
 	Envelopes []proto.Message
+	// OrderingKeys are the ordering keys of the envelopes at the same index, nil when the envelopes have no key.
+	// See PubSubConfig.PartitionedTopics.
+	OrderingKeys []string
+}
+
+// OrderingKey returns the ordering key of the envelope at the index, empty if it has none
+func (b *PubSubBatch) OrderingKey(i int) string {
+	if i < len(b.OrderingKeys) {
+		return b.OrderingKeys[i]
+	}
+
+	return ""
+}
+
+// add appends the envelope with its ordering key
+func (b *PubSubBatch) add(envelope proto.Message, orderingKey string) {
+	if orderingKey != "" && len(b.OrderingKeys) < len(b.Envelopes) {
+		b.OrderingKeys = append(b.OrderingKeys, make([]string, len(b.Envelopes)-len(b.OrderingKeys))...)
+	}
+	if orderingKey != "" || len(b.OrderingKeys) > 0 {
+		b.OrderingKeys = append(b.OrderingKeys, orderingKey)
+	}
+	b.Envelopes = append(b.Envelopes, envelope)
+}
+
+// remove removes the envelope at the index with its ordering key
+func (b *PubSubBatch) remove(i int) {
+	b.Envelopes = append(b.Envelopes[:i], b.Envelopes[i+1:]...)
+	if i < len(b.OrderingKeys) {
+		b.OrderingKeys = append(b.OrderingKeys[:i], b.OrderingKeys[i+1:]...)
+	}
 }
 
 // Serialize converts a PubSubBatch to a PubSubBatchTransport.
@@ -27,7 +58,7 @@ func (b *PubSubBatch) Serialize() (remote.RootSerialized, error) {
 		Envelopes: make([]*PubSubEnvelope, 0),
 	}
 
-	for _, envelope := range b.Envelopes {
+	for i, envelope := range b.Envelopes {
 		var serializerId int32
 		messageData, typeName, err := remote.Serialize(envelope, serializerId)
 		if err != nil {
@@ -49,6 +80,7 @@ func (b *PubSubBatch) Serialize() (remote.RootSerialized, error) {
 			MessageData:  messageData,
 			TypeId:       int32(typeIndex),
 			SerializerId: serializerId,
+			OrderingKey:  b.OrderingKey(i),
 		})
 	}
 	return batch, nil
@@ -70,7 +102,7 @@ func (t *PubSubBatchTransport) Deserialize() (remote.RootSerializable, error) {
 			panic("message is not proto.Message")
 		}
 
-		b.Envelopes = append(b.Envelopes, protoMessage)
+		b.add(protoMessage, envelope.OrderingKey)
 	}
 	return b, nil
 }
@@ -0,0 +1,164 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/remote"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// deliveryFailure is the last failed attempt of the delivery of a batch to a subscriber
+type deliveryFailure struct {
+	subscriber *SubscriberIdentity
+	status     DeliveryStatus
+	attempts   int
+	err        error
+}
+
+// deliveryStatus returns the status of a delivery which failed with the error
+func deliveryStatus(err error) DeliveryStatus {
+	switch err {
+	case nil:
+		return DeliveryStatus_Delivered
+	case actor.ErrTimeout, remote.ErrTimeout:
+		return DeliveryStatus_Timeout
+	case actor.ErrDeadLetter, remote.ErrDeadLetter:
+		return DeliveryStatus_SubscriberNoLongerReachable
+	default:
+		return DeliveryStatus_OtherError
+	}
+}
+
+// deliveryRetryPolicy returns the retry policy of the deliveries to the subscriber of the topic, nil if they are
+// not retried
+func (c *Cluster) deliveryRetryPolicy(topic string, subscriber *SubscriberIdentity) *RetryPolicy {
+	if c.Config.PubSubConfig.DeliveryRetryPolicy == nil {
+		return nil
+	}
+
+	return c.Config.PubSubConfig.DeliveryRetryPolicy(partitionedTopic(topic), subscriber)
+}
+
+// nextDeliveryAttempt returns the delay before the next attempt of a delivery which failed the attempts since it
+// started, false when the policy has no attempt left
+func nextDeliveryAttempt(policy *RetryPolicy, attempts int, started time.Time) (time.Duration, bool) {
+	if maxAttempts := policy.attemptsLimit(); maxAttempts > 0 && attempts >= maxAttempts {
+		return 0, false
+	}
+
+	backoff := policy.Backoff(attempts)
+	if policy.Deadline > 0 && time.Since(started)+backoff >= policy.Deadline {
+		return 0, false
+	}
+
+	return backoff, true
+}
+
+// publishDeadLetters publishes the envelopes which could not be delivered to the subscriber to the dead-letter
+// topic without blocking the actor, which continues with published once the dead-letter topic acknowledged them.
+// It does nothing when there is no dead-letter topic or the envelopes come from it.
+func (c *Cluster) publishDeadLetters(ctx actor.Context, topic string, envelopes []proto.Message, failure deliveryFailure, published func(err error)) {
+	deadLetterTopic := c.Config.PubSubConfig.DeadLetterTopic
+	topic = partitionedTopic(topic)
+	if deadLetterTopic == "" || topic == deadLetterTopic {
+		published(nil)
+		return
+	}
+
+	batch, err := deadLetterBatch(topic, envelopes, failure)
+	if err != nil {
+		published(err)
+		return
+	}
+
+	reenterAfterCall(ctx, func() (interface{}, error) {
+		return c.Publisher().PublishBatch(context.Background(), deadLetterTopic, batch)
+	}, func(res interface{}, err error) {
+		if err == nil && res.(*PublishResponse).Status != PublishStatus_Ok {
+			err = errors.New("the dead-letter topic failed to publish the messages")
+		}
+		if err == nil {
+			c.Logger().Warn("Pub-sub messages published to the dead-letter topic", slog.String("topic", topic), slog.Any("subscriber", failure.subscriber), slog.Int("messages", len(envelopes)))
+		}
+		published(err)
+	})
+}
+
+// deadLetterBatch wraps the envelopes which could not be delivered to the subscriber of the topic in dead letters
+func deadLetterBatch(topic string, envelopes []proto.Message, failure deliveryFailure) (*PubSubBatch, error) {
+
+	var errorMessage string
+	if failure.err != nil {
+		errorMessage = failure.err.Error()
+	}
+	failedAt := timestamppb.Now()
+
+	batch := &PubSubBatch{Envelopes: make([]proto.Message, 0, len(envelopes))}
+	for _, envelope := range envelopes {
+		message, err := anypb.New(envelope)
+		if err != nil {
+			return nil, err
+		}
+
+		batch.Envelopes = append(batch.Envelopes, &PubSubDeadLetter{
+			Topic:      topic,
+			Subscriber: failure.subscriber,
+			Message:    message,
+			Status:     failure.status,
+			Attempts:   int32(failure.attempts),
+			Error:      errorMessage,
+			FailedAt:   failedAt,
+		})
+	}
+
+	return batch, nil
+}
+
+// callResult is the result of a call made by reenterAfterCall
+type callResult struct {
+	res interface{}
+	err error
+}
+
+// reenterAfterCall makes the blocking call outside of the actor, which keeps processing its messages, and continues
+// with the result of the call in the actor. The call must time out by itself.
+func reenterAfterCall(ctx actor.Context, call func() (interface{}, error), cont func(res interface{}, err error)) {
+	system := ctx.ActorSystem()
+	future := actor.NewFuture(system, -1)
+	go func() {
+		res, err := call()
+		system.Root.Send(future.PID(), &callResult{res: res, err: err})
+	}()
+
+	ctx.ReenterAfter(future, func(res interface{}, _ error) {
+		result := res.(*callResult)
+		cont(result.res, result.err)
+	})
+}
+
+// ReplayDeadLetter delivers the message of the dead letter again to its subscriber, only. It returns an error
+// when the subscriber did not process it within the subscriber timeout.
+func (c *Cluster) ReplayDeadLetter(deadLetter *PubSubDeadLetter) error {
+	message, err := deadLetter.Message.UnmarshalNew()
+	if err != nil {
+		return err
+	}
+	batch := &PubSubAutoRespondBatch{Envelopes: []proto.Message{message}}
+
+	if pid := deadLetter.Subscriber.GetPid(); pid != nil {
+		_, err = c.ActorSystem.Root.RequestFuture(pid, batch, c.Config.PubSubConfig.SubscriberTimeout).Result()
+		return err
+	}
+	if ci := deadLetter.Subscriber.GetClusterIdentity(); ci != nil {
+		_, err = c.Request(ci.Identity, ci.Kind, batch)
+		return err
+	}
+
+	return errors.New("the dead letter has no subscriber")
+}
@@ -0,0 +1,82 @@
+package cluster
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/remote"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextDeliveryAttempt(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 3, InitialBackoff: 10 * time.Millisecond, Multiplier: 2}
+	started := time.Now()
+
+	backoff, ok := nextDeliveryAttempt(policy, 1, started)
+	assert.True(t, ok)
+	assert.Equal(t, 10*time.Millisecond, backoff)
+	backoff, ok = nextDeliveryAttempt(policy, 2, started)
+	assert.True(t, ok)
+	assert.Equal(t, 20*time.Millisecond, backoff)
+	_, ok = nextDeliveryAttempt(policy, 3, started)
+	assert.False(t, ok, "the batch is a poison message after MaxAttempts")
+
+	policy = &RetryPolicy{InitialBackoff: 10 * time.Millisecond, Deadline: time.Second}
+	_, ok = nextDeliveryAttempt(policy, 100, started)
+	assert.True(t, ok, "the attempts are only limited by the deadline")
+	_, ok = nextDeliveryAttempt(policy, 1, started.Add(-time.Second))
+	assert.False(t, ok, "the deadline is exceeded")
+
+	policy = &RetryPolicy{InitialBackoff: 10 * time.Millisecond}
+	_, ok = nextDeliveryAttempt(policy, defaultMaxAttempts, started)
+	assert.False(t, ok, "a policy without limits makes the default attempts")
+}
+
+func TestDeliveryStatus(t *testing.T) {
+	assert.Equal(t, DeliveryStatus_Delivered, deliveryStatus(nil))
+	assert.Equal(t, DeliveryStatus_Timeout, deliveryStatus(actor.ErrTimeout))
+	assert.Equal(t, DeliveryStatus_Timeout, deliveryStatus(remote.ErrTimeout))
+	assert.Equal(t, DeliveryStatus_SubscriberNoLongerReachable, deliveryStatus(actor.ErrDeadLetter))
+	assert.Equal(t, DeliveryStatus_SubscriberNoLongerReachable, deliveryStatus(remote.ErrDeadLetter))
+	assert.Equal(t, DeliveryStatus_OtherError, deliveryStatus(errors.New("failed")))
+}
+
+func TestReenterAfterCallDoesNotBlockTheActor(t *testing.T) {
+	system := actor.NewActorSystem()
+	release := make(chan struct{})
+	results := make(chan interface{}, 2)
+	pid := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		switch msg := ctx.Message().(type) {
+		case string:
+			if msg == "call" {
+				reenterAfterCall(ctx, func() (interface{}, error) {
+					<-release
+					return "called", nil
+				}, func(res interface{}, err error) {
+					results <- res
+				})
+				return
+			}
+			results <- msg
+		}
+	}))
+
+	system.Root.Send(pid, "call")
+	system.Root.Send(pid, "next")
+	select {
+	case res := <-results:
+		assert.Equal(t, "next", res)
+	case <-time.After(time.Second):
+		assert.Fail(t, "the actor did not process its messages during the call")
+	}
+
+	close(release)
+	select {
+	case res := <-results:
+		assert.Equal(t, "called", res)
+	case <-time.After(time.Second):
+		assert.Fail(t, "the actor did not continue with the result of the call")
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"time"
 
 	"github.com/asynkron/protoactor-go/actor"
-	"github.com/asynkron/protoactor-go/remote"
 )
 
 type PubSubMemberDeliveryActor struct {
@@ -23,60 +22,134 @@ func NewPubSubMemberDeliveryActor(subscriberTimeout time.Duration, logger *slog.
 }
 
 func (p *PubSubMemberDeliveryActor) Receive(c actor.Context) {
-	if batch, ok := c.Message().(*DeliverBatchRequest); ok {
-		topicBatch := &PubSubAutoRespondBatch{Envelopes: batch.PubSubBatch.Envelopes}
-		siList := batch.Subscribers.Subscribers
+	switch msg := c.Message().(type) {
+	case *DeliverBatchRequest:
+		p.deliver(c, msg)
+	case *retrySubscriberDelivery:
+		p.attempt(c, []*subscriberDelivery{msg.delivery})
+	case *activateTopicPatterns:
+		p.activateTopicPatterns(c, msg)
+	}
+}
 
-		invalidDeliveries := make([]*SubscriberDeliveryReport, 0, len(siList))
+// batchDelivery is the delivery of a batch to the subscribers, it completes once every subscriber got the batch or
+// failed all its attempts
+type batchDelivery struct {
+	request   *DeliverBatchRequest
+	batch     *PubSubAutoRespondBatch
+	remaining int
+	failures  []deliveryFailure
+}
 
-		type futureWithIdentity struct {
-			future   *actor.Future
-			identity *SubscriberIdentity
-		}
-		futureList := make([]futureWithIdentity, 0, len(siList))
-		for _, identity := range siList {
-			f := p.DeliverBatch(c, topicBatch, identity)
-			if f != nil {
-				futureList = append(futureList, futureWithIdentity{future: f, identity: identity})
+// subscriberDelivery is the delivery of a batch to a subscriber
+type subscriberDelivery struct {
+	batch    *batchDelivery
+	identity *SubscriberIdentity
+	policy   *RetryPolicy
+	started  time.Time
+	attempts int
+	err      error
+}
+
+// retrySubscriberDelivery is sent to the delivery actor when a failed delivery is due for its next attempt
+type retrySubscriberDelivery struct {
+	delivery *subscriberDelivery
+}
+
+// deliver delivers the batch to the subscribers. The failed deliveries are retried with the retry policies of
+// their subscribers, without holding up the other deliveries, so a retried batch may reach its subscriber after
+// the next ones. The deliveries which failed all their attempts are published to the dead-letter topic and
+// reported to the topic.
+func (p *PubSubMemberDeliveryActor) deliver(c actor.Context, batch *DeliverBatchRequest) {
+	cluster := GetCluster(c.ActorSystem())
+	siList := batch.Subscribers.Subscribers
+	if len(siList) == 0 {
+		return
+	}
+
+	now := time.Now()
+	delivery := &batchDelivery{
+		request:   batch,
+		batch:     &PubSubAutoRespondBatch{Envelopes: batch.PubSubBatch.Envelopes},
+		remaining: len(siList),
+	}
+	deliveries := make([]*subscriberDelivery, len(siList))
+	for i, identity := range siList {
+		deliveries[i] = &subscriberDelivery{batch: delivery, identity: identity, policy: cluster.deliveryRetryPolicy(batch.Topic, identity), started: now}
+	}
+
+	p.attempt(c, deliveries)
+}
+
+// attempted schedules the next attempt of a failed delivery, or completes it when it succeeded or has no attempt left
+func (p *PubSubMemberDeliveryActor) attempted(c actor.Context, d *subscriberDelivery) {
+	if d.err != nil {
+		status := deliveryStatus(d.err)
+		// a PID which is no longer reachable never processes the batch
+		if d.policy != nil && !(status == DeliveryStatus_SubscriberNoLongerReachable && d.identity.GetPid() != nil) {
+			if backoff, ok := nextDeliveryAttempt(d.policy, d.attempts, d.started); ok {
+				system, self := c.ActorSystem(), c.Self()
+				time.AfterFunc(backoff, func() {
+					system.Root.Send(self, &retrySubscriberDelivery{delivery: d})
+				})
+				return
 			}
 		}
+		d.batch.failures = append(d.batch.failures, deliveryFailure{subscriber: d.identity, status: status, attempts: d.attempts, err: d.err})
+	}
 
-		for _, fWithIdentity := range futureList {
-			_, err := fWithIdentity.future.Result()
-			identityLog := func(err error) {
-				if p.shouldThrottle() == actor.Open {
-					if fWithIdentity.identity.GetPid() != nil {
-						c.Logger().Error("Pub-sub message failed to deliver to PID", slog.String("pid", fWithIdentity.identity.GetPid().String()), slog.Any("error", err))
-					} else if fWithIdentity.identity.GetClusterIdentity() != nil {
-						c.Logger().Error("Pub-sub message failed to deliver to cluster identity", slog.String("cluster identity", fWithIdentity.identity.GetClusterIdentity().String()), slog.Any("error", err))
-					}
-				}
-			}
+	d.batch.remaining--
+	if d.batch.remaining == 0 && len(d.batch.failures) > 0 {
+		p.reportFailures(c, d.batch)
+	}
+}
 
-			status := DeliveryStatus_Delivered
+// reportFailures publishes the batch to the dead-letter topic for each failed delivery and reports them to the topic,
+// without waiting for the dead-letter topic and the topic to respond
+func (p *PubSubMemberDeliveryActor) reportFailures(c actor.Context, delivery *batchDelivery) {
+	cluster := GetCluster(c.ActorSystem())
+	topic := delivery.request.Topic
+
+	invalidDeliveries := make([]*SubscriberDeliveryReport, len(delivery.failures))
+	for i, failure := range delivery.failures {
+		cluster.publishDeadLetters(c, topic, delivery.request.PubSubBatch.Envelopes, failure, func(err error) {
 			if err != nil {
-				switch err {
-				case actor.ErrTimeout, remote.ErrTimeout:
-					identityLog(err)
-					status = DeliveryStatus_Timeout
-				case actor.ErrDeadLetter, remote.ErrDeadLetter:
-					identityLog(err)
-					status = DeliveryStatus_SubscriberNoLongerReachable
-				default:
-					identityLog(err)
-					status = DeliveryStatus_OtherError
-				}
+				c.Logger().Error("Pub-sub messages failed to publish to the dead-letter topic", slog.String("topic", topic), slog.Any("error", err))
 			}
-			if status != DeliveryStatus_Delivered {
-				invalidDeliveries = append(invalidDeliveries, &SubscriberDeliveryReport{Status: status, Subscriber: fWithIdentity.identity})
+		})
+		invalidDeliveries[i] = &SubscriberDeliveryReport{Status: failure.status, Subscriber: failure.subscriber}
+	}
+
+	// we use cluster.Call to locate the topic actor in the cluster
+	reenterAfterCall(c, func() (interface{}, error) {
+		return cluster.Request(topic, TopicActorKind, &NotifyAboutFailingSubscribersRequest{InvalidDeliveries: invalidDeliveries})
+	}, func(interface{}, error) {})
+}
+
+// attempt delivers the batches to the subscribers of the deliveries concurrently, and waits for their results
+func (p *PubSubMemberDeliveryActor) attempt(c actor.Context, deliveries []*subscriberDelivery) {
+	futures := make([]*actor.Future, len(deliveries))
+	for i, d := range deliveries {
+		futures[i] = p.DeliverBatch(c, d.batch.batch, d.identity)
+	}
+
+	for i, d := range deliveries {
+		d.attempts++
+		d.err = nil
+		if futures[i] != nil {
+			if _, err := futures[i].Result(); err != nil {
+				d.err = err
+				if p.shouldThrottle() == actor.Open {
+					if d.identity.GetPid() != nil {
+						c.Logger().Error("Pub-sub message failed to deliver to PID", slog.String("pid", d.identity.GetPid().String()), slog.Int("attempt", d.attempts), slog.Any("error", err))
+					} else if d.identity.GetClusterIdentity() != nil {
+						c.Logger().Error("Pub-sub message failed to deliver to cluster identity", slog.String("cluster identity", d.identity.GetClusterIdentity().String()), slog.Int("attempt", d.attempts), slog.Any("error", err))
+					}
+				}
 			}
 		}
 
-		if len(invalidDeliveries) > 0 {
-			cluster := GetCluster(c.ActorSystem())
-			// we use cluster.Call to locate the topic actor in the cluster
-			_, _ = cluster.Request(batch.Topic, TopicActorKind, &NotifyAboutFailingSubscribersRequest{InvalidDeliveries: invalidDeliveries})
-		}
+		p.attempted(c, d)
 	}
 }
 
@@ -0,0 +1,417 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/remote"
+	"google.golang.org/protobuf/proto"
+)
+
+// TopicLogStore persists the batches published to durable topics and the offsets committed by their subscribers
+type TopicLogStore interface {
+	// Append appends the batch to the log of the topic and returns its offset
+	Append(ctx context.Context, topic string, batch *PubSubBatchTransport) (uint64, error)
+	// Read returns up to limit entries of the log of the topic, starting at the offset
+	Read(ctx context.Context, topic string, from uint64, limit int) ([]*TopicLogEntry, error)
+	// LastOffset returns the offset of the last entry appended to the log of the topic, 0 if none was appended
+	LastOffset(ctx context.Context, topic string) (uint64, error)
+	// Truncate removes the entries of the log of the topic before the offset
+	Truncate(ctx context.Context, topic string, before uint64) error
+	// Offsets returns the offsets committed by the subscribers of the topic
+	Offsets(ctx context.Context, topic string) ([]*TopicOffset, error)
+	// Commit sets the offset of the subscriber of the topic
+	Commit(ctx context.Context, topic string, offset *TopicOffset) error
+	// RemoveOffset removes the offset of the subscriber of the topic
+	RemoveOffset(ctx context.Context, topic string, subscriber *SubscriberIdentity) error
+}
+
+// resumeDelivery is sent to a durable topic to deliver the pending batches to a subscriber again
+type resumeDelivery struct {
+	subscriber subscribeIdentityStruct
+}
+
+// durableSubscriber is the delivery state of a subscriber of a durable topic
+type durableSubscriber struct {
+	identity   *SubscriberIdentity
+	offset     uint64
+	delivering bool
+	retrying   bool
+	// attempts is the number of failed deliveries of the batch after the offset, since started
+	attempts int
+	started  time.Time
+}
+
+// durableTopic delivers the batches of the log of a topic in order to each subscriber, a batch is delivered again
+// until the subscriber acknowledged it, then its offset is committed
+type durableTopic struct {
+	store              TopicLogStore
+	subscriberTimeout  time.Duration
+	redeliveryInterval time.Duration
+	lastOffset         uint64
+	subscribers        map[subscribeIdentityStruct]*durableSubscriber
+}
+
+func newDurableTopic(c *Cluster, topic string) *durableTopic {
+	config := c.Config.PubSubConfig
+	if config.LogStore == nil || (config.DurableTopic != nil && !config.DurableTopic(partitionedTopic(topic))) {
+		return nil
+	}
+
+	return &durableTopic{
+		store:              config.LogStore,
+		subscriberTimeout:  config.SubscriberTimeout,
+		redeliveryInterval: config.RedeliveryInterval,
+		subscribers:        make(map[subscribeIdentityStruct]*durableSubscriber),
+	}
+}
+
+// onDurableStarted restores the subscribers of the topic from their committed offsets and resumes their delivery
+func (t *TopicActor) onDurableStarted(c actor.Context) {
+	ctx := context.Background()
+	lastOffset, err := t.durable.store.LastOffset(ctx, t.topic)
+	if err != nil {
+		c.Logger().Error("Error when loading the log of the durable topic", slog.String("topic", t.topic), slog.Any("error", err))
+	}
+	t.durable.lastOffset = lastOffset
+
+	offsets, err := t.durable.store.Offsets(ctx, t.topic)
+	if err != nil {
+		c.Logger().Error("Error when loading the offsets of the durable topic", slog.String("topic", t.topic), slog.Any("error", err))
+	}
+	for _, offset := range offsets {
+		key := newSubscribeIdentityStruct(offset.Subscriber)
+		t.subscribers[key] = offset.Subscriber
+		t.durable.subscribers[key] = &durableSubscriber{identity: offset.Subscriber, offset: offset.Offset}
+	}
+
+	// subscribers without offset subscribed before the topic was durable, they receive the batches published from now on
+	for key, identity := range t.subscribers {
+		if _, ok := t.durable.subscribers[key]; !ok {
+			s := &durableSubscriber{identity: identity, offset: lastOffset}
+			t.durable.subscribers[key] = s
+			t.commitDurable(c, s)
+		}
+	}
+
+	for key := range t.durable.subscribers {
+		t.deliverDurable(c, key)
+	}
+}
+
+// onDurablePubSubBatch appends the batch to the log, the publisher is acknowledged once the batch is persisted
+func (t *TopicActor) onDurablePubSubBatch(c actor.Context, batch *PubSubBatch) *PublishResponse {
+	transport, err := batch.Serialize()
+	if err != nil {
+		c.Logger().Error("Error when serializing the batch of the durable topic", slog.String("topic", t.topic), slog.Any("error", err))
+		return &PublishResponse{Status: PublishStatus_Failed}
+	}
+
+	offset, err := t.durable.store.Append(context.Background(), t.topic, transport.(*PubSubBatchTransport))
+	if err != nil {
+		c.Logger().Error("Error when appending to the log of the durable topic", slog.String("topic", t.topic), slog.Any("error", err))
+		return &PublishResponse{Status: PublishStatus_Failed}
+	}
+	t.durable.lastOffset = offset
+
+	for key := range t.durable.subscribers {
+		t.deliverDurable(c, key)
+	}
+
+	return &PublishResponse{}
+}
+
+// onDurableSubscribe starts the delivery to the subscriber, a new subscriber receives the batches published from now on
+// and a subscriber which subscribes again resumes from its committed offset
+func (t *TopicActor) onDurableSubscribe(c actor.Context, subscriber *SubscriberIdentity) {
+	key := newSubscribeIdentityStruct(subscriber)
+	if _, ok := t.durable.subscribers[key]; !ok {
+		s := &durableSubscriber{identity: subscriber, offset: t.durable.lastOffset}
+		t.durable.subscribers[key] = s
+		t.commitDurable(c, s)
+	}
+
+	t.deliverDurable(c, key)
+}
+
+// removeDurableSubscriber removes the subscriber and its offset
+func (t *TopicActor) removeDurableSubscriber(c actor.Context, key subscribeIdentityStruct) {
+	s, ok := t.durable.subscribers[key]
+	if !ok {
+		return
+	}
+
+	delete(t.durable.subscribers, key)
+	if err := t.durable.store.RemoveOffset(context.Background(), t.topic, s.identity); err != nil {
+		c.Logger().Error("Error when removing the offset of the subscriber", slog.String("topic", t.topic), slog.Any("subscriber", s.identity), slog.Any("error", err))
+	}
+	t.truncateDurable(c)
+}
+
+// deliverDurable delivers the next batch to the subscriber, unless a delivery to the subscriber is in progress
+func (t *TopicActor) deliverDurable(c actor.Context, key subscribeIdentityStruct) {
+	s, ok := t.durable.subscribers[key]
+	if !ok || s.delivering || s.retrying || s.offset >= t.durable.lastOffset {
+		return
+	}
+
+	entries, err := t.durable.store.Read(context.Background(), t.topic, s.offset+1, 1)
+	if err != nil || len(entries) == 0 {
+		c.Logger().Error("Error when reading the log of the durable topic", slog.String("topic", t.topic), slog.Uint64("offset", s.offset+1), slog.Any("error", err))
+		t.retryDurable(c, key, s, t.durable.redeliveryInterval)
+		return
+	}
+	entry := entries[0]
+
+	batch, err := entry.Batch.Deserialize()
+	if err != nil {
+		// the batch can not be delivered to any subscriber, it is skipped
+		c.Logger().Error("Error when deserializing the batch of the durable topic", slog.String("topic", t.topic), slog.Uint64("offset", entry.Offset), slog.Any("error", err))
+		s.offset = entry.Offset
+		t.commitDurable(c, s)
+		t.deliverDurable(c, key)
+		return
+	}
+
+	envelopes := t.filterEnvelopes(key, batch.(*PubSubBatch).Envelopes)
+	if len(envelopes) == 0 {
+		// the filter of the subscriber rejects the whole batch
+		s.offset = entry.Offset
+		t.commitDurable(c, s)
+		t.truncateDurable(c)
+		t.deliverDurable(c, key)
+		return
+	}
+
+	pid := t.getPID(c, s.identity)
+	if pid == nil {
+		t.retryDurable(c, key, s, t.durable.redeliveryInterval)
+		return
+	}
+
+	if s.attempts == 0 {
+		s.started = time.Now()
+	}
+	s.delivering = true
+	future := c.RequestFuture(pid, &PubSubAutoRespondBatch{Envelopes: envelopes}, t.durable.subscriberTimeout)
+	c.ReenterAfter(future, func(_ interface{}, err error) {
+		s.delivering = false
+		if current, ok := t.durable.subscribers[key]; !ok || current != s {
+			return
+		}
+
+		if err != nil && s.identity.GetPid() != nil && (errors.Is(err, actor.ErrDeadLetter) || errors.Is(err, remote.ErrDeadLetter)) {
+			// the subscriber actor stopped and can not subscribe again with the same PID
+			t.removeSubscribers(c, []subscribeIdentityStruct{key})
+			return
+		}
+
+		if err != nil {
+			s.attempts++
+			delay := t.durable.redeliveryInterval
+			if policy := GetCluster(c.ActorSystem()).deliveryRetryPolicy(t.topic, s.identity); policy != nil {
+				backoff, ok := nextDeliveryAttempt(policy, s.attempts, s.started)
+				if !ok {
+					t.skipPoisonBatch(c, key, s, entry.Offset, envelopes, err)
+					return
+				}
+				delay = backoff
+			}
+			if t.shouldThrottle() == actor.Open {
+				c.Logger().Warn("Durable topic failed to deliver a batch, it is delivered again", slog.String("topic", t.topic), slog.Any("subscriber", s.identity), slog.Uint64("offset", entry.Offset), slog.Int("attempt", s.attempts), slog.Any("error", err))
+			}
+			t.retryDurable(c, key, s, delay)
+			return
+		}
+
+		s.attempts = 0
+		if entry.Offset > s.offset {
+			s.offset = entry.Offset
+			t.commitDurable(c, s)
+			t.truncateDurable(c)
+		}
+		t.deliverDurable(c, key)
+	})
+}
+
+// skipPoisonBatch publishes the batch which failed all the attempts of the retry policy of the subscriber to the
+// dead-letter topic, then delivers the next batches to the subscriber
+func (t *TopicActor) skipPoisonBatch(c actor.Context, key subscribeIdentityStruct, s *durableSubscriber, offset uint64, envelopes []proto.Message, err error) {
+	failure := deliveryFailure{subscriber: s.identity, status: deliveryStatus(err), attempts: s.attempts, err: err}
+	// the subscriber is not delivered the next batches until the batch is published
+	s.delivering = true
+	GetCluster(c.ActorSystem()).publishDeadLetters(c, t.topic, envelopes, failure, func(err error) {
+		s.delivering = false
+		if current, ok := t.durable.subscribers[key]; !ok || current != s {
+			return
+		}
+
+		if err != nil {
+			// the batch is kept until it is delivered or published to the dead-letter topic
+			c.Logger().Error("Error when publishing a poison batch to the dead-letter topic", slog.String("topic", t.topic), slog.Uint64("offset", offset), slog.Any("error", err))
+			t.retryDurable(c, key, s, t.durable.redeliveryInterval)
+			return
+		}
+
+		c.Logger().Warn("Durable topic skipped a batch which the subscriber failed to process", slog.String("topic", t.topic), slog.Any("subscriber", s.identity), slog.Uint64("offset", offset), slog.Int("attempts", s.attempts))
+		s.attempts = 0
+		s.offset = offset
+		t.commitDurable(c, s)
+		t.truncateDurable(c)
+		t.deliverDurable(c, key)
+	})
+}
+
+// retryDurable delivers the pending batches to the subscriber again after the delay
+func (t *TopicActor) retryDurable(c actor.Context, key subscribeIdentityStruct, s *durableSubscriber, delay time.Duration) {
+	if s.retrying {
+		return
+	}
+
+	s.retrying = true
+	system, self := c.ActorSystem(), c.Self()
+	time.AfterFunc(delay, func() {
+		system.Root.Send(self, &resumeDelivery{subscriber: key})
+	})
+}
+
+func (t *TopicActor) onResumeDelivery(c actor.Context, msg *resumeDelivery) {
+	if s, ok := t.durable.subscribers[msg.subscriber]; ok {
+		s.retrying = false
+		t.deliverDurable(c, msg.subscriber)
+	}
+}
+
+func (t *TopicActor) commitDurable(c actor.Context, s *durableSubscriber) {
+	err := t.durable.store.Commit(context.Background(), t.topic, &TopicOffset{Subscriber: s.identity, Offset: s.offset})
+	if err != nil {
+		c.Logger().Error("Error when committing the offset of the subscriber", slog.String("topic", t.topic), slog.Any("subscriber", s.identity), slog.Any("error", err))
+	}
+}
+
+// truncateDurable removes the entries of the log which were delivered to all subscribers
+func (t *TopicActor) truncateDurable(c actor.Context) {
+	before := t.durable.lastOffset + 1
+	for _, s := range t.durable.subscribers {
+		if s.offset+1 < before {
+			before = s.offset + 1
+		}
+	}
+
+	if err := t.durable.store.Truncate(context.Background(), t.topic, before); err != nil {
+		c.Logger().Error("Error when truncating the log of the durable topic", slog.String("topic", t.topic), slog.Any("error", err))
+	}
+}
+
+// InMemoryTopicLogStore is a TopicLogStore keeping the logs in memory, the logs are lost when the member stops.
+// kvstore.BoltTopicLogStore keeps them across restarts.
+type InMemoryTopicLogStore struct {
+	mu   sync.Mutex
+	logs map[string]*inMemoryTopicLog
+}
+
+var _ TopicLogStore = (*InMemoryTopicLogStore)(nil)
+
+type inMemoryTopicLog struct {
+	entries    []*TopicLogEntry
+	lastOffset uint64
+	offsets    map[subscribeIdentityStruct]*TopicOffset
+}
+
+// NewInMemoryTopicLogStore creates an empty in-memory log store
+func NewInMemoryTopicLogStore() *InMemoryTopicLogStore {
+	return &InMemoryTopicLogStore{logs: make(map[string]*inMemoryTopicLog)}
+}
+
+func (s *InMemoryTopicLogStore) log(topic string) *inMemoryTopicLog {
+	l, ok := s.logs[topic]
+	if !ok {
+		l = &inMemoryTopicLog{offsets: make(map[subscribeIdentityStruct]*TopicOffset)}
+		s.logs[topic] = l
+	}
+
+	return l
+}
+
+func (s *InMemoryTopicLogStore) Append(_ context.Context, topic string, batch *PubSubBatchTransport) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	l := s.log(topic)
+	l.lastOffset++
+	l.entries = append(l.entries, &TopicLogEntry{Offset: l.lastOffset, Batch: batch})
+
+	return l.lastOffset, nil
+}
+
+func (s *InMemoryTopicLogStore) Read(_ context.Context, topic string, from uint64, limit int) ([]*TopicLogEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var entries []*TopicLogEntry
+	for _, entry := range s.log(topic).entries {
+		if len(entries) == limit {
+			break
+		}
+		if entry.Offset >= from {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
+
+func (s *InMemoryTopicLogStore) LastOffset(_ context.Context, topic string) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.log(topic).lastOffset, nil
+}
+
+func (s *InMemoryTopicLogStore) Truncate(_ context.Context, topic string, before uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	l := s.log(topic)
+	i := 0
+	for i < len(l.entries) && l.entries[i].Offset < before {
+		i++
+	}
+	l.entries = l.entries[i:]
+
+	return nil
+}
+
+func (s *InMemoryTopicLogStore) Offsets(_ context.Context, topic string) ([]*TopicOffset, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	offsets := make([]*TopicOffset, 0, len(s.log(topic).offsets))
+	for _, offset := range s.log(topic).offsets {
+		offsets = append(offsets, proto.Clone(offset).(*TopicOffset))
+	}
+
+	return offsets, nil
+}
+
+func (s *InMemoryTopicLogStore) Commit(_ context.Context, topic string, offset *TopicOffset) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.log(topic).offsets[newSubscribeIdentityStruct(offset.Subscriber)] = proto.Clone(offset).(*TopicOffset)
+
+	return nil
+}
+
+func (s *InMemoryTopicLogStore) RemoveOffset(_ context.Context, topic string, subscriber *SubscriberIdentity) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.log(topic).offsets, newSubscribeIdentityStruct(subscriber))
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryTopicLogStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewInMemoryTopicLogStore()
+
+	for i := 0; i < 3; i++ {
+		offset, err := store.Append(ctx, "topic", &PubSubBatchTransport{})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(i+1), offset)
+	}
+
+	last, err := store.LastOffset(ctx, "topic")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), last)
+
+	last, err = store.LastOffset(ctx, "other")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), last)
+
+	entries, err := store.Read(ctx, "topic", 2, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, uint64(2), entries[0].Offset)
+	assert.Equal(t, uint64(3), entries[1].Offset)
+
+	entries, err = store.Read(ctx, "topic", 1, 1)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, uint64(1), entries[0].Offset)
+
+	// the offsets continue after the log is truncated
+	require.NoError(t, store.Truncate(ctx, "topic", 3))
+	entries, err = store.Read(ctx, "topic", 1, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, uint64(3), entries[0].Offset)
+
+	offset, err := store.Append(ctx, "topic", &PubSubBatchTransport{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), offset)
+}
+
+func TestInMemoryTopicLogStoreOffsets(t *testing.T) {
+	ctx := context.Background()
+	store := NewInMemoryTopicLogStore()
+	pid := &SubscriberIdentity{Identity: &SubscriberIdentity_Pid{Pid: actor.NewPID("address", "id")}}
+	grain := &SubscriberIdentity{Identity: &SubscriberIdentity_ClusterIdentity{ClusterIdentity: NewClusterIdentity("identity", "kind")}}
+
+	require.NoError(t, store.Commit(ctx, "topic", &TopicOffset{Subscriber: pid, Offset: 1}))
+	require.NoError(t, store.Commit(ctx, "topic", &TopicOffset{Subscriber: grain, Offset: 2}))
+	require.NoError(t, store.Commit(ctx, "topic", &TopicOffset{Subscriber: grain, Offset: 3}))
+
+	offsets, err := store.Offsets(ctx, "topic")
+	require.NoError(t, err)
+	committed := make(map[subscribeIdentityStruct]uint64)
+	for _, offset := range offsets {
+		committed[newSubscribeIdentityStruct(offset.Subscriber)] = offset.Offset
+	}
+	assert.Equal(t, map[subscribeIdentityStruct]uint64{
+		newSubscribeIdentityStruct(pid):   1,
+		newSubscribeIdentityStruct(grain): 3,
+	}, committed)
+
+	require.NoError(t, store.RemoveOffset(ctx, "topic", pid))
+	offsets, err = store.Offsets(ctx, "topic")
+	require.NoError(t, err)
+	require.Len(t, offsets, 1)
+	assert.Equal(t, uint64(3), offsets[0].Offset)
+
+	offsets, err = store.Offsets(ctx, "other")
+	require.NoError(t, err)
+	assert.Empty(t, offsets)
+}
@@ -9,14 +9,18 @@ func (c *Cluster) Publisher() Publisher {
 	return NewPublisher(c)
 }
 
-// BatchingProducer create a new PubSub batching producer for specified topic, that publishes directly to the topic actor
+// BatchingProducer create a new PubSub batching producer for specified topic, that publishes directly to the topic actor.
+// The pending messages of the producer are published before the member leaves, see Drain
 func (c *Cluster) BatchingProducer(topic string, opts ...BatchingProducerConfigOption) *BatchingProducer {
-	return NewBatchingProducer(c.Publisher(), topic, opts...)
+	producer := NewBatchingProducer(c.Publisher(), topic, opts...)
+	c.PubSub.trackProducer(producer)
+
+	return producer
 }
 
 // SubscribeByPid subscribes to a PubSub topic by subscriber PID
 func (c *Cluster) SubscribeByPid(topic string, pid *actor.PID, opts ...GrainCallOption) (*SubscribeResponse, error) {
-	res, err := c.Request(topic, TopicActorKind, &SubscribeRequest{
+	res, err := c.requestTopic(topic, &SubscribeRequest{
 		Subscriber: &SubscriberIdentity{Identity: &SubscriberIdentity_Pid{Pid: pid}},
 	}, opts...)
 	if err != nil {
@@ -27,7 +31,7 @@ func (c *Cluster) SubscribeByPid(topic string, pid *actor.PID, opts ...GrainCall
 
 // SubscribeByClusterIdentity subscribes to a PubSub topic by cluster identity
 func (c *Cluster) SubscribeByClusterIdentity(topic string, identity *ClusterIdentity, opts ...GrainCallOption) (*SubscribeResponse, error) {
-	res, err := c.Request(topic, TopicActorKind, &SubscribeRequest{
+	res, err := c.requestTopic(topic, &SubscribeRequest{
 		Subscriber: &SubscriberIdentity{Identity: &SubscriberIdentity_ClusterIdentity{ClusterIdentity: identity}},
 	}, opts...)
 	if err != nil {
@@ -36,6 +40,23 @@ func (c *Cluster) SubscribeByClusterIdentity(topic string, identity *ClusterIden
 	return res.(*SubscribeResponse), err
 }
 
+// SubscribeWithFilter subscribes to a PubSub topic with a filter evaluated by the topic, only the messages matching
+// the filter are delivered to the subscriber. Subscribing again replaces the filter of the subscriber.
+func (c *Cluster) SubscribeWithFilter(topic string, subscriber *SubscriberIdentity, filter *SubscriptionFilter, opts ...GrainCallOption) (*SubscribeResponse, error) {
+	if _, err := CompileFilter(filter); err != nil {
+		return nil, err
+	}
+
+	res, err := c.requestTopic(topic, &SubscribeRequest{Subscriber: subscriber, Filter: filter}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if errRes, ok := res.(*GrainErrorResponse); ok {
+		return nil, errRes
+	}
+	return res.(*SubscribeResponse), err
+}
+
 // SubscribeWithReceive subscribe to a PubSub topic by providing a Receive function, that will be used to spawn a subscriber actor
 func (c *Cluster) SubscribeWithReceive(topic string, receive actor.ReceiveFunc, opts ...GrainCallOption) (*SubscribeResponse, error) {
 	props := actor.PropsFromFunc(receive)
@@ -45,7 +66,7 @@ func (c *Cluster) SubscribeWithReceive(topic string, receive actor.ReceiveFunc,
 
 // UnsubscribeByPid unsubscribes from a PubSub topic by subscriber PID
 func (c *Cluster) UnsubscribeByPid(topic string, pid *actor.PID, opts ...GrainCallOption) (*UnsubscribeResponse, error) {
-	res, err := c.Request(topic, TopicActorKind, &UnsubscribeRequest{
+	res, err := c.requestTopic(topic, &UnsubscribeRequest{
 		Subscriber: &SubscriberIdentity{Identity: &SubscriberIdentity_Pid{Pid: pid}},
 	}, opts...)
 	if err != nil {
@@ -56,7 +77,7 @@ func (c *Cluster) UnsubscribeByPid(topic string, pid *actor.PID, opts ...GrainCa
 
 // UnsubscribeByClusterIdentity unsubscribes from a PubSub topic by cluster identity
 func (c *Cluster) UnsubscribeByClusterIdentity(topic string, identity *ClusterIdentity, opts ...GrainCallOption) (*UnsubscribeResponse, error) {
-	res, err := c.Request(topic, TopicActorKind, &UnsubscribeRequest{
+	res, err := c.requestTopic(topic, &UnsubscribeRequest{
 		Subscriber: &SubscriberIdentity{Identity: &SubscriberIdentity_ClusterIdentity{ClusterIdentity: identity}},
 	}, opts...)
 	if err != nil {
@@ -67,7 +88,7 @@ func (c *Cluster) UnsubscribeByClusterIdentity(topic string, identity *ClusterId
 
 // UnsubscribeByIdentityAndKind unsubscribes from a PubSub topic by cluster identity
 func (c *Cluster) UnsubscribeByIdentityAndKind(topic string, identity string, kind string, opts ...GrainCallOption) (*UnsubscribeResponse, error) {
-	res, err := c.Request(topic, TopicActorKind, &UnsubscribeRequest{
+	res, err := c.requestTopic(topic, &UnsubscribeRequest{
 		Subscriber: &SubscriberIdentity{Identity: &SubscriberIdentity_ClusterIdentity{ClusterIdentity: NewClusterIdentity(identity, kind)}},
 	}, opts...)
 	if err != nil {
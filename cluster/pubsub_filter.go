@@ -0,0 +1,483 @@
+package cluster
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// MessageFilter is a compiled SubscriptionFilter.
+//
+// The expression of the filter compares the fields of the messages with literals:
+//   - a field is referenced by its protobuf name, nested fields with dots, such as customer.tier
+//   - the literals are numbers, double-quoted strings, true and false
+//   - the comparisons are ==, !=, <, <=, >, >= and combine with &&, ||, ! and parentheses
+//   - a bool field is a condition by itself, such as `express && amount > 100`
+//   - enum fields compare with the name of their value, such as `status == "SHIPPED"`
+//
+// Comparing values of different types or fields the message does not have is false, so a filter over the
+// fields of one message type does not match the other message types of the topic.
+type MessageFilter struct {
+	messageTypes map[protoreflect.FullName]empty
+	expression   filterNode
+}
+
+// CompileFilter compiles the filter, a nil filter matches all messages
+func CompileFilter(filter *SubscriptionFilter) (*MessageFilter, error) {
+	f := &MessageFilter{}
+	if filter == nil {
+		return f, nil
+	}
+
+	if len(filter.MessageTypes) > 0 {
+		f.messageTypes = make(map[protoreflect.FullName]empty, len(filter.MessageTypes))
+		for _, messageType := range filter.MessageTypes {
+			f.messageTypes[protoreflect.FullName(messageType)] = empty{}
+		}
+	}
+
+	if strings.TrimSpace(filter.Expression) != "" {
+		expression, err := parseFilterExpression(filter.Expression)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter expression %q: %w", filter.Expression, err)
+		}
+		f.expression = expression
+	}
+
+	return f, nil
+}
+
+// Match returns true if the message passes the filter
+func (f *MessageFilter) Match(message proto.Message) bool {
+	if f.messageTypes == nil && f.expression == nil {
+		return true
+	}
+
+	reflected := message.ProtoReflect()
+	if f.messageTypes != nil {
+		if _, ok := f.messageTypes[reflected.Descriptor().FullName()]; !ok {
+			return false
+		}
+	}
+
+	return f.expression == nil || isTrue(f.expression.eval(reflected))
+}
+
+// subscriberFilter is the filter of a subscriber of a topic
+type subscriberFilter struct {
+	filter   *SubscriptionFilter
+	compiled *MessageFilter
+}
+
+// setFilter sets the filter of the subscriber, a nil filter removes it
+func (t *TopicActor) setFilter(key subscribeIdentityStruct, filter *SubscriptionFilter) error {
+	if filter == nil {
+		delete(t.filters, key)
+		return nil
+	}
+
+	compiled, err := CompileFilter(filter)
+	if err != nil {
+		return err
+	}
+	t.filters[key] = &subscriberFilter{filter: filter, compiled: compiled}
+
+	return nil
+}
+
+// loadFilters compiles the filters of the subscribers loaded from the subscription store
+func (t *TopicActor) loadFilters(filters []*SubscriberFilter, logger *slog.Logger) {
+	for _, f := range filters {
+		key := newSubscribeIdentityStruct(f.Subscriber)
+		if _, ok := t.subscribers[key]; !ok {
+			continue
+		}
+		if err := t.setFilter(key, f.Filter); err != nil {
+			logger.Error("Topic could not compile the filter of the subscriber", slog.String("topic", t.topic), slog.Any("subscriber", f.Subscriber), slog.Any("error", err))
+		}
+	}
+}
+
+// savedFilters returns the filters of the subscribers to save in the subscription store
+func (t *TopicActor) savedFilters() []*SubscriberFilter {
+	if len(t.filters) == 0 {
+		return nil
+	}
+
+	filters := make([]*SubscriberFilter, 0, len(t.filters))
+	for key, f := range t.filters {
+		filters = append(filters, &SubscriberFilter{Subscriber: t.subscribers[key], Filter: f.filter})
+	}
+
+	return filters
+}
+
+// filterEnvelopes returns the envelopes passing the filter of the subscriber, the envelopes themselves if the
+// subscriber has no filter
+func (t *TopicActor) filterEnvelopes(key subscribeIdentityStruct, envelopes []proto.Message) []proto.Message {
+	f, ok := t.filters[key]
+	if !ok {
+		return envelopes
+	}
+
+	filtered := make([]proto.Message, 0, len(envelopes))
+	for _, envelope := range envelopes {
+		if f.compiled.Match(envelope) {
+			filtered = append(filtered, envelope)
+		}
+	}
+
+	return filtered
+}
+
+// matchesKey identifies the envelopes of a batch passing a filter, the filtered envelopes are in the order of the batch
+func matchesKey(envelopes, filtered []proto.Message) string {
+	if len(envelopes) == len(filtered) {
+		return ""
+	}
+
+	matches := make([]byte, len(envelopes))
+	for i, j := 0, 0; i < len(envelopes); i++ {
+		matches[i] = '0'
+		if j < len(filtered) && envelopes[i] == filtered[j] {
+			matches[i] = '1'
+			j++
+		}
+	}
+
+	return string(matches)
+}
+
+// filterNode is a node of a parsed filter expression
+type filterNode interface {
+	eval(m protoreflect.Message) interface{}
+}
+
+type (
+	literalNode struct{ value interface{} }
+	fieldNode   struct{ path []protoreflect.Name }
+	notNode     struct{ operand filterNode }
+	logicalNode struct {
+		and         bool
+		left, right filterNode
+	}
+	comparisonNode struct {
+		op          string
+		left, right filterNode
+	}
+)
+
+func (n *literalNode) eval(protoreflect.Message) interface{} { return n.value }
+
+// eval returns the value of the field as a float64, string or bool, nil if the message has no such field
+func (n *fieldNode) eval(m protoreflect.Message) interface{} {
+	for i, name := range n.path {
+		fd := m.Descriptor().Fields().ByName(name)
+		if fd == nil || fd.IsList() || fd.IsMap() {
+			return nil
+		}
+
+		if i < len(n.path)-1 {
+			if fd.Kind() != protoreflect.MessageKind && fd.Kind() != protoreflect.GroupKind {
+				return nil
+			}
+			m = m.Get(fd).Message()
+			continue
+		}
+
+		value := m.Get(fd)
+		switch fd.Kind() {
+		case protoreflect.BoolKind:
+			return value.Bool()
+		case protoreflect.StringKind:
+			return value.String()
+		case protoreflect.EnumKind:
+			if enum := fd.Enum().Values().ByNumber(value.Enum()); enum != nil {
+				return string(enum.Name())
+			}
+			return float64(value.Enum())
+		case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+			protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+			return float64(value.Int())
+		case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+			return float64(value.Uint())
+		case protoreflect.FloatKind, protoreflect.DoubleKind:
+			return value.Float()
+		default:
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (n *notNode) eval(m protoreflect.Message) interface{} {
+	return !isTrue(n.operand.eval(m))
+}
+
+func (n *logicalNode) eval(m protoreflect.Message) interface{} {
+	if n.and {
+		return isTrue(n.left.eval(m)) && isTrue(n.right.eval(m))
+	}
+
+	return isTrue(n.left.eval(m)) || isTrue(n.right.eval(m))
+}
+
+func (n *comparisonNode) eval(m protoreflect.Message) interface{} {
+	left, right := n.left.eval(m), n.right.eval(m)
+
+	switch l := left.(type) {
+	case float64:
+		if r, ok := right.(float64); ok {
+			return compareOrdered(n.op, l, r)
+		}
+	case string:
+		if r, ok := right.(string); ok {
+			return compareOrdered(n.op, l, r)
+		}
+	case bool:
+		if r, ok := right.(bool); ok {
+			switch n.op {
+			case "==":
+				return l == r
+			case "!=":
+				return l != r
+			}
+		}
+	}
+
+	return false
+}
+
+func compareOrdered[T float64 | string](op string, l, r T) bool {
+	switch op {
+	case "==":
+		return l == r
+	case "!=":
+		return l != r
+	case "<":
+		return l < r
+	case "<=":
+		return l <= r
+	case ">":
+		return l > r
+	case ">=":
+		return l >= r
+	default:
+		return false
+	}
+}
+
+func isTrue(value interface{}) bool {
+	b, ok := value.(bool)
+	return ok && b
+}
+
+// filterParser is a recursive descent parser of the filter expressions:
+//
+//	or         = and { "||" and }
+//	and        = unary { "&&" unary }
+//	unary      = "!" unary | "(" or ")" | comparison
+//	comparison = operand [ ( "==" | "!=" | "<" | "<=" | ">" | ">=" ) operand ]
+//	operand    = field | number | string | "true" | "false"
+type filterParser struct {
+	tokens []string
+	pos    int
+}
+
+func parseFilterExpression(expression string) (filterNode, error) {
+	tokens, err := tokenizeFilter(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &filterParser{tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q", p.tokens[p.pos])
+	}
+
+	return node, nil
+}
+
+func (p *filterParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+
+	return ""
+}
+
+func (p *filterParser) next() string {
+	token := p.peek()
+	p.pos++
+
+	return token
+}
+
+func (p *filterParser) parseOr() (filterNode, error) {
+	left, err := p.parseAnd()
+	for err == nil && p.peek() == "||" {
+		p.next()
+		var right filterNode
+		if right, err = p.parseAnd(); err == nil {
+			left = &logicalNode{left: left, right: right}
+		}
+	}
+
+	return left, err
+}
+
+func (p *filterParser) parseAnd() (filterNode, error) {
+	left, err := p.parseUnary()
+	for err == nil && p.peek() == "&&" {
+		p.next()
+		var right filterNode
+		if right, err = p.parseUnary(); err == nil {
+			left = &logicalNode{and: true, left: left, right: right}
+		}
+	}
+
+	return left, err
+}
+
+func (p *filterParser) parseUnary() (filterNode, error) {
+	switch p.peek() {
+	case "!":
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{operand: operand}, nil
+	case "(":
+		p.next()
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if token := p.next(); token != ")" {
+			return nil, fmt.Errorf("expected ) instead of %q", token)
+		}
+		return node, nil
+	default:
+		return p.parseComparison()
+	}
+}
+
+func (p *filterParser) parseComparison() (filterNode, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	switch op := p.peek(); op {
+	case "==", "!=", "<", "<=", ">", ">=":
+		p.next()
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return &comparisonNode{op: op, left: left, right: right}, nil
+	default:
+		return left, nil
+	}
+}
+
+func (p *filterParser) parseOperand() (filterNode, error) {
+	token := p.next()
+	switch {
+	case token == "":
+		return nil, fmt.Errorf("unexpected end of expression")
+	case token == "true" || token == "false":
+		return &literalNode{value: token == "true"}, nil
+	case token[0] == '"':
+		value, err := strconv.Unquote(token)
+		if err != nil {
+			return nil, fmt.Errorf("invalid string %s", token)
+		}
+		return &literalNode{value: value}, nil
+	case token[0] == '-' || token[0] == '.' || unicode.IsDigit(rune(token[0])):
+		value, err := strconv.ParseFloat(token, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s", token)
+		}
+		return &literalNode{value: value}, nil
+	case isFilterIdentifier(rune(token[0])):
+		names := strings.Split(token, ".")
+		path := make([]protoreflect.Name, len(names))
+		for i, name := range names {
+			if !protoreflect.Name(name).IsValid() {
+				return nil, fmt.Errorf("invalid field %s", token)
+			}
+			path[i] = protoreflect.Name(name)
+		}
+		return &fieldNode{path: path}, nil
+	default:
+		return nil, fmt.Errorf("unexpected %q", token)
+	}
+}
+
+func isFilterIdentifier(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+// tokenizeFilter splits the expression in operators, parentheses, strings, numbers and field paths
+func tokenizeFilter(expression string) ([]string, error) {
+	var tokens []string
+	runes := []rune(expression)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')':
+			tokens = append(tokens, string(r))
+			i++
+		case strings.ContainsRune("=!<>&|", r):
+			if i+1 < len(runes) {
+				if op := string(runes[i : i+2]); op == "==" || op == "!=" || op == "<=" || op == ">=" || op == "&&" || op == "||" {
+					tokens = append(tokens, op)
+					i += 2
+					continue
+				}
+			}
+			if r != '!' && r != '<' && r != '>' {
+				return nil, fmt.Errorf("unexpected %q", string(r))
+			}
+			tokens = append(tokens, string(r))
+			i++
+		case r == '"':
+			j := i + 1
+			for ; j < len(runes) && runes[j] != '"'; j++ {
+				if runes[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(runes) {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			tokens = append(tokens, string(runes[i:j+1]))
+			i = j + 1
+		case r == '-' || r == '.' || unicode.IsDigit(r) || isFilterIdentifier(r):
+			j := i + 1
+			for ; j < len(runes) && (runes[j] == '.' || runes[j] == '_' || unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j])); j++ {
+			}
+			tokens = append(tokens, string(runes[i:j]))
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected %q", string(r))
+		}
+	}
+
+	return tokens, nil
+}
@@ -0,0 +1,104 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestMessageFilterExpressions(t *testing.T) {
+	member := &Member{Host: "eu-1", Port: 8080, Id: "a"}
+	report := &SubscriberDeliveryReport{
+		Subscriber: &SubscriberIdentity{Identity: &SubscriberIdentity_ClusterIdentity{ClusterIdentity: NewClusterIdentity("id", "orders")}},
+		Status:     DeliveryStatus_Timeout,
+	}
+
+	cases := []struct {
+		expression string
+		message    proto.Message
+		match      bool
+	}{
+		{`port == 8080`, member, true},
+		{`port > 8000 && host == "eu-1"`, member, true},
+		{`port >= 8081`, member, false},
+		{`port < 9000.5`, member, true},
+		{`host != "eu-1" || port <= 8080`, member, true},
+		{`!(host == "eu-1")`, member, false},
+		{`host > "eu-0"`, member, true},
+		{`(port == 1 || port == 8080) && id == "a"`, member, true},
+		{`port == "8080"`, member, false},
+		{`unknown == 1`, member, false},
+		{`unknown != 1`, member, false},
+		{`status == "Timeout"`, report, true},
+		{`subscriber.cluster_identity.kind == "orders"`, report, true},
+		{`subscriber.pid.id == "orders"`, report, false},
+		{`status == "Timeout"`, member, false},
+		{`value`, wrapperspb.Bool(true), true},
+		{`!value`, wrapperspb.Bool(true), false},
+		{`value == false`, wrapperspb.Bool(false), true},
+		{`value > -1.5`, wrapperspb.Double(-1), true},
+		{`value == "a \"quoted\" string"`, wrapperspb.String(`a "quoted" string`), true},
+	}
+
+	for _, c := range cases {
+		filter, err := CompileFilter(&SubscriptionFilter{Expression: c.expression})
+		require.NoError(t, err, c.expression)
+		assert.Equal(t, c.match, filter.Match(c.message), c.expression)
+	}
+}
+
+func TestMessageFilterMessageTypes(t *testing.T) {
+	filter, err := CompileFilter(&SubscriptionFilter{
+		MessageTypes: []string{"cluster.Member", "google.protobuf.StringValue"},
+		Expression:   `host == "eu-1" || value == "eu-1"`,
+	})
+	require.NoError(t, err)
+
+	assert.True(t, filter.Match(&Member{Host: "eu-1"}))
+	assert.False(t, filter.Match(&Member{Host: "us-1"}))
+	assert.True(t, filter.Match(wrapperspb.String("eu-1")))
+	assert.False(t, filter.Match(&ClusterIdentity{Identity: "eu-1"}))
+
+	all, err := CompileFilter(nil)
+	require.NoError(t, err)
+	assert.True(t, all.Match(&ClusterIdentity{}))
+}
+
+func TestCompileFilterRejectsInvalidExpressions(t *testing.T) {
+	for _, expression := range []string{
+		`port ==`,
+		`port = 1`,
+		`(port == 1`,
+		`port == 1)`,
+		`host == "unterminated`,
+		`port == 1 && || port == 2`,
+		`port == 1.2.3`,
+		`port.1 == 1`,
+		`port # 1`,
+	} {
+		_, err := CompileFilter(&SubscriptionFilter{Expression: expression})
+		assert.Error(t, err, expression)
+	}
+}
+
+func TestTopicActorFiltersEnvelopesPerSubscriber(t *testing.T) {
+	topic := NewTopicActor(nil, nil)
+	first := newSubscribeIdentityStruct(&SubscriberIdentity{Identity: &SubscriberIdentity_Pid{Pid: actor.NewPID("address", "first")}})
+	second := newSubscribeIdentityStruct(&SubscriberIdentity{Identity: &SubscriberIdentity_Pid{Pid: actor.NewPID("address", "second")}})
+	require.NoError(t, topic.setFilter(first, &SubscriptionFilter{Expression: `port > 1`}))
+
+	envelopes := []proto.Message{&Member{Port: 1}, &Member{Port: 2}, &Member{Port: 3}}
+	filtered := topic.filterEnvelopes(first, envelopes)
+	assert.Equal(t, envelopes[1:], filtered)
+	assert.Equal(t, "011", matchesKey(envelopes, filtered))
+
+	assert.Equal(t, envelopes, topic.filterEnvelopes(second, envelopes))
+	assert.Equal(t, "", matchesKey(envelopes, envelopes))
+
+	require.NoError(t, topic.setFilter(first, nil))
+	assert.Equal(t, envelopes, topic.filterEnvelopes(first, envelopes))
+}
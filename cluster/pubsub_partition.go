@@ -0,0 +1,111 @@
+package cluster
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// A partitioned topic is sharded across several topic actors, so a hot topic is not limited by the throughput of a
+// single actor. The batches published to the topic are split by the ordering keys of their messages, the messages
+// with the same key go to the same partition and are delivered in publish order, the messages without key go to a
+// random partition. Subscribing to a partitioned topic subscribes to all its partitions.
+//
+// The partitioned topics are configured with the same number of partitions on all members,
+// see WithPubSubPartitionedTopic.
+
+// partitionSeparator separates the topic from the partition in the identity of the topic actor of a partition
+const partitionSeparator = "#"
+
+// PartitionIdentity returns the identity of the topic actor of the partition of the topic
+func PartitionIdentity(topic string, partition int) string {
+	return topic + partitionSeparator + strconv.Itoa(partition)
+}
+
+// PartitionOf returns the partition of the topic the messages with the ordering key are published to
+func PartitionOf(orderingKey string, partitions int) int {
+	if orderingKey == "" {
+		return rand.Intn(partitions)
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(orderingKey))
+
+	return int(h.Sum32() % uint32(partitions))
+}
+
+// partitionedTopic returns the topic of the identity of the topic actor of a partition, or the identity itself
+// when it is not a partition
+func partitionedTopic(identity string) string {
+	i := strings.LastIndex(identity, partitionSeparator)
+	if i < 0 {
+		return identity
+	}
+	if _, err := strconv.Atoi(identity[i+1:]); err != nil {
+		return identity
+	}
+
+	return identity[:i]
+}
+
+// topicPartitions returns the number of partitions of the topic, 1 when it is not partitioned
+func (c *Cluster) topicPartitions(topic string) int {
+	if partitions := c.Config.PubSubConfig.PartitionedTopics[topic]; partitions > 1 {
+		return partitions
+	}
+
+	return 1
+}
+
+// topicIdentities returns the identities of the topic actors of the partitions of the topic, the topic itself when
+// it is not partitioned
+func (c *Cluster) topicIdentities(topic string) []string {
+	partitions := c.topicPartitions(topic)
+	if partitions == 1 {
+		return []string{topic}
+	}
+
+	identities := make([]string, partitions)
+	for i := range identities {
+		identities[i] = PartitionIdentity(topic, i)
+	}
+
+	return identities
+}
+
+// requestTopic sends the request to the topic actor, or to the topic actors of all partitions of a partitioned
+// topic, and returns the last response
+func (c *Cluster) requestTopic(topic string, message interface{}, opts ...GrainCallOption) (interface{}, error) {
+	var res interface{}
+	for _, identity := range c.topicIdentities(topic) {
+		var err error
+		res, err = c.Request(identity, TopicActorKind, message, opts...)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := res.(*GrainErrorResponse); ok {
+			return res, nil
+		}
+	}
+
+	return res, nil
+}
+
+// splitByPartition splits the batch in the batches of each partition, keeping the order of the messages
+func splitByPartition(batch *PubSubBatch, partitions int) map[int]*PubSubBatch {
+	batches := make(map[int]*PubSubBatch)
+	for i, envelope := range batch.Envelopes {
+		key := batch.OrderingKey(i)
+		partition := PartitionOf(key, partitions)
+
+		b, ok := batches[partition]
+		if !ok {
+			b = &PubSubBatch{}
+			batches[partition] = b
+		}
+		b.add(envelope, key)
+	}
+
+	return batches
+}
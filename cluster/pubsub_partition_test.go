@@ -0,0 +1,83 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestPartitionOfIsStablePerKey(t *testing.T) {
+	for _, key := range []string{"a", "b", "customer-1", "customer-2"} {
+		partition := PartitionOf(key, 8)
+		assert.GreaterOrEqual(t, partition, 0)
+		assert.Less(t, partition, 8)
+		for i := 0; i < 10; i++ {
+			assert.Equal(t, partition, PartitionOf(key, 8), key)
+		}
+	}
+	for i := 0; i < 100; i++ {
+		partition := PartitionOf("", 4)
+		assert.GreaterOrEqual(t, partition, 0)
+		assert.Less(t, partition, 4)
+	}
+}
+
+func TestPartitionedTopic(t *testing.T) {
+	assert.Equal(t, "orders", partitionedTopic(PartitionIdentity("orders", 3)))
+	assert.Equal(t, "orders#eu", partitionedTopic("orders#eu"))
+	assert.Equal(t, "orders", partitionedTopic("orders"))
+}
+
+func TestSplitByPartitionKeepsTheOrderOfEachKey(t *testing.T) {
+	batch := &PubSubBatch{}
+	keys := []string{"a", "b", "c", "a", "b", "a", "c"}
+	for i, key := range keys {
+		batch.add(&TestMessage{Number: int32(i)}, key)
+	}
+
+	batches := splitByPartition(batch, 4)
+	total := 0
+	for partition, b := range batches {
+		total += len(b.Envelopes)
+		require.Len(t, b.OrderingKeys, len(b.Envelopes))
+		last := make(map[string]int32)
+		for i, envelope := range b.Envelopes {
+			key := b.OrderingKey(i)
+			assert.Equal(t, partition, PartitionOf(key, 4))
+			number := envelope.(*TestMessage).Number
+			if previous, ok := last[key]; ok {
+				assert.Less(t, previous, number)
+			}
+			last[key] = number
+		}
+	}
+	assert.Equal(t, len(keys), total)
+}
+
+func TestPubSubBatchKeepsOrderingKeysParallelToEnvelopes(t *testing.T) {
+	batch := &PubSubBatch{}
+	batch.add(&TestMessage{Number: 1}, "")
+	assert.Nil(t, batch.OrderingKeys)
+	batch.add(&TestMessage{Number: 2}, "a")
+	batch.add(&TestMessage{Number: 3}, "")
+	batch.add(&TestMessage{Number: 4}, "b")
+	assert.Equal(t, []string{"", "a", "", "b"}, batch.OrderingKeys)
+
+	batch.remove(1)
+	assert.Equal(t, []string{"", "", "b"}, batch.OrderingKeys)
+	assert.Equal(t, "b", batch.OrderingKey(2))
+	assert.Equal(t, "", batch.OrderingKey(5))
+
+	serialized, err := batch.Serialize()
+	require.NoError(t, err)
+	deserialized, err := serialized.(*PubSubBatchTransport).Deserialize()
+	require.NoError(t, err)
+	result := deserialized.(*PubSubBatch)
+	assert.Equal(t, batch.OrderingKeys, result.OrderingKeys)
+	require.Len(t, result.Envelopes, 3)
+	for i := range batch.Envelopes {
+		assert.True(t, proto.Equal(batch.Envelopes[i], result.Envelopes[i]))
+	}
+}
@@ -68,6 +68,7 @@ type BatchingProducer struct {
 	loopCancel       context.CancelFunc
 	loopDone         chan struct{}
 	msgLeft          uint32
+	onDispose        func()
 }
 
 func NewBatchingProducer(publisher Publisher, topic string, opts ...BatchingProducerConfigOption) *BatchingProducer {
@@ -105,8 +106,9 @@ func newPubSubBatchWithReceipts() *pubsubBatchWithReceipts {
 }
 
 type produceMessage struct {
-	message proto.Message
-	ctx     context.Context
+	message     proto.Message
+	orderingKey string
+	ctx         context.Context
 }
 
 // Dispose stops the producer and releases all resources.
@@ -114,6 +116,22 @@ func (p *BatchingProducer) Dispose() {
 	p.loopCancel()
 	p.publisherChannel.broadcast()
 	<-p.loopDone
+	if p.onDispose != nil {
+		p.onDispose()
+	}
+}
+
+// Flush waits until the messages produced so far are published, failed or cancelled
+func (p *BatchingProducer) Flush(ctx context.Context) error {
+	for atomic.LoadUint32(&p.msgLeft) > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	return nil
 }
 
 // ProduceProcessInfo is the context for a Produce call
@@ -122,6 +140,7 @@ type ProduceProcessInfo struct {
 	Err        error
 	cancelFunc context.CancelFunc
 	cancelled  chan struct{}
+	onFinished func()
 }
 
 // IsCancelled returns true if the context has been cancelled
@@ -149,6 +168,7 @@ func (p *ProduceProcessInfo) setErr(err error) {
 	p.Err = err
 	p.cancelFunc()
 	close(p.Finished)
+	p.finished()
 }
 
 // cancel the ProduceProcessInfo context
@@ -156,12 +176,20 @@ func (p *ProduceProcessInfo) cancel() {
 	p.cancelFunc()
 	close(p.Finished)
 	close(p.cancelled)
+	p.finished()
 }
 
 // success closes the ProduceProcessInfo Finished channel
 func (p *ProduceProcessInfo) success() {
 	p.cancelFunc()
 	close(p.Finished)
+	p.finished()
+}
+
+func (p *ProduceProcessInfo) finished() {
+	if p.onFinished != nil {
+		p.onFinished()
+	}
 }
 
 type produceProcessInfoKey struct{}
@@ -173,17 +201,28 @@ func (p *BatchingProducer) getProduceProcessInfo(ctx context.Context) *ProducePr
 
 // Produce a message to producer queue. The return info can be used to wait for the message to be published.
 func (p *BatchingProducer) Produce(ctx context.Context, message proto.Message) (*ProduceProcessInfo, error) {
+	return p.ProduceWithOrderingKey(ctx, "", message)
+}
+
+// ProduceWithOrderingKey produces a message with an ordering key to producer queue. The messages of the producer
+// with the same key are published and delivered in the order they were produced, and they are published to the
+// same partition of a partitioned topic.
+func (p *BatchingProducer) ProduceWithOrderingKey(ctx context.Context, orderingKey string, message proto.Message) (*ProduceProcessInfo, error) {
 	ctx, cancel := context.WithCancel(ctx)
 	info := &ProduceProcessInfo{
 		Finished:   make(chan struct{}),
 		cancelled:  make(chan struct{}),
 		cancelFunc: cancel,
+		onFinished: func() { atomic.AddUint32(&p.msgLeft, ^uint32(0)) },
 	}
 	ctx = context.WithValue(ctx, produceProcessInfoKey{}, info)
+	atomic.AddUint32(&p.msgLeft, 1)
 	if !p.publisherChannel.tryWrite(produceMessage{
-		message: message,
-		ctx:     ctx,
+		message:     message,
+		orderingKey: orderingKey,
+		ctx:         ctx,
 	}) {
+		atomic.AddUint32(&p.msgLeft, ^uint32(0))
 		if p.publisherChannel.isComplete() {
 			return info, &InvalidOperationException{Topic: p.topic}
 		}
@@ -227,7 +266,7 @@ loop:
 				case <-msg.ctx.Done():
 					p.getProduceProcessInfo(msg.ctx).cancel()
 				default:
-					batchWrapper.batch.Envelopes = append(batchWrapper.batch.Envelopes, msg.message)
+					batchWrapper.batch.add(msg.message, msg.orderingKey)
 					batchWrapper.ctxArr = append(batchWrapper.ctxArr, msg.ctx)
 				}
 
@@ -329,7 +368,7 @@ func (p *BatchingProducer) removeCancelledFromBatch(batchWrapper *pubsubBatchWit
 				info.cancel()
 			}
 
-			batchWrapper.batch.Envelopes = append(batchWrapper.batch.Envelopes[:i], batchWrapper.batch.Envelopes[i+1:]...)
+			batchWrapper.batch.remove(i)
 			batchWrapper.ctxArr = append(batchWrapper.ctxArr[:i], batchWrapper.ctxArr[i+1:]...)
 		default:
 			continue
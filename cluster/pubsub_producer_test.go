@@ -110,6 +110,31 @@ func (suite *PubSubBatchingProducerTestSuite) TestProducerSendsMessagesInBatches
 	suite.allSentNumbersShouldEqual(suite.batchesSent, suite.iter(0, 10000)...)
 }
 
+func (suite *PubSubBatchingProducerTestSuite) TestFlushWaitsForProducedMessages() {
+	producer := NewBatchingProducer(newMockPublisher(suite.record), "topic", WithBatchingProducerBatchSize(10))
+	defer producer.Dispose()
+
+	for i := 0; i < 100; i++ {
+		_, err := producer.Produce(context.Background(), &TestMessage{Number: int32(i)})
+		suite.Assert().NoError(err)
+	}
+
+	suite.Assert().NoError(producer.Flush(context.Background()))
+	suite.allSentNumbersShouldEqual(suite.batchesSent, suite.iter(0, 100)...)
+}
+
+func (suite *PubSubBatchingProducerTestSuite) TestFlushStopsWithContext() {
+	producer := NewBatchingProducer(newMockPublisher(suite.wait), "topic", WithBatchingProducerBatchSize(10))
+	defer producer.Dispose()
+
+	_, err := producer.Produce(context.Background(), &TestMessage{Number: 1})
+	suite.Assert().NoError(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	suite.Assert().ErrorIs(producer.Flush(ctx), context.DeadlineExceeded)
+}
+
 func (suite *PubSubBatchingProducerTestSuite) TestPublishingThroughStoppedProducerThrows() {
 	producer := NewBatchingProducer(newMockPublisher(suite.record), "topic", WithBatchingProducerBatchSize(10))
 	producer.Dispose()
@@ -284,6 +309,39 @@ func (suite *PubSubBatchingProducerTestSuite) TestIfMessageIsCancelledMeanwhileR
 	suite.allSentNumbersShouldEqual(publisher.sentBatches, 2)
 }
 
+func (suite *PubSubBatchingProducerTestSuite) TestProducerKeepsOrderingKeysOfRetriedBatches() {
+	var keys []string
+	failed := false
+	producer := NewBatchingProducer(newMockPublisher(func(batch *PubSubBatch) (*PublishResponse, error) {
+		if !failed {
+			failed = true
+			return &PublishResponse{Status: PublishStatus_Failed}, &testException{}
+		}
+		for i := range batch.Envelopes {
+			keys = append(keys, batch.OrderingKey(i))
+		}
+		return &PublishResponse{Status: PublishStatus_Ok}, nil
+	}), "topic",
+		WithBatchingProducerBatchSize(10),
+		WithBatchingProducerOnPublishingError(func(retry int, e error, batch *PubSubBatch) *PublishingErrorDecision {
+			return RetryBatchImmediately
+		}))
+	defer producer.Dispose()
+
+	var infos []*ProduceProcessInfo
+	for i, key := range []string{"a", "", "b", "a"} {
+		info, err := producer.ProduceWithOrderingKey(context.Background(), key, &TestMessage{Number: int32(i)})
+		suite.Assert().NoError(err)
+		infos = append(infos, info)
+	}
+	for _, info := range infos {
+		<-info.Finished
+		suite.Assert().NoError(info.Err)
+	}
+
+	suite.Assert().Equal([]string{"a", "", "b", "a"}, keys)
+}
+
 func (suite *PubSubBatchingProducerTestSuite) TestCanHandlePublishTimeouts() {
 }
 
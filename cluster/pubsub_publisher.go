@@ -2,6 +2,7 @@ package cluster
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"time"
 
@@ -9,6 +10,9 @@ import (
 	"google.golang.org/protobuf/types/known/durationpb"
 )
 
+// ErrPublishToTopicPattern is returned when publishing to a topic pattern, the patterns can only be subscribed to
+var ErrPublishToTopicPattern = errors.New("cannot publish to a topic pattern")
+
 type PublisherConfig struct {
 	IdleTimeout time.Duration
 }
@@ -45,7 +49,7 @@ func (p *defaultPublisher) Initialize(ctx context.Context, topic string, config
 	case <-ctx.Done():
 		return nil, ctx.Err()
 	default:
-		res, err := p.cluster.Request(topic, TopicActorKind, &Initialize{
+		res, err := p.cluster.requestTopic(topic, &Initialize{
 			IdleTimeout: durationpb.New(config.IdleTimeout),
 		})
 		if err != nil {
@@ -60,6 +64,13 @@ func (p *defaultPublisher) PublishBatch(ctx context.Context, topic string, batch
 	case <-ctx.Done():
 		return nil, ctx.Err()
 	default:
+		if IsTopicPattern(topic) {
+			return nil, ErrPublishToTopicPattern
+		}
+		if partitions := p.cluster.topicPartitions(topic); partitions > 1 {
+			return p.publishPartitioned(topic, batch, partitions, opts...)
+		}
+
 		res, err := p.cluster.Request(topic, TopicActorKind, batch, opts...)
 		if err != nil {
 			return nil, err
@@ -73,3 +84,25 @@ func (p *defaultPublisher) Publish(ctx context.Context, topic string, message pr
 		Envelopes: []proto.Message{message},
 	}, opts...)
 }
+
+// publishPartitioned publishes the batches of the partitions of the batch in the order of the partitions,
+// the publishing stops at the first partition which fails
+func (p *defaultPublisher) publishPartitioned(topic string, batch *PubSubBatch, partitions int, opts ...GrainCallOption) (*PublishResponse, error) {
+	batches := splitByPartition(batch, partitions)
+	for partition := 0; partition < partitions; partition++ {
+		b, ok := batches[partition]
+		if !ok {
+			continue
+		}
+
+		res, err := p.cluster.Request(PartitionIdentity(topic, partition), TopicActorKind, b, opts...)
+		if err != nil {
+			return nil, err
+		}
+		if res := res.(*PublishResponse); res.Status != PublishStatus_Ok {
+			return res, nil
+		}
+	}
+
+	return &PublishResponse{}, nil
+}
@@ -16,15 +16,18 @@ const TopicActorKind = "prototopic"
 type TopicActor struct {
 	topic                string
 	subscribers          map[subscribeIdentityStruct]*SubscriberIdentity
+	filters              map[subscribeIdentityStruct]*subscriberFilter
 	subscriptionStore    KeyValueStore[*Subscribers]
 	topologySubscription *eventstream.Subscription
 	shouldThrottle       actor.ShouldThrottle
+	durable              *durableTopic // nil when the topic is not durable
 }
 
 func NewTopicActor(store KeyValueStore[*Subscribers], logger *slog.Logger) *TopicActor {
 	return &TopicActor{
 		subscriptionStore: store,
 		subscribers:       make(map[subscribeIdentityStruct]*SubscriberIdentity),
+		filters:           make(map[subscribeIdentityStruct]*subscriberFilter),
 		shouldThrottle: actor.NewThrottleWithLogger(logger, 10, time.Second, func(logger *slog.Logger, count int32) {
 			logger.Info("[TopicActor] Throttled logs", slog.Int("count", int(count)))
 		}),
@@ -51,6 +54,8 @@ func (t *TopicActor) Receive(c actor.Context) {
 		t.onNotifyAboutFailingSubscribers(c, msg)
 	case *ClusterTopology:
 		t.onClusterTopologyChanged(c, msg)
+	case *resumeDelivery:
+		t.onResumeDelivery(c, msg)
 	}
 }
 
@@ -68,12 +73,21 @@ func (t *TopicActor) onStarted(c actor.Context) {
 			t.subscribers[newSubscribeIdentityStruct(subscriber)] = subscriber
 		}
 	}
+	t.loadFilters(sub.Filters, c.Logger())
+	t.durable = newDurableTopic(GetCluster(c.ActorSystem()), t.topic)
+	if t.durable != nil {
+		t.onDurableStarted(c)
+	}
 	t.unsubscribeSubscribersOnMembersThatLeft(c)
+	t.updatePatternHosted(c)
 
 	c.Logger().Debug("Topic started", slog.String("topic", t.topic))
 }
 
 func (t *TopicActor) onStopping(c actor.Context) {
+	if IsTopicPattern(t.topic) {
+		GetPubSub(c.ActorSystem()).setPatternHosted(t.topic, false)
+	}
 	if t.topologySubscription != nil {
 		c.ActorSystem().EventStream.Unsubscribe(t.topologySubscription)
 		t.topologySubscription = nil
@@ -99,29 +113,61 @@ type pidAndSubscriber struct {
 	subscriber *SubscriberIdentity
 }
 
-// onPubSubBatch handles a PubSubBatch message, sends the message to all subscribers
+// onPubSubBatch handles a PubSubBatch message, sends the message to all subscribers. The publisher is
+// acknowledged once the topic actors of the matching patterns acknowledged the batch too.
 func (t *TopicActor) onPubSubBatch(c actor.Context, batch *PubSubBatch) {
-	// map subscribers to map[address][](pid, subscriber)
-	members := make(map[string][]pidAndSubscriber)
-	for _, identity := range t.subscribers {
+	var forwards []*actor.Future
+	if !IsTopicPattern(t.topic) {
+		forwards = t.forwardToPatterns(c, batch)
+	}
+
+	var res *PublishResponse
+	if t.durable != nil {
+		res = t.onDurablePubSubBatch(c, batch)
+	} else {
+		res = t.deliverBatch(c, batch)
+	}
+	t.respondAfterForwards(c, res, forwards)
+}
+
+// deliverBatch sends the batch to the delivery actors of the members of the subscribers
+func (t *TopicActor) deliverBatch(c actor.Context, batch *PubSubBatch) *PublishResponse {
+
+	// map subscribers to map[address and filtered batch][](pid, subscriber), the subscribers on a member which
+	// receive the same envelopes get one delivery
+	type delivery struct {
+		address string
+		matches string
+	}
+	members := make(map[delivery][]pidAndSubscriber)
+	batches := make(map[delivery]*PubSubBatch)
+	for key, identity := range t.subscribers {
+		envelopes := t.filterEnvelopes(key, batch.Envelopes)
+		if len(envelopes) == 0 {
+			continue
+		}
+
 		pid := t.getPID(c, identity)
 		if pid != nil {
-			members[pid.Address] = append(members[pid.Address], pidAndSubscriber{pid: pid, subscriber: identity})
+			d := delivery{address: pid.Address, matches: matchesKey(batch.Envelopes, envelopes)}
+			members[d] = append(members[d], pidAndSubscriber{pid: pid, subscriber: identity})
+			batches[d] = &PubSubBatch{Envelopes: envelopes}
 		}
 	}
 
 	// send message to each member
-	for address, member := range members {
+	for d, member := range members {
 		subscribersOnMember := t.getSubscribersForAddress(member)
 		deliveryMessage := &DeliverBatchRequest{
 			Subscribers: subscribersOnMember,
-			PubSubBatch: batch,
+			PubSubBatch: batches[d],
 			Topic:       t.topic,
 		}
-		deliveryPid := actor.NewPID(address, PubSubDeliveryName)
+		deliveryPid := actor.NewPID(d.address, PubSubDeliveryName)
 		c.Send(deliveryPid, deliveryMessage)
 	}
-	c.Respond(&PublishResponse{})
+
+	return &PublishResponse{}
 }
 
 // getSubscribersForAddress returns the subscribers for the given member list
@@ -177,7 +223,7 @@ func (t *TopicActor) unsubscribeUnreachablePidSubscribers(c actor.Context, allIn
 			subscribers = append(subscribers, newSubscribeIdentityStruct(r.Subscriber))
 		}
 	}
-	t.removeSubscribers(subscribers, c.Logger())
+	t.removeSubscribers(c, subscribers)
 }
 
 // onClusterTopologyChanged handles a ClusterTopology message
@@ -197,7 +243,7 @@ func (t *TopicActor) onClusterTopologyChanged(ctx actor.Context, msg *ClusterTop
 				}
 			}
 		}
-		t.removeSubscribers(subscribersThatLeft, ctx.Logger())
+		t.removeSubscribers(ctx, subscribersThatLeft)
 	}
 }
 
@@ -217,19 +263,25 @@ func (t *TopicActor) unsubscribeSubscribersOnMembersThatLeft(c actor.Context) {
 			}
 		}
 	}
-	t.removeSubscribers(subscribersThatLeft, c.Logger())
+	t.removeSubscribers(c, subscribersThatLeft)
 }
 
 // removeSubscribers remove subscribers from the topic
-func (t *TopicActor) removeSubscribers(subscribersThatLeft []subscribeIdentityStruct, logger *slog.Logger) {
+func (t *TopicActor) removeSubscribers(c actor.Context, subscribersThatLeft []subscribeIdentityStruct) {
 	if len(subscribersThatLeft) > 0 {
+		logger := c.Logger()
 		for _, subscriber := range subscribersThatLeft {
 			delete(t.subscribers, subscriber)
+			delete(t.filters, subscriber)
+			if t.durable != nil {
+				t.removeDurableSubscriber(c, subscriber)
+			}
 		}
 		if t.shouldThrottle() == actor.Open {
 			logger.Warn("Topic removed subscribers, because they are dead or they are on members that left the clusterIdentity:", slog.String("topic", t.topic), slog.Any("subscribers", subscribersThatLeft))
 		}
 		t.saveSubscriptionsInTopicActor(logger)
+		t.updatePatternHosted(c)
 	}
 }
 
@@ -252,7 +304,7 @@ func (t *TopicActor) loadSubscriptions(topic string, logger *slog.Logger) *Subsc
 
 // saveSubscriptionsInTopicActor saves the TopicActor.subscribers for the TopicActor.topic to the subscription store
 func (t *TopicActor) saveSubscriptionsInTopicActor(logger *slog.Logger) {
-	var subscribers *Subscribers = &Subscribers{Subscribers: maps.Values(t.subscribers)}
+	var subscribers *Subscribers = &Subscribers{Subscribers: maps.Values(t.subscribers), Filters: t.savedFilters()}
 
 	// TODO: cancellation logic config?
 	logger.Debug("Saving subscriptions for topic", slog.String("topic", t.topic), slog.Any("subscriptions", subscribers))
@@ -263,15 +315,31 @@ func (t *TopicActor) saveSubscriptionsInTopicActor(logger *slog.Logger) {
 }
 
 func (t *TopicActor) onUnsubscribe(c actor.Context, msg *UnsubscribeRequest) {
-	delete(t.subscribers, newSubscribeIdentityStruct(msg.Subscriber))
+	key := newSubscribeIdentityStruct(msg.Subscriber)
+	delete(t.subscribers, key)
+	delete(t.filters, key)
+	if t.durable != nil {
+		t.removeDurableSubscriber(c, key)
+	}
 	t.saveSubscriptionsInTopicActor(c.Logger())
+	t.updatePatternHosted(c)
 	c.Respond(&UnsubscribeResponse{})
 }
 
 func (t *TopicActor) onSubscribe(c actor.Context, msg *SubscribeRequest) {
-	t.subscribers[newSubscribeIdentityStruct(msg.Subscriber)] = msg.Subscriber
+	key := newSubscribeIdentityStruct(msg.Subscriber)
+	if err := t.setFilter(key, msg.Filter); err != nil {
+		c.Respond(NewGrainErrorResponse(ErrorReason_INVALID_ARGUMENT, err.Error()))
+		return
+	}
+
+	t.subscribers[key] = msg.Subscriber
 	c.Logger().Debug("Topic subscribed", slog.String("topic", t.topic), slog.Any("subscriber", msg.Subscriber))
 	t.saveSubscriptionsInTopicActor(c.Logger())
+	t.updatePatternHosted(c)
+	if t.durable != nil {
+		t.onDurableSubscribe(c, msg.Subscriber)
+	}
 	c.Respond(&SubscribeResponse{})
 }
 
@@ -0,0 +1,315 @@
+package cluster
+
+import (
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/asynkron/protoactor-go/actor"
+)
+
+// Topics are hierarchical names made of tokens separated by dots, such as "orders.eu.de".
+// A subscription to a topic pattern receives the messages published to all matching topics:
+//   - "*" matches exactly one token, "orders.*" matches "orders.eu" but not "orders.eu.de"
+//   - ">" as last token matches one or more tokens, "orders.>" matches "orders.eu" and "orders.eu.de"
+//
+// The topic actor of a pattern holds its subscribers, the members hosting such actors gossip their patterns
+// and the topic actors of the matching topics forward the published batches to them. A publisher is acknowledged
+// once the topic actors of the matching patterns acknowledged the batch, when one of them fails the publisher
+// is told the batch failed and publishes it again, so pattern subscribers receive it at least once.
+const (
+	topicSeparator        = "."
+	topicSingleWildcard   = "*"
+	topicMultipleWildcard = ">"
+)
+
+// IsTopicPattern returns true if the topic contains wildcards
+func IsTopicPattern(topic string) bool {
+	for _, token := range strings.Split(topic, topicSeparator) {
+		if token == topicSingleWildcard || token == topicMultipleWildcard {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MatchTopic returns true if the topic matches the pattern
+func MatchTopic(pattern, topic string) bool {
+	patternTokens := strings.Split(pattern, topicSeparator)
+	topicTokens := strings.Split(topic, topicSeparator)
+
+	for i, token := range patternTokens {
+		switch {
+		case token == topicMultipleWildcard:
+			return i == len(patternTokens)-1 && i < len(topicTokens)
+		case i >= len(topicTokens):
+			return false
+		case token != topicSingleWildcard && token != topicTokens[i]:
+			return false
+		}
+	}
+
+	return len(patternTokens) == len(topicTokens)
+}
+
+// TopicMatcher finds the patterns matching a topic in a tree of the pattern tokens, the time to match a topic
+// depends on the number of its tokens and not on the number of patterns.
+// It is not safe for concurrent use.
+type TopicMatcher struct {
+	root *topicNode
+}
+
+type topicNode struct {
+	children map[string]*topicNode
+	pattern  string // the pattern ending at the node, empty if none
+}
+
+// NewTopicMatcher creates a matcher without patterns
+func NewTopicMatcher(patterns ...string) *TopicMatcher {
+	m := &TopicMatcher{root: &topicNode{}}
+	for _, pattern := range patterns {
+		m.Add(pattern)
+	}
+
+	return m
+}
+
+// Add adds the pattern to the matcher
+func (m *TopicMatcher) Add(pattern string) {
+	node := m.root
+	for _, token := range strings.Split(pattern, topicSeparator) {
+		child, ok := node.children[token]
+		if !ok {
+			if node.children == nil {
+				node.children = make(map[string]*topicNode)
+			}
+			child = &topicNode{}
+			node.children[token] = child
+		}
+		node = child
+	}
+	node.pattern = pattern
+}
+
+// Remove removes the pattern from the matcher
+func (m *TopicMatcher) Remove(pattern string) {
+	m.root.remove(strings.Split(pattern, topicSeparator))
+}
+
+// remove removes the pattern of the tokens below the node and returns true if the node became empty
+func (n *topicNode) remove(tokens []string) bool {
+	if len(tokens) == 0 {
+		n.pattern = ""
+		return len(n.children) == 0
+	}
+
+	child, ok := n.children[tokens[0]]
+	if ok && child.remove(tokens[1:]) {
+		delete(n.children, tokens[0])
+	}
+
+	return n.pattern == "" && len(n.children) == 0
+}
+
+// Match returns the patterns matching the topic
+func (m *TopicMatcher) Match(topic string) []string {
+	return m.root.match(strings.Split(topic, topicSeparator), nil)
+}
+
+func (n *topicNode) match(tokens []string, patterns []string) []string {
+	if len(tokens) == 0 {
+		if n.pattern != "" {
+			patterns = append(patterns, n.pattern)
+		}
+		return patterns
+	}
+
+	if child, ok := n.children[tokens[0]]; ok {
+		patterns = child.match(tokens[1:], patterns)
+	}
+	if child, ok := n.children[topicSingleWildcard]; ok {
+		patterns = child.match(tokens[1:], patterns)
+	}
+	if child, ok := n.children[topicMultipleWildcard]; ok && child.pattern != "" {
+		patterns = append(patterns, child.pattern)
+	}
+
+	return patterns
+}
+
+// matchingPatterns returns the patterns with subscribers matching the topic
+func (p *PubSub) matchingPatterns(topic string) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.matcher.Match(topic)
+}
+
+// setPatternHosted registers or unregisters a pattern whose topic actor is hosted by the member and gossips the
+// patterns of the member
+func (p *PubSub) setPatternHosted(pattern string, hosted bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.hostedPatterns[pattern]; ok == hosted {
+		return
+	}
+
+	if hosted {
+		p.hostedPatterns[pattern] = empty{}
+	} else {
+		delete(p.hostedPatterns, pattern)
+	}
+	p.updateMatcher()
+
+	patterns := make([]string, 0, len(p.hostedPatterns))
+	for pattern := range p.hostedPatterns {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+	p.cluster.Gossip.SetState(TopicPatternsKey, &TopicPatterns{Patterns: patterns})
+}
+
+// onTopicPatternsGossiped updates the patterns of another member
+func (p *PubSub) onTopicPatternsGossiped(update *GossipUpdate) {
+	patterns := &TopicPatterns{}
+	if err := update.Value.UnmarshalTo(patterns); err != nil {
+		p.cluster.Logger().Warn("Could not unpack topic patterns", slog.String("member", update.MemberID), slog.Any("error", err))
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.memberPatterns[update.MemberID] = patterns.Patterns
+	p.updateMatcher()
+}
+
+// onMembersLeft forgets the patterns of the members which left and activates the topic actors of the patterns
+// no other member hosts, so they keep receiving the batches of the matching topics
+func (p *PubSub) onMembersLeft(members []*Member) {
+	p.mu.Lock()
+	var left []string
+	for _, member := range members {
+		left = append(left, p.memberPatterns[member.Id]...)
+		delete(p.memberPatterns, member.Id)
+	}
+	p.updateMatcher()
+
+	var orphans []string
+	for _, pattern := range left {
+		if !p.isPatternHosted(pattern) {
+			orphans = append(orphans, pattern)
+		}
+	}
+	p.mu.Unlock()
+
+	if len(orphans) == 0 || p.cluster.ActorSystem.IsStopped() {
+		return
+	}
+	p.cluster.ActorSystem.Root.Send(p.delivery, &activateTopicPatterns{patterns: orphans})
+}
+
+// activateTopicPatterns is sent to the delivery actor to activate the topic actors of the patterns
+type activateTopicPatterns struct {
+	patterns []string
+}
+
+// activateTopicPatterns activates the topic actors of the patterns, they restore their subscribers when started
+func (p *PubSubMemberDeliveryActor) activateTopicPatterns(c actor.Context, msg *activateTopicPatterns) {
+	cluster := GetCluster(c.ActorSystem())
+	for _, pattern := range msg.patterns {
+		pid := cluster.Get(pattern, TopicActorKind)
+		if pid == nil {
+			c.Logger().Error("Could not activate the topic of the pattern", slog.String("pattern", pattern))
+			continue
+		}
+
+		pattern := pattern
+		c.ReenterAfter(c.RequestFuture(pid, &Initialize{}, cluster.Config.RequestTimeoutTime), func(_ interface{}, err error) {
+			if err != nil {
+				c.Logger().Error("Could not activate the topic of the pattern", slog.String("pattern", pattern), slog.Any("error", err))
+			}
+		})
+	}
+}
+
+// isPatternHosted returns true if a member hosts the topic actor of the pattern, the caller holds the lock
+func (p *PubSub) isPatternHosted(pattern string) bool {
+	if _, ok := p.hostedPatterns[pattern]; ok {
+		return true
+	}
+	for _, patterns := range p.memberPatterns {
+		for _, hosted := range patterns {
+			if hosted == pattern {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// forwardToPatterns forwards the batch published to the topic to the topic actors of the matching patterns and
+// returns the futures of their responses, a nil future when the topic actor of a pattern was not found
+func (t *TopicActor) forwardToPatterns(c actor.Context, batch *PubSubBatch) []*actor.Future {
+	cluster := GetCluster(c.ActorSystem())
+	patterns := GetPubSub(c.ActorSystem()).matchingPatterns(partitionedTopic(t.topic))
+	futures := make([]*actor.Future, len(patterns))
+	for i, pattern := range patterns {
+		if pid := t.getClusterIdentityPid(c, NewClusterIdentity(pattern, TopicActorKind)); pid != nil {
+			futures[i] = c.RequestFuture(pid, batch, cluster.Config.RequestTimeoutTime)
+		} else if t.shouldThrottle() == actor.Open {
+			c.Logger().Error("Topic could not forward the batch to the pattern", slog.String("topic", t.topic), slog.String("pattern", pattern))
+		}
+	}
+
+	return futures
+}
+
+// respondAfterForwards responds to the publisher once the forwarded batches were acknowledged, the batch failed
+// when one of them was not
+func (t *TopicActor) respondAfterForwards(c actor.Context, res *PublishResponse, forwards []*actor.Future) {
+	if len(forwards) == 0 {
+		c.Respond(res)
+		return
+	}
+
+	if forwards[0] == nil {
+		t.respondAfterForwards(c, &PublishResponse{Status: PublishStatus_Failed}, forwards[1:])
+		return
+	}
+
+	c.ReenterAfter(forwards[0], func(r interface{}, err error) {
+		if ack, ok := r.(*PublishResponse); err != nil || !ok || ack.Status != PublishStatus_Ok {
+			if t.shouldThrottle() == actor.Open {
+				c.Logger().Error("Topic pattern did not acknowledge the forwarded batch", slog.String("topic", t.topic), slog.Any("response", r), slog.Any("error", err))
+			}
+			res = &PublishResponse{Status: PublishStatus_Failed}
+		}
+		t.respondAfterForwards(c, res, forwards[1:])
+	})
+}
+
+// updatePatternHosted registers the pattern of the topic while it has subscribers, so the matching topics forward
+// their batches to it
+func (t *TopicActor) updatePatternHosted(c actor.Context) {
+	if IsTopicPattern(t.topic) {
+		GetPubSub(c.ActorSystem()).setPatternHosted(t.topic, len(t.subscribers) > 0)
+	}
+}
+
+// updateMatcher rebuilds the matcher from the patterns of all members, the caller holds the lock
+func (p *PubSub) updateMatcher() {
+	matcher := NewTopicMatcher()
+	for pattern := range p.hostedPatterns {
+		matcher.Add(pattern)
+	}
+	for _, patterns := range p.memberPatterns {
+		for _, pattern := range patterns {
+			matcher.Add(pattern)
+		}
+	}
+	p.matcher = matcher
+}
@@ -0,0 +1,138 @@
+package cluster
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var topicMatchCases = []struct {
+	pattern, topic string
+	match          bool
+}{
+	{"orders.*", "orders.eu", true},
+	{"orders.*", "orders.eu.de", false},
+	{"orders.*", "orders", false},
+	{"orders.*", "payments.eu", false},
+	{"orders.*.de", "orders.eu.de", true},
+	{"orders.*.de", "orders.eu.fr", false},
+	{"*.eu", "orders.eu", true},
+	{"*", "orders", true},
+	{"*", "orders.eu", false},
+	{"orders.>", "orders.eu", true},
+	{"orders.>", "orders.eu.de", true},
+	{"orders.>", "orders", false},
+	{"orders.*.>", "orders.eu.de.berlin", true},
+	{"orders.*.>", "orders.eu", false},
+	{">", "orders", true},
+	{">", "orders.eu.de", true},
+	{"orders.>.de", "orders.eu.de", false},
+	{"orders.eu", "orders.eu", true},
+	{"orders.eu", "orders.eu.de", false},
+}
+
+func TestMatchTopic(t *testing.T) {
+	for _, c := range topicMatchCases {
+		assert.Equal(t, c.match, MatchTopic(c.pattern, c.topic), "%s %s", c.pattern, c.topic)
+	}
+}
+
+func TestIsTopicPattern(t *testing.T) {
+	assert.True(t, IsTopicPattern("orders.*"))
+	assert.True(t, IsTopicPattern("orders.>"))
+	assert.True(t, IsTopicPattern("*"))
+	assert.False(t, IsTopicPattern("orders.eu"))
+	assert.False(t, IsTopicPattern("orders*.eu"))
+}
+
+func TestTopicMatcherMatchesLikeMatchTopic(t *testing.T) {
+	patterns := make([]string, 0, len(topicMatchCases))
+	for _, c := range topicMatchCases {
+		patterns = append(patterns, c.pattern)
+	}
+	matcher := NewTopicMatcher(patterns...)
+
+	for _, c := range topicMatchCases {
+		var expected []string
+		for _, pattern := range patterns {
+			if MatchTopic(pattern, c.topic) && !contains(expected, pattern) {
+				expected = append(expected, pattern)
+			}
+		}
+		assert.ElementsMatch(t, expected, matcher.Match(c.topic), c.topic)
+	}
+}
+
+func TestTopicMatcherRemove(t *testing.T) {
+	matcher := NewTopicMatcher("orders.*", "orders.*.de", "orders.>")
+
+	matcher.Remove("orders.*")
+	assert.ElementsMatch(t, []string{"orders.>"}, matcher.Match("orders.eu"))
+	assert.ElementsMatch(t, []string{"orders.*.de", "orders.>"}, matcher.Match("orders.eu.de"))
+
+	matcher.Remove("orders.*.de")
+	matcher.Remove("orders.>")
+	matcher.Remove("unknown.*")
+	assert.Empty(t, matcher.Match("orders.eu.de"))
+	assert.Empty(t, matcher.root.children)
+}
+
+func Benchmark_TopicMatcher_Match(b *testing.B) {
+	for _, v := range []int{10, 1000, 100000} {
+		matcher := NewTopicMatcher()
+		for i := 0; i < v; i++ {
+			matcher.Add(fmt.Sprintf("orders.region%d.*", i))
+		}
+		matcher.Add("orders.>")
+
+		b.Run(fmt.Sprintf("patterns*%d", v), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if len(matcher.Match("orders.region7.de")) != 2 {
+					b.Fatal("patterns not matched")
+				}
+			}
+		})
+	}
+}
+
+func TestTopicAcknowledgesThePublisherAfterThePatterns(t *testing.T) {
+	system := actor.NewActorSystem()
+	root := system.Root
+	pattern := func(status PublishStatus) *actor.PID {
+		return root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+			if _, ok := ctx.Message().(*PubSubBatch); ok {
+				ctx.Respond(&PublishResponse{Status: status})
+			}
+		}))
+	}
+	ok, failed := pattern(PublishStatus_Ok), pattern(PublishStatus_Failed)
+
+	// a nil pattern was not found
+	publish := func(patterns ...*actor.PID) PublishStatus {
+		topic := NewTopicActor(nil, system.Logger())
+		pid := root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+			if batch, isBatch := ctx.Message().(*PubSubBatch); isBatch {
+				forwards := make([]*actor.Future, len(patterns))
+				for i, p := range patterns {
+					if p != nil {
+						forwards[i] = ctx.RequestFuture(p, batch, time.Second)
+					}
+				}
+				topic.respondAfterForwards(ctx, &PublishResponse{}, forwards)
+			}
+		}))
+
+		res, err := root.RequestFuture(pid, &PubSubBatch{}, 2*time.Second).Result()
+		require.NoError(t, err)
+		return res.(*PublishResponse).Status
+	}
+
+	assert.Equal(t, PublishStatus_Ok, publish())
+	assert.Equal(t, PublishStatus_Ok, publish(ok, ok))
+	assert.Equal(t, PublishStatus_Failed, publish(ok, failed))
+	assert.Equal(t, PublishStatus_Failed, publish(nil, ok))
+}
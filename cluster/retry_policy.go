@@ -0,0 +1,113 @@
+package cluster
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/remote"
+)
+
+// errActivationNotFound is the error of the attempts which found no activation of the grain
+var errActivationNotFound = errors.New("grain activation not found")
+
+// ErrRetryPolicyNotSupported is returned by RequestFuture for calls with a retry policy, the response of a future
+// is not retried
+var ErrRetryPolicyNotSupported = errors.New("retry policies only apply to Request, RequestFuture does not retry")
+
+// defaultMaxAttempts limits the attempts of the policies without MaxAttempts and Deadline
+const defaultMaxAttempts = 3
+
+// RetryPolicy decides whether and when a failed grain call is retried.
+// A policy is set per call with WithRetryPolicy, per kind with Kind.WithRetryPolicy or for the cluster with
+// WithGrainRetryPolicy, the most specific one applies. Calls without policy retry with RetryCount and RetryAction.
+// Policies apply to Request, RequestFuture only retries the lookup of the activation with RetryCount.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts of the call including the first one, 0 means the attempts are only
+	// limited by the Deadline, a policy without both makes 3 attempts
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between two attempts
+	MaxBackoff time.Duration
+	// Multiplier is the growth of the delay after each retry
+	Multiplier float64
+	// Jitter randomizes each delay by up to this fraction of it, so callers failing together do not retry together
+	Jitter float64
+	// Deadline is the time budget of all attempts and delays of the call, 0 means no budget
+	Deadline time.Duration
+	// RetryableReasons are the reasons of the grain error responses which are retried, see ErrorReason_UNAVAILABLE.
+	// Timeouts and dead letters are always retried.
+	RetryableReasons []string
+}
+
+// DefaultRetryPolicy returns a policy of 3 attempts with an exponential backoff from 50ms to 1s,
+// retrying the grain errors which are usually transient
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 50 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		RetryableReasons: []string{
+			ErrorReason_UNAVAILABLE,
+			ErrorReason_RESOURCE_EXHAUSTED,
+			ErrorReason_ABORTED,
+			ErrorReason_DEADLINE_EXCEEDED,
+		},
+	}
+}
+
+// attemptsLimit returns the number of attempts of the policy, 0 when they are only limited by the Deadline
+func (p *RetryPolicy) attemptsLimit() int {
+	if p.MaxAttempts <= 0 && p.Deadline <= 0 {
+		return defaultMaxAttempts
+	}
+
+	return p.MaxAttempts
+}
+
+// IsRetryable returns true if the error of an attempt is retried by the policy
+func (p *RetryPolicy) IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var grainErr *GrainErrorResponse
+	if errors.As(err, &grainErr) {
+		return contains(p.RetryableReasons, grainErr.Reason)
+	}
+
+	return isTransientRequestError(err)
+}
+
+// Backoff returns the delay before the given retry, starting at 1
+func (p *RetryPolicy) Backoff(retry int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	backoff := float64(p.InitialBackoff) * math.Pow(multiplier, float64(retry-1))
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		backoff += backoff * p.Jitter * (2*rand.Float64() - 1)
+	}
+
+	return time.Duration(backoff)
+}
+
+// isTransientRequestError returns true for the request errors which may succeed on another attempt
+func isTransientRequestError(err error) bool {
+	switch err {
+	case actor.ErrTimeout, remote.ErrTimeout, actor.ErrDeadLetter, remote.ErrDeadLetter, errActivationNotFound:
+		return true
+	default:
+		return false
+	}
+}
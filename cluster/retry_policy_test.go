@@ -0,0 +1,118 @@
+package cluster
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := &RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 2}
+
+	assert.Equal(t, 100*time.Millisecond, policy.Backoff(1))
+	assert.Equal(t, 200*time.Millisecond, policy.Backoff(2))
+	assert.Equal(t, 800*time.Millisecond, policy.Backoff(4))
+	assert.Equal(t, time.Second, policy.Backoff(5))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		backoff := policy.Backoff(2)
+		assert.GreaterOrEqual(t, backoff, 100*time.Millisecond)
+		assert.LessOrEqual(t, backoff, 300*time.Millisecond)
+	}
+}
+
+func TestRetryPolicyIsRetryable(t *testing.T) {
+	policy := DefaultRetryPolicy()
+
+	assert.True(t, policy.IsRetryable(actor.ErrTimeout))
+	assert.True(t, policy.IsRetryable(actor.ErrDeadLetter))
+	assert.True(t, policy.IsRetryable(NewGrainErrorResponse(ErrorReason_UNAVAILABLE, "")))
+	assert.True(t, policy.IsRetryable(fmt.Errorf("wrapped: %w", NewGrainErrorResponse(ErrorReason_ABORTED, ""))))
+	assert.False(t, policy.IsRetryable(NewGrainErrorResponse(ErrorReason_INVALID_ARGUMENT, "")))
+	assert.False(t, policy.IsRetryable(fmt.Errorf("other")))
+	assert.False(t, policy.IsRetryable(nil))
+}
+
+// slowIdentityLookup finds the activation after the delay
+type slowIdentityLookup struct {
+	fakeIdentityLookup
+	delay time.Duration
+	pid   *actor.PID
+}
+
+func (l *slowIdentityLookup) Get(*ClusterIdentity) *actor.PID {
+	time.Sleep(l.delay)
+	return l.pid
+}
+
+func TestAttemptTimeout(t *testing.T) {
+	ttl, ok := attemptTimeout(time.Second, time.Time{})
+	assert.True(t, ok)
+	assert.Equal(t, time.Second, ttl)
+
+	ttl, ok = attemptTimeout(time.Second, time.Now().Add(time.Minute))
+	assert.True(t, ok)
+	assert.Equal(t, time.Second, ttl)
+
+	ttl, ok = attemptTimeout(time.Second, time.Now().Add(100*time.Millisecond))
+	assert.True(t, ok)
+	assert.LessOrEqual(t, ttl, 100*time.Millisecond)
+
+	_, ok = attemptTimeout(time.Second, time.Now())
+	assert.False(t, ok)
+}
+
+func TestRequestAttemptTimesOutWhenTheLookupUsesUpTheDeadline(t *testing.T) {
+	c := newClusterForTest("test-request-attempt", nil)
+	silent := c.ActorSystem.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {}))
+	c.IdentityLookup = &slowIdentityLookup{delay: 50 * time.Millisecond, pid: silent}
+	dcc := newDefaultClusterContext(c).(*DefaultContext)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := dcc.requestAttempt("identity", "kind", "hello", DefaultGrainCallConfig(c), time.Now().Add(10*time.Millisecond))
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		assert.Equal(t, actor.ErrTimeout, err)
+	case <-time.After(time.Second):
+		assert.Fail(t, "the attempt did not time out at the deadline")
+	}
+}
+
+func TestRequestWithRetryPolicyWithoutLimitsIsBounded(t *testing.T) {
+	c := newClusterForTest("test-unbounded-policy", nil)
+	var attempts int
+	c.IdentityLookup = &countingIdentityLookup{attempts: &attempts}
+	dcc := newDefaultClusterContext(c).(*DefaultContext)
+
+	policy := &RetryPolicy{InitialBackoff: time.Millisecond}
+	_, err := dcc.requestWithRetryPolicy("identity", "kind", "hello", DefaultGrainCallConfig(c), policy)
+	assert.ErrorIs(t, err, errActivationNotFound)
+	assert.Equal(t, defaultMaxAttempts, attempts)
+}
+
+// countingIdentityLookup never finds the activation and counts the lookups
+type countingIdentityLookup struct {
+	fakeIdentityLookup
+	attempts *int
+}
+
+func (l *countingIdentityLookup) Get(*ClusterIdentity) *actor.PID {
+	*l.attempts++
+	return nil
+}
+
+func TestRequestFutureRejectsRetryPolicies(t *testing.T) {
+	c := newClusterForTest("test-request-future-policy", nil)
+	dcc := newDefaultClusterContext(c).(*DefaultContext)
+
+	_, err := dcc.RequestFuture("identity", "kind", "hello", WithRetryPolicy(DefaultRetryPolicy()))
+	assert.Equal(t, ErrRetryPolicyNotSupported, err)
+}
@@ -0,0 +1,484 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/eventstream"
+)
+
+const SingletonManagerName = "singleton-manager"
+
+// ErrSingletonsNotStarted is returned when a singleton proxy is requested before the cluster member started
+var ErrSingletonsNotStarted = errors.New("singletons are only available on started cluster members")
+
+// SingletonHostSelector selects the member hosting a singleton kind from the members supporting the kind.
+// startedAt holds the time each member started its singleton manager, for the members which gossiped it so far.
+// Returning nil keeps the singleton where it is until the selection can be made.
+type SingletonHostSelector func(c *Cluster, candidates Members, startedAt map[string]int64) *Member
+
+// OldestMemberSelector hosts singletons on the member which has been up the longest.
+// A joining member never takes a singleton over, so singletons only move when their host leaves.
+func OldestMemberSelector(_ *Cluster, candidates Members, startedAt map[string]int64) *Member {
+	var oldest *Member
+	for _, member := range candidates {
+		started, ok := startedAt[member.Id]
+		if !ok {
+			// the age of the member is not known yet
+			return nil
+		}
+
+		if oldest == nil || started < startedAt[oldest.Id] || (started == startedAt[oldest.Id] && member.Id < oldest.Id) {
+			oldest = member
+		}
+	}
+
+	return oldest
+}
+
+// LeaderMemberSelector hosts singletons on the elected leader, see LeaderElection.
+// Kinds the leader does not support are hosted on the oldest member supporting them.
+func LeaderMemberSelector(c *Cluster, candidates Members, startedAt map[string]int64) *Member {
+	leader, _ := c.LeaderElection.Leader()
+	if leader == nil {
+		return nil
+	}
+
+	for _, member := range candidates {
+		if member.Id == leader.Id {
+			return member
+		}
+	}
+
+	return OldestMemberSelector(c, candidates, startedAt)
+}
+
+type SingletonConfig struct {
+	// HostSelector selects the member hosting each singleton kind. Default is OldestMemberSelector.
+	HostSelector SingletonHostSelector
+	// ProxyBufferSize is the number of messages a singleton proxy buffers while no activation is available.
+	// Default is 10000.
+	ProxyBufferSize int
+}
+
+func newSingletonConfig() *SingletonConfig {
+	return &SingletonConfig{
+		HostSelector:    OldestMemberSelector,
+		ProxyBufferSize: 10000,
+	}
+}
+
+// Singletons keeps exactly one activation of each singleton kind in the cluster.
+// A kind is declared as singleton with Kind.AsSingleton, it is hosted by one of the members supporting it
+// and moved to another member when the host leaves or drains. Draining members are never selected as host.
+type Singletons struct {
+	cluster *Cluster
+	manager *actor.PID
+	sub     *eventstream.Subscription
+}
+
+func newSingletons(c *Cluster) *Singletons {
+	return &Singletons{cluster: c}
+}
+
+// Start the singleton manager of the member
+func (s *Singletons) Start() {
+	system := s.cluster.ActorSystem
+	props := actor.PropsFromProducer(func() actor.Actor { return newSingletonManager(s.cluster) })
+
+	var err error
+	s.manager, err = system.Root.SpawnNamed(props, SingletonManagerName)
+	if err != nil {
+		panic(err) // let it crash
+	}
+
+	s.sub = system.EventStream.Subscribe(func(evt interface{}) {
+		switch msg := evt.(type) {
+		case *ClusterTopology, *LeaderChanged:
+			system.Root.Send(s.manager, msg)
+		case *GossipUpdate:
+			if msg.Key == SingletonsKey || msg.Key == DrainingKey {
+				system.Root.Send(s.manager, msg)
+			}
+		}
+	})
+	s.cluster.Logger().Info("Started Cluster Singletons")
+}
+
+// Stop the singleton manager, stopping the singletons hosted by the member
+func (s *Singletons) Stop() {
+	if s.manager == nil {
+		return
+	}
+
+	s.cluster.ActorSystem.EventStream.Unsubscribe(s.sub)
+	_ = s.cluster.ActorSystem.Root.PoisonFuture(s.manager).Wait()
+}
+
+// drain stops the singletons hosted by the draining member and waits until they terminated, the proxies of the
+// member keep forwarding to the singletons once they are started on other members
+func (s *Singletons) drain(ctx context.Context) error {
+	if s.manager == nil {
+		return nil
+	}
+
+	timeout := s.cluster.Config.DrainTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+
+	return s.cluster.ActorSystem.Root.RequestFuture(s.manager, &drainSingletons{}, timeout).Wait()
+}
+
+// Proxy returns the PID of a local proxy forwarding messages to the activation of the singleton kind.
+// The proxy buffers messages while the singleton is moved to another member.
+func (s *Singletons) Proxy(kind string) (*actor.PID, error) {
+	if s.manager == nil {
+		return nil, ErrSingletonsNotStarted
+	}
+
+	res, err := s.cluster.ActorSystem.Root.RequestFuture(s.manager, &getSingletonProxy{kind: kind}, s.cluster.Config.TimeoutTime).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*actor.PID), nil
+}
+
+type getSingletonProxy struct {
+	kind string
+}
+
+type drainSingletons struct{}
+
+type singletonsDrained struct{}
+
+type singletonTarget struct {
+	pid *actor.PID
+}
+
+// singletonManager hosts the singletons this member is selected for and keeps the proxies pointed at the current activations
+type singletonManager struct {
+	cluster   *Cluster
+	kinds     []string
+	startedAt int64
+	members   Members
+	states    map[string]*SingletonState
+	hosted    map[string]*actor.PID
+	// stopping holds the singletons handed over to another member until they terminated
+	stopping map[string]*actor.PID
+	proxies  map[string]*actor.PID
+	targets  map[string]*actor.PID
+	// stopped is set once the manager stops, its singletons are not started again while they terminate
+	stopped bool
+	// drained are the senders of drainSingletons waiting until the singletons terminated
+	drained []*actor.PID
+}
+
+func newSingletonManager(c *Cluster) *singletonManager {
+	kinds := make([]string, 0)
+	for name, kind := range c.kinds {
+		if kind.Singleton {
+			kinds = append(kinds, name)
+		}
+	}
+
+	return &singletonManager{
+		cluster:  c,
+		kinds:    kinds,
+		states:   map[string]*SingletonState{},
+		hosted:   map[string]*actor.PID{},
+		stopping: map[string]*actor.PID{},
+		proxies:  map[string]*actor.PID{},
+		targets:  map[string]*actor.PID{},
+	}
+}
+
+func (m *singletonManager) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *actor.Started:
+		m.startedAt = time.Now().UnixMilli()
+		m.members = m.cluster.MemberList.Members().Members()
+		m.publishState()
+		m.update(ctx)
+	case *actor.Stopping:
+		m.stopped = true
+	case *ClusterTopology:
+		m.members = msg.Members
+		for _, member := range msg.Left {
+			delete(m.states, member.Id)
+		}
+		m.update(ctx)
+	case *GossipUpdate:
+		if msg.Key == DrainingKey {
+			// the draining member no longer hosts singletons
+			m.update(ctx)
+			return
+		}
+
+		state := &SingletonState{}
+		if err := msg.Value.UnmarshalTo(state); err != nil {
+			ctx.Logger().Warn("Could not unpack singleton state", slog.String("member", msg.MemberID), slog.Any("error", err))
+			return
+		}
+		m.states[msg.MemberID] = state
+		m.update(ctx)
+	case *LeaderChanged:
+		m.update(ctx)
+	case *actor.Terminated:
+		m.onTerminated(msg, ctx)
+	case *getSingletonProxy:
+		ctx.Respond(m.getProxy(msg.kind, ctx))
+	case *drainSingletons:
+		m.onDrain(ctx)
+	}
+}
+
+// update starts and stops the local singletons and points the proxies to the current activations
+func (m *singletonManager) update(ctx actor.Context) {
+	myID := m.cluster.ActorSystem.ID
+	changed := false
+
+	for _, kind := range m.kinds {
+		host := m.selectHost(kind)
+		if host == nil {
+			continue
+		}
+
+		pid, hosting := m.hosted[kind]
+		switch {
+		case host.Id == myID && !hosting:
+			if _, stopping := m.stopping[kind]; stopping {
+				// started again once the previous activation terminated
+				continue
+			}
+
+			clusterKind := m.cluster.GetClusterKind(kind)
+			props := WithClusterIdentity(clusterKind.Props, NewClusterIdentity(kind, kind))
+			pid, err := ctx.SpawnNamed(props, kind)
+			if err != nil {
+				ctx.Logger().Error("Failed to start singleton", slog.String("kind", kind), slog.Any("error", err))
+				continue
+			}
+
+			ctx.Logger().Info("Started singleton", slog.String("kind", kind), slog.Any("pid", pid))
+			m.hosted[kind] = pid
+			changed = true
+		case host.Id != myID && hosting:
+			// hand the singleton over to the selected member
+			ctx.Logger().Info("Stopping singleton hosted by another member", slog.String("kind", kind), slog.String("host", host.Address()))
+			m.stopSingleton(kind, pid, ctx)
+			changed = true
+		}
+	}
+
+	if changed {
+		m.publishState()
+	}
+
+	for kind, proxy := range m.proxies {
+		m.updateProxy(kind, proxy, ctx)
+	}
+}
+
+// stopSingleton poisons the hosted singleton, the manager is notified once it terminated
+func (m *singletonManager) stopSingleton(kind string, pid *actor.PID, ctx actor.Context) {
+	ctx.Poison(pid)
+	m.stopping[kind] = pid
+	delete(m.hosted, kind)
+}
+
+// onDrain stops the singletons of the draining member, also those no other member can host, and responds once
+// they terminated
+func (m *singletonManager) onDrain(ctx actor.Context) {
+	m.update(ctx)
+	if len(m.hosted) > 0 {
+		for kind, pid := range m.hosted {
+			ctx.Logger().Info("Stopping singleton of the draining member", slog.String("kind", kind))
+			m.stopSingleton(kind, pid, ctx)
+		}
+		m.publishState()
+	}
+
+	m.drained = append(m.drained, ctx.Sender())
+	m.respondDrained(ctx)
+}
+
+func (m *singletonManager) respondDrained(ctx actor.Context) {
+	if len(m.stopping) > 0 || len(m.hosted) > 0 {
+		return
+	}
+
+	for _, sender := range m.drained {
+		ctx.Send(sender, &singletonsDrained{})
+	}
+	m.drained = nil
+}
+
+// onTerminated forgets the stopped singleton, and the crashed one, which is started again if this member still hosts it
+func (m *singletonManager) onTerminated(msg *actor.Terminated, ctx actor.Context) {
+	if m.stopped {
+		return
+	}
+
+	for kind, pid := range m.stopping {
+		if pid.Equal(msg.Who) {
+			delete(m.stopping, kind)
+			m.update(ctx)
+			m.respondDrained(ctx)
+			return
+		}
+	}
+
+	for kind, pid := range m.hosted {
+		if pid.Equal(msg.Who) {
+			ctx.Logger().Warn("Singleton terminated", slog.String("kind", kind), slog.Any("pid", pid))
+			delete(m.hosted, kind)
+			m.publishState()
+			m.update(ctx)
+			return
+		}
+	}
+}
+
+func (m *singletonManager) publishState() {
+	if len(m.kinds) == 0 {
+		return
+	}
+
+	activations := make(map[string]*actor.PID, len(m.hosted))
+	for kind, pid := range m.hosted {
+		activations[kind] = pid
+	}
+
+	state := &SingletonState{
+		StartedAt:   m.startedAt,
+		Activations: activations,
+	}
+	m.states[m.cluster.ActorSystem.ID] = state
+	m.cluster.Gossip.SetState(SingletonsKey, state)
+}
+
+func (m *singletonManager) selectHost(kind string) *Member {
+	candidates := make(Members, 0)
+	startedAt := make(map[string]int64)
+
+	for _, member := range m.members {
+		if !member.HasKind(kind) || m.cluster.MemberList.IsDraining(member.Id) {
+			continue
+		}
+
+		candidates = append(candidates, member)
+		if state, ok := m.states[member.Id]; ok {
+			startedAt[member.Id] = state.StartedAt
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	return m.cluster.Config.SingletonConfig.HostSelector(m.cluster, candidates, startedAt)
+}
+
+// activation returns the activation of the kind on the selected host, or nil while the host did not start it yet
+func (m *singletonManager) activation(kind string) *actor.PID {
+	host := m.selectHost(kind)
+	if host == nil {
+		return nil
+	}
+
+	state, ok := m.states[host.Id]
+	if !ok {
+		return nil
+	}
+
+	return state.Activations[kind]
+}
+
+func (m *singletonManager) getProxy(kind string, ctx actor.Context) *actor.PID {
+	if proxy, ok := m.proxies[kind]; ok {
+		return proxy
+	}
+
+	bufferSize := m.cluster.Config.SingletonConfig.ProxyBufferSize
+	props := actor.PropsFromProducer(func() actor.Actor { return &singletonProxy{kind: kind, bufferSize: bufferSize} })
+	proxy := ctx.SpawnPrefix(props, "proxy-"+kind)
+	m.proxies[kind] = proxy
+	m.updateProxy(kind, proxy, ctx)
+
+	return proxy
+}
+
+func (m *singletonManager) updateProxy(kind string, proxy *actor.PID, ctx actor.Context) {
+	target := m.activation(kind)
+	if current, ok := m.targets[kind]; ok && samePID(current, target) {
+		return
+	}
+
+	m.targets[kind] = target
+	ctx.Send(proxy, &singletonTarget{pid: target})
+}
+
+type bufferedMessage struct {
+	message interface{}
+	sender  *actor.PID
+}
+
+// singletonProxy forwards messages to the current activation of a singleton,
+// messages are buffered while the singleton is not available. The proxy watches the activation, so it buffers
+// again as soon as the activation terminates, before its host is known to have left.
+type singletonProxy struct {
+	kind       string
+	bufferSize int
+	target     *actor.PID
+	buffer     []bufferedMessage
+}
+
+func (p *singletonProxy) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *actor.Started, *actor.Stopping, *actor.Stopped, *actor.Restarting:
+	case *singletonTarget:
+		if p.target != nil && !p.target.Equal(msg.pid) {
+			ctx.Unwatch(p.target)
+		}
+		p.target = msg.pid
+		if p.target == nil {
+			return
+		}
+
+		ctx.Watch(p.target)
+
+		for _, buffered := range p.buffer {
+			ctx.RequestWithCustomSender(p.target, buffered.message, buffered.sender)
+		}
+		p.buffer = nil
+	case *actor.Terminated:
+		if p.target.Equal(msg.Who) {
+			ctx.Logger().Info("Singleton terminated, buffering until it is available again", slog.String("kind", p.kind), slog.Any("pid", msg.Who))
+			p.target = nil
+		}
+	default:
+		if p.target != nil {
+			ctx.Forward(p.target)
+			return
+		}
+
+		if len(p.buffer) >= p.bufferSize {
+			ctx.Logger().Warn("Singleton proxy buffer is full, dropping message", slog.String("kind", p.kind), slog.Any("message", msg))
+			return
+		}
+		p.buffer = append(p.buffer, bufferedMessage{message: msg, sender: ctx.Sender()})
+	}
+}
+
+func samePID(a, b *actor.PID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Equal(b)
+}
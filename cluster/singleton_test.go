@@ -0,0 +1,106 @@
+package cluster
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestOldestMemberSelector(t *testing.T) {
+	members := newMembersForTest(3)
+
+	startedAt := map[string]int64{
+		members[0].Id: 300,
+		members[1].Id: 100,
+		members[2].Id: 200,
+	}
+	assert.Equal(t, members[1], OldestMemberSelector(nil, members, startedAt))
+
+	// ties are broken by member id
+	startedAt[members[2].Id] = 100
+	assert.Equal(t, members[1], OldestMemberSelector(nil, members, startedAt))
+
+	// the selection waits until the age of every member is known
+	delete(startedAt, members[0].Id)
+	assert.Nil(t, OldestMemberSelector(nil, members, startedAt))
+}
+
+func TestSingletonProxyBuffersWhenTheActivationTerminates(t *testing.T) {
+	system := actor.NewActorSystem()
+	root := system.Root
+	echo := actor.PropsFromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(*wrapperspb.StringValue); ok {
+			ctx.Respond(msg)
+		}
+	})
+	proxy := root.Spawn(actor.PropsFromProducer(func() actor.Actor {
+		return &singletonProxy{kind: "kind", bufferSize: 10}
+	}))
+
+	first := root.Spawn(echo)
+	root.Send(proxy, &singletonTarget{pid: first})
+	res, err := root.RequestFuture(proxy, wrapperspb.String("first"), time.Second).Result()
+	require.NoError(t, err)
+	assert.Equal(t, "first", res.(*wrapperspb.StringValue).Value)
+
+	// the proxy is not told the activation moved yet, the message waits for the next activation
+	require.NoError(t, root.StopFuture(first).Wait())
+	future := root.RequestFuture(proxy, wrapperspb.String("second"), 5*time.Second)
+	time.Sleep(100 * time.Millisecond)
+	root.Send(proxy, &singletonTarget{pid: root.Spawn(echo)})
+
+	res, err = future.Result()
+	require.NoError(t, err)
+	assert.Equal(t, "second", res.(*wrapperspb.StringValue).Value)
+}
+
+func TestSingletonManagerHandsOverWithoutWaitingForTheSingleton(t *testing.T) {
+	var host atomic.Value
+	selector := func(_ *Cluster, candidates Members, _ map[string]int64) *Member {
+		for _, member := range candidates {
+			if member.Id == host.Load().(string) {
+				return member
+			}
+		}
+		return nil
+	}
+	var started atomic.Int32
+	kind := NewKind("kind", actor.PropsFromFunc(func(ctx actor.Context) {
+		switch ctx.Message().(type) {
+		case *actor.Started:
+			started.Add(1)
+		case *actor.Stopping:
+			time.Sleep(500 * time.Millisecond)
+		}
+	})).AsSingleton()
+
+	c := newClusterForTest("test-singleton-handover", nil, WithSingletonHostSelector(selector))
+	c.Gossip.throttler = func() actor.Valve { return actor.Closed }
+	c.kinds[kind.Kind] = kind.Build(c)
+	root := c.ActorSystem.Root
+	members := Members{
+		{Id: c.ActorSystem.ID, Host: "127.0.0.1", Port: 1, Kinds: []string{kind.Kind}},
+		{Id: "other", Host: "127.0.0.1", Port: 2, Kinds: []string{kind.Kind}},
+	}
+
+	host.Store(c.ActorSystem.ID)
+	manager := root.Spawn(actor.PropsFromProducer(func() actor.Actor { return newSingletonManager(c) }))
+	root.Send(manager, &ClusterTopology{Members: members})
+	require.Eventually(t, func() bool { return started.Load() == 1 }, time.Second, 10*time.Millisecond)
+
+	// the manager keeps answering while the singleton stops
+	host.Store("other")
+	root.Send(manager, &LeaderChanged{})
+	_, err := root.RequestFuture(manager, &getSingletonProxy{kind: kind.Kind}, 200*time.Millisecond).Result()
+	require.NoError(t, err)
+
+	// the singleton is started again once the previous activation terminated
+	host.Store(c.ActorSystem.ID)
+	root.Send(manager, &LeaderChanged{})
+	require.Eventually(t, func() bool { return started.Load() == 2 }, 2*time.Second, 10*time.Millisecond)
+}
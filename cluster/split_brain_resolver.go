@@ -0,0 +1,250 @@
+package cluster
+
+import (
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NetworkPartition describes the sides of a network partition as seen by a member
+type NetworkPartition struct {
+	// Reachable are the members of the side of this member, including this member
+	Reachable Members
+	// Unreachable are the members of the other sides, their heartbeats expired
+	Unreachable Members
+	// StartedAt holds the unix milliseconds each member started at, for the members which gossiped it
+	StartedAt map[string]int64
+}
+
+// SplitBrainStrategy decides whether the side of this member keeps running after a network partition.
+// The members of each side decide independently, so the strategy must come to opposite decisions on the
+// opposite sides of the same partition.
+type SplitBrainStrategy func(partition *NetworkPartition) bool
+
+// KeepMajority keeps the side with the most members. On equal sides, the side with the member with the
+// lowest id is kept.
+func KeepMajority(partition *NetworkPartition) bool {
+	reachable, unreachable := len(partition.Reachable), len(partition.Unreachable)
+	if reachable != unreachable {
+		return reachable > unreachable
+	}
+
+	return lowestID(partition.Reachable) < lowestID(partition.Unreachable)
+}
+
+// KeepOldest keeps the side of the member which has been up the longest, as gossiped in its heartbeat.
+func KeepOldest(partition *NetworkPartition) bool {
+	oldest := oldestMember(partition.Reachable, partition.StartedAt)
+	other := oldestMember(partition.Unreachable, partition.StartedAt)
+	if oldest == nil || other == nil {
+		return other == nil
+	}
+
+	return oldestMember(Members{oldest, other}, partition.StartedAt) == oldest
+}
+
+// KeepReferee keeps the side of the member with the address, host:port. All other sides are downed, and so
+// is the whole cluster when the referee itself is lost.
+func KeepReferee(address string) SplitBrainStrategy {
+	return func(partition *NetworkPartition) bool {
+		for _, member := range partition.Reachable {
+			if member.Address() == address {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// StaticQuorum keeps the sides with at least quorumSize members. The quorum should be more than half of the
+// members of the cluster, otherwise both sides of a partition can keep running.
+func StaticQuorum(quorumSize int) SplitBrainStrategy {
+	return func(partition *NetworkPartition) bool {
+		return len(partition.Reachable) >= quorumSize
+	}
+}
+
+func lowestID(members Members) string {
+	var id string
+	for _, member := range members {
+		if id == "" || member.Id < id {
+			id = member.Id
+		}
+	}
+
+	return id
+}
+
+// oldestMember returns the member which started first, members with an unknown start are considered younger
+// than all others
+func oldestMember(members Members, startedAt map[string]int64) *Member {
+	var oldest *Member
+	for _, member := range members {
+		if oldest == nil {
+			oldest = member
+			continue
+		}
+
+		started, ok := startedAt[member.Id]
+		oldestStarted, oldestOk := startedAt[oldest.Id]
+		switch {
+		case ok && !oldestOk:
+			oldest = member
+		case ok == oldestOk && (started < oldestStarted || (started == oldestStarted && member.Id < oldest.Id)):
+			oldest = member
+		}
+	}
+
+	return oldest
+}
+
+type SplitBrainResolverConfig struct {
+	// Strategy decides which side of a network partition keeps running. Default is nil, the members with
+	// expired heartbeats are blocked without resolving the partition, so each side keeps running.
+	Strategy SplitBrainStrategy
+	// StableAfter is the time the unreachable members must not change before the partition is resolved.
+	// Default is 10s.
+	StableAfter time.Duration
+	// Down is called on the members of the sides which are downed. Default shuts the member down.
+	Down func(c *Cluster)
+}
+
+func newSplitBrainResolverConfig() *SplitBrainResolverConfig {
+	return &SplitBrainResolverConfig{
+		StableAfter: time.Second * 10,
+		Down: func(c *Cluster) {
+			c.Shutdown(false)
+		},
+	}
+}
+
+// SplitBrainResolved is published on the EventStream when a member resolved a network partition
+type SplitBrainResolved struct {
+	Partition *NetworkPartition
+	// Downed is true on the members of the sides which are downed
+	Downed bool
+}
+
+// SplitBrainResolver resolves network partitions, so the sides of a partition do not keep running with their own
+// topology and activate the same grains.
+// The members whose heartbeats expired are unreachable. Once the unreachable members did not change for
+// StableAfter, the strategy decides whether the side of this member keeps running. The kept side blocks the
+// unreachable members, the block list is gossiped with the topology. The members of the other sides down
+// themselves.
+type SplitBrainResolver struct {
+	cluster *Cluster
+
+	mu          sync.Mutex
+	unreachable map[string]empty
+	stableSince time.Time
+	downed      bool
+}
+
+func newSplitBrainResolver(c *Cluster) *SplitBrainResolver {
+	return &SplitBrainResolver{cluster: c, unreachable: map[string]empty{}}
+}
+
+// enabled returns true if the expired heartbeats are resolved by the split brain resolver
+func (sbr *SplitBrainResolver) enabled() bool {
+	return sbr.cluster.Config.SplitBrainResolverConfig.Strategy != nil
+}
+
+// onHeartbeatsExpired updates the unreachable members to the members whose heartbeats expired, and resolves the
+// partition once they are stable
+func (sbr *SplitBrainResolver) onHeartbeatsExpired(memberIDs []string) {
+	sbr.mu.Lock()
+	defer sbr.mu.Unlock()
+
+	if sbr.downed {
+		return
+	}
+
+	unreachable := make(map[string]empty, len(memberIDs))
+	for _, id := range memberIDs {
+		unreachable[id] = empty{}
+	}
+	if !sameMemberIDs(unreachable, sbr.unreachable) {
+		sbr.unreachable = unreachable
+		sbr.stableSince = time.Now()
+		return
+	}
+	if len(unreachable) == 0 || time.Since(sbr.stableSince) < sbr.cluster.Config.SplitBrainResolverConfig.StableAfter {
+		return
+	}
+
+	sbr.resolve()
+}
+
+func (sbr *SplitBrainResolver) resolve() {
+	partition := sbr.partition()
+	if len(partition.Unreachable) == 0 {
+		return
+	}
+
+	survives := sbr.cluster.Config.SplitBrainResolverConfig.Strategy(partition)
+	sbr.cluster.ActorSystem.EventStream.Publish(&SplitBrainResolved{Partition: partition, Downed: !survives})
+
+	unreachable := make([]string, 0, len(partition.Unreachable))
+	for _, member := range partition.Unreachable {
+		unreachable = append(unreachable, member.Id)
+	}
+	sort.Strings(unreachable)
+
+	if !survives {
+		sbr.cluster.Logger().Warn("Downing member, its side of the network partition is not kept",
+			slog.Int("reachable", len(partition.Reachable)), slog.String("unreachable", strings.Join(unreachable, ",")))
+		sbr.downed = true
+		sbr.cluster.Config.SplitBrainResolverConfig.Down(sbr.cluster)
+		return
+	}
+
+	sbr.cluster.Logger().Info("Blocking the members on the other side of the network partition",
+		slog.Int("reachable", len(partition.Reachable)), slog.String("members", strings.Join(unreachable, ",")))
+	sbr.cluster.Remote.BlockList().Block(unreachable...)
+	sbr.unreachable = map[string]empty{}
+	sbr.cluster.MemberList.refreshTopology()
+}
+
+// partition returns the sides of the partition among the members of the topology
+func (sbr *SplitBrainResolver) partition() *NetworkPartition {
+	blockList := sbr.cluster.Remote.BlockList()
+	partition := &NetworkPartition{StartedAt: make(map[string]int64)}
+	for _, member := range sbr.cluster.MemberList.Members().Members() {
+		if blockList.IsBlocked(member.Id) {
+			continue
+		}
+		if _, ok := sbr.unreachable[member.Id]; ok {
+			partition.Unreachable = append(partition.Unreachable, member)
+		} else {
+			partition.Reachable = append(partition.Reachable, member)
+		}
+	}
+
+	heartbeats, err := NewGossipKey[*MemberHeartbeat](sbr.cluster.Gossip, HearthbeatKey).Get()
+	if err != nil {
+		sbr.cluster.Logger().Warn("Could not get the start of the members", slog.Any("error", err))
+	}
+	for memberID, heartbeat := range heartbeats {
+		if heartbeat.StartedAt > 0 {
+			partition.StartedAt[memberID] = heartbeat.StartedAt
+		}
+	}
+
+	return partition
+}
+
+func sameMemberIDs(a, b map[string]empty) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for id := range a {
+		if _, ok := b[id]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
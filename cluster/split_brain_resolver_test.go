@@ -0,0 +1,73 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testPartition(reachable, unreachable []string) (*NetworkPartition, *NetworkPartition) {
+	side := func(ids []string) Members {
+		members := make(Members, 0, len(ids))
+		for i, id := range ids {
+			members = append(members, &Member{Id: id, Host: "host-" + id, Port: int32(i)})
+		}
+		return members
+	}
+
+	return &NetworkPartition{Reachable: side(reachable), Unreachable: side(unreachable), StartedAt: map[string]int64{}},
+		&NetworkPartition{Reachable: side(unreachable), Unreachable: side(reachable), StartedAt: map[string]int64{}}
+}
+
+func TestKeepMajority(t *testing.T) {
+	majority, minority := testPartition([]string{"a", "b", "c"}, []string{"d", "e"})
+	assert.True(t, KeepMajority(majority))
+	assert.False(t, KeepMajority(minority))
+
+	// on equal sides, the side of the lowest id is kept
+	lowest, other := testPartition([]string{"d", "a"}, []string{"b", "c"})
+	assert.True(t, KeepMajority(lowest))
+	assert.False(t, KeepMajority(other))
+}
+
+func TestKeepOldest(t *testing.T) {
+	oldestSide, other := testPartition([]string{"c"}, []string{"a", "b"})
+	for _, partition := range []*NetworkPartition{oldestSide, other} {
+		partition.StartedAt["a"] = 200
+		partition.StartedAt["b"] = 300
+		partition.StartedAt["c"] = 100
+	}
+	assert.True(t, KeepOldest(oldestSide))
+	assert.False(t, KeepOldest(other))
+
+	// members with an unknown start are younger than all others
+	known, unknown := testPartition([]string{"c"}, []string{"a"})
+	known.StartedAt["c"] = 100
+	unknown.StartedAt["c"] = 100
+	assert.True(t, KeepOldest(known))
+	assert.False(t, KeepOldest(unknown))
+
+	// members which started at the same time are ordered by id
+	lowest, other := testPartition([]string{"a"}, []string{"b"})
+	assert.True(t, KeepOldest(lowest))
+	assert.False(t, KeepOldest(other))
+}
+
+func TestKeepReferee(t *testing.T) {
+	refereeSide, other := testPartition([]string{"a"}, []string{"b", "c"})
+	strategy := KeepReferee(refereeSide.Reachable[0].Address())
+	assert.True(t, strategy(refereeSide))
+	assert.False(t, strategy(other))
+}
+
+func TestStaticQuorum(t *testing.T) {
+	strategy := StaticQuorum(3)
+	quorum, other := testPartition([]string{"a", "b", "c"}, []string{"d", "e"})
+	assert.True(t, strategy(quorum))
+	assert.False(t, strategy(other))
+
+	// no side has the quorum
+	left, right := testPartition([]string{"a", "b"}, []string{"c", "d"})
+	assert.False(t, strategy(left))
+	assert.False(t, strategy(right))
+}
@@ -28,6 +28,9 @@ func NextContextExtensionID() ContextExtensionID {
 }
 
 func (ex *ContextExtensions) Get(id ContextExtensionID) ContextExtension {
+	if int(id) >= len(ex.extensions) {
+		return nil
+	}
 	return ex.extensions[id]
 }
 
@@ -0,0 +1,96 @@
+// Copyright (C) 2017 - 2022 Asynkron.se <http://www.asynkron.se>
+
+package metrics
+
+import (
+	"fmt"
+	"log/slog"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+type GossipMetrics struct {
+	GossipMessagesSent     metric.Int64ObservableCounter
+	GossipMessagesReceived metric.Int64ObservableCounter
+	GossipBytesSent        metric.Int64ObservableCounter
+	GossipBytesReceived    metric.Int64ObservableCounter
+	GossipDigestExchanges  metric.Int64ObservableCounter
+	GossipConvergenceTime  metric.Float64ObservableGauge
+}
+
+// NewGossipMetrics creates a new GossipMetrics value and returns a pointer to it
+func NewGossipMetrics(logger *slog.Logger) *GossipMetrics {
+	meter := otel.Meter(LibName)
+	instruments := GossipMetrics{}
+
+	var err error
+
+	if instruments.GossipMessagesSent, err = meter.Int64ObservableCounter(
+		"protoactor_cluster_gossip_messages_sent",
+		metric.WithDescription("Number of gossip messages sent"),
+		metric.WithUnit("1"),
+	); err != nil {
+		err = fmt.Errorf("failed to create GossipMessagesSent instrument, %w", err)
+		logger.Error(err.Error(), slog.Any("error", err))
+	}
+
+	if instruments.GossipMessagesReceived, err = meter.Int64ObservableCounter(
+		"protoactor_cluster_gossip_messages_received",
+		metric.WithDescription("Number of gossip messages received"),
+		metric.WithUnit("1"),
+	); err != nil {
+		err = fmt.Errorf("failed to create GossipMessagesReceived instrument, %w", err)
+		logger.Error(err.Error(), slog.Any("error", err))
+	}
+
+	if instruments.GossipBytesSent, err = meter.Int64ObservableCounter(
+		"protoactor_cluster_gossip_bytes_sent",
+		metric.WithDescription("Number of bytes of the gossip messages sent"),
+		metric.WithUnit("By"),
+	); err != nil {
+		err = fmt.Errorf("failed to create GossipBytesSent instrument, %w", err)
+		logger.Error(err.Error(), slog.Any("error", err))
+	}
+
+	if instruments.GossipBytesReceived, err = meter.Int64ObservableCounter(
+		"protoactor_cluster_gossip_bytes_received",
+		metric.WithDescription("Number of bytes of the gossip messages received"),
+		metric.WithUnit("By"),
+	); err != nil {
+		err = fmt.Errorf("failed to create GossipBytesReceived instrument, %w", err)
+		logger.Error(err.Error(), slog.Any("error", err))
+	}
+
+	if instruments.GossipDigestExchanges, err = meter.Int64ObservableCounter(
+		"protoactor_cluster_gossip_digest_exchanges",
+		metric.WithDescription("Number of gossip digest exchanges completed"),
+		metric.WithUnit("1"),
+	); err != nil {
+		err = fmt.Errorf("failed to create GossipDigestExchanges instrument, %w", err)
+		logger.Error(err.Error(), slog.Any("error", err))
+	}
+
+	if instruments.GossipConvergenceTime, err = meter.Float64ObservableGauge(
+		"protoactor_cluster_gossip_convergence_time_seconds",
+		metric.WithDescription("Time the last converged change of the gossip state of a member took to reach all members in seconds"),
+		metric.WithUnit("s"),
+	); err != nil {
+		err = fmt.Errorf("failed to create GossipConvergenceTime instrument, %w", err)
+		logger.Error(err.Error(), slog.Any("error", err))
+	}
+
+	return &instruments
+}
+
+// Observables returns all the instruments that have to be observed by a callback
+func (gm *GossipMetrics) Observables() []metric.Observable {
+	return []metric.Observable{
+		gm.GossipMessagesSent,
+		gm.GossipMessagesReceived,
+		gm.GossipBytesSent,
+		gm.GossipBytesReceived,
+		gm.GossipDigestExchanges,
+		gm.GossipConvergenceTime,
+	}
+}
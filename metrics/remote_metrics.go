@@ -0,0 +1,86 @@
+// Copyright (C) 2017 - 2022 Asynkron.se <http://www.asynkron.se>
+
+package metrics
+
+import (
+	"fmt"
+	"log/slog"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+type RemoteMetrics struct {
+	// Endpoints
+	EndpointReconnectCount metric.Int64ObservableCounter
+	EndpointRoundTripTime  metric.Float64ObservableGauge
+	EndpointQueueLength    metric.Int64ObservableGauge
+	EndpointBytesSent      metric.Int64ObservableCounter
+	EndpointBytesReceived  metric.Int64ObservableCounter
+}
+
+// NewRemoteMetrics creates a new RemoteMetrics value and returns a pointer to it
+func NewRemoteMetrics(logger *slog.Logger) *RemoteMetrics {
+	meter := otel.Meter(LibName)
+	instruments := RemoteMetrics{}
+
+	var err error
+
+	if instruments.EndpointReconnectCount, err = meter.Int64ObservableCounter(
+		"protoactor_remote_endpoint_reconnect_count",
+		metric.WithDescription("Number of times an endpoint reconnected"),
+		metric.WithUnit("1"),
+	); err != nil {
+		err = fmt.Errorf("failed to create EndpointReconnectCount instrument, %w", err)
+		logger.Error(err.Error(), slog.Any("error", err))
+	}
+
+	if instruments.EndpointRoundTripTime, err = meter.Float64ObservableGauge(
+		"protoactor_remote_endpoint_round_trip_time_seconds",
+		metric.WithDescription("Last measured heartbeat round-trip time of an endpoint in seconds"),
+		metric.WithUnit("s"),
+	); err != nil {
+		err = fmt.Errorf("failed to create EndpointRoundTripTime instrument, %w", err)
+		logger.Error(err.Error(), slog.Any("error", err))
+	}
+
+	if instruments.EndpointQueueLength, err = meter.Int64ObservableGauge(
+		"protoactor_remote_endpoint_queue_length",
+		metric.WithDescription("Number of messages waiting in an endpoint writer queue"),
+		metric.WithUnit("1"),
+	); err != nil {
+		err = fmt.Errorf("failed to create EndpointQueueLength instrument, %w", err)
+		logger.Error(err.Error(), slog.Any("error", err))
+	}
+
+	if instruments.EndpointBytesSent, err = meter.Int64ObservableCounter(
+		"protoactor_remote_endpoint_bytes_sent",
+		metric.WithDescription("Number of bytes sent to an endpoint"),
+		metric.WithUnit("By"),
+	); err != nil {
+		err = fmt.Errorf("failed to create EndpointBytesSent instrument, %w", err)
+		logger.Error(err.Error(), slog.Any("error", err))
+	}
+
+	if instruments.EndpointBytesReceived, err = meter.Int64ObservableCounter(
+		"protoactor_remote_endpoint_bytes_received",
+		metric.WithDescription("Number of bytes received from an endpoint"),
+		metric.WithUnit("By"),
+	); err != nil {
+		err = fmt.Errorf("failed to create EndpointBytesReceived instrument, %w", err)
+		logger.Error(err.Error(), slog.Any("error", err))
+	}
+
+	return &instruments
+}
+
+// Observables returns all the instruments that have to be observed by a callback
+func (rm *RemoteMetrics) Observables() []metric.Observable {
+	return []metric.Observable{
+		rm.EndpointReconnectCount,
+		rm.EndpointRoundTripTime,
+		rm.EndpointQueueLength,
+		rm.EndpointBytesSent,
+		rm.EndpointBytesReceived,
+	}
+}
@@ -70,6 +70,9 @@ func (r *Remote) SpawnNamed(address, name, kind string, timeout time.Duration) (
 	}
 	switch msg := res.(type) {
 	case *ActorPidResponse:
+		if msg.StatusCode == ResponseStatusCodeUNKNOWNKIND.ToInt32() {
+			return nil, ErrUnknownKind
+		}
 		return msg, nil
 	default:
 		return nil, errors.New("remote: Unknown response when remote activating")
@@ -90,16 +93,21 @@ func (a *activator) Receive(context actor.Context) {
 		context.Logger().Info("Started Activator")
 	case *Ping:
 		context.Respond(&Pong{})
+	case *ActivatorStatusRequest:
+		context.Respond(a.remote.activatorStatus())
+	case *actor.Terminated:
+		a.remote.activatorActors.Store(int32(len(context.Children())))
 	case *ActorPidRequest:
 		props, exist := a.remote.kinds[msg.Kind]
 
-		// if props not exist, return error and panic
+		// unknown kinds are a caller error, there is no need to restart the activator
 		if !exist {
+			context.Logger().Warn("Activator received request for unknown kind", slog.String("kind", msg.Kind))
 			response := &ActorPidResponse{
-				StatusCode: ResponseStatusCodeERROR.ToInt32(),
+				StatusCode: ResponseStatusCodeUNKNOWNKIND.ToInt32(),
 			}
 			context.Respond(response)
-			panic(fmt.Errorf("no Props found for kind %s", msg.Kind))
+			return
 		}
 
 		name := msg.Name
@@ -110,6 +118,7 @@ func (a *activator) Receive(context actor.Context) {
 		}
 
 		pid, err := context.SpawnNamed(props, "Remote$"+name)
+		a.remote.activatorActors.Store(int32(len(context.Children())))
 
 		if err == nil {
 			response := &ActorPidResponse{Pid: pid}
@@ -141,3 +150,11 @@ func (a *activator) Receive(context actor.Context) {
 		context.Logger().Error("Activator received unknown message", slog.Any("message", msg))
 	}
 }
+
+func (r *Remote) labels() map[string]string {
+	labels := make(map[string]string, len(r.config.Labels))
+	for k, v := range r.config.Labels {
+		labels[k] = v
+	}
+	return labels
+}
@@ -0,0 +1,69 @@
+package remote
+
+import (
+	"github.com/asynkron/protoactor-go/actor"
+)
+
+// SendWithBackpressure sends a message to a remote PID, unlike a regular send it fails with
+// ErrEndpointCongested instead of queueing the message when the endpoint to the PID is congested
+func (r *Remote) SendWithBackpressure(pid *actor.PID, message interface{}) error {
+	if pid.Address == r.actorSystem.Address() {
+		r.actorSystem.Root.Send(pid, message)
+		return nil
+	}
+
+	if r.IsCongested(pid.Address) {
+		return ErrEndpointCongested
+	}
+
+	header, msg, sender := actor.UnwrapEnvelope(message)
+	r.SendMessage(pid, header, msg, sender, -1)
+	return nil
+}
+
+// IsCongested returns true when the endpoint writer queue of the given address is above the high watermark
+func (r *Remote) IsCongested(address string) bool {
+	if r.config.EndpointWriterHighWatermark <= 0 {
+		return false
+	}
+
+	// an address without endpoint has nothing queued
+	h, ok := r.endpointHealth.lookup(address)
+	if !ok {
+		return false
+	}
+	return h.congested.Load() || h.queueLength() >= r.config.EndpointWriterHighWatermark
+}
+
+// checkCongested flags the endpoint as congested once its queue reaches the high watermark
+func (r *Remote) checkCongested(h *endpointHealth) {
+	high := r.config.EndpointWriterHighWatermark
+	if high <= 0 || h.congested.Load() {
+		return
+	}
+
+	length := h.queueLength()
+	if length >= high && h.congested.CompareAndSwap(false, true) {
+		r.actorSystem.EventStream.Publish(&EndpointCongestedEvent{Address: h.address, QueueLength: length})
+	}
+}
+
+// checkDecongested clears the congestion flag once the queue of a congested endpoint drains to the low watermark
+func (r *Remote) checkDecongested(h *endpointHealth) {
+	if !h.congested.Load() {
+		return
+	}
+
+	length := h.queueLength()
+	if length <= r.config.EndpointWriterLowWatermark && h.congested.CompareAndSwap(true, false) {
+		r.actorSystem.EventStream.Publish(&EndpointDecongestedEvent{Address: h.address, QueueLength: length})
+	}
+}
+
+// clearCongested resets the congestion flag of an endpoint whose writer has terminated,
+// the messages still queued for the terminated writer will never be sent
+func (r *Remote) clearCongested(h *endpointHealth) {
+	if h.congested.CompareAndSwap(true, false) {
+		r.actorSystem.EventStream.Publish(&EndpointDecongestedEvent{Address: h.address})
+	}
+}
@@ -0,0 +1,75 @@
+package remote
+
+import (
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemote_SendWithBackpressure(t *testing.T) {
+	system := actor.NewActorSystem()
+	config := Configure("localhost", 0, WithEndpointWriterWatermarks(0, 5))
+	config.MaxRetryCount = 1
+	remote := NewRemote(system, config)
+	remote.Start()
+	defer remote.Shutdown(true)
+
+	congested := make(chan *EndpointCongestedEvent, 1)
+	decongested := make(chan *EndpointDecongestedEvent, 1)
+	sub := system.EventStream.Subscribe(func(evt interface{}) {
+		switch e := evt.(type) {
+		case *EndpointCongestedEvent:
+			congested <- e
+		case *EndpointDecongestedEvent:
+			decongested <- e
+		}
+	})
+	defer system.EventStream.Unsubscribe(sub)
+
+	// nothing listens on this address, the endpoint writer keeps the queue filled while it tries to connect
+	pid := actor.NewPID("localhost:1", "target")
+	assert.NoError(t, remote.SendWithBackpressure(pid, &ActorPidRequest{}))
+	for i := 0; i < 10; i++ {
+		system.Root.Send(pid, &ActorPidRequest{})
+	}
+
+	select {
+	case e := <-congested:
+		assert.Equal(t, "localhost:1", e.Address)
+		assert.GreaterOrEqual(t, e.QueueLength, 5)
+	case <-time.After(time.Second):
+		t.Fatal("endpoint was not reported as congested")
+	}
+
+	assert.True(t, remote.IsCongested("localhost:1"))
+	assert.Equal(t, ErrEndpointCongested, remote.SendWithBackpressure(pid, &ActorPidRequest{}))
+
+	select {
+	case e := <-decongested:
+		assert.Equal(t, "localhost:1", e.Address)
+	case <-time.After(10 * time.Second):
+		t.Fatal("endpoint was not reported as decongested")
+	}
+	assert.False(t, remote.IsCongested("localhost:1"))
+}
+
+func TestRemote_IsCongestedDoesNotRegisterEndpoints(t *testing.T) {
+	remote := NewRemote(actor.NewActorSystem(), Configure("localhost", 0, WithEndpointWriterWatermarks(0, 5)))
+
+	assert.False(t, remote.IsCongested("localhost:2"))
+	_, ok := remote.EndpointStats("localhost:2")
+	assert.False(t, ok)
+	assert.Empty(t, remote.AllEndpointStats())
+}
+
+func TestWithEndpointWriterWatermarks_RejectsInvalidWatermarks(t *testing.T) {
+	assert.NoError(t, Configure("localhost", 0, WithEndpointWriterWatermarks(0, 5)).Validate())
+
+	for _, watermarks := range [][2]int{{5, 5}, {6, 5}, {-1, 5}, {0, 0}, {0, -1}} {
+		config := Configure("localhost", 0, WithEndpointWriterWatermarks(watermarks[0], watermarks[1]))
+		assert.Error(t, config.Validate(), "watermarks %v", watermarks)
+		assert.Equal(t, 0, config.EndpointWriterHighWatermark)
+	}
+}
@@ -1,6 +1,11 @@
 package remote
 
-import "google.golang.org/grpc"
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+)
 
 type ConfigOption func(config *Config)
 
@@ -18,6 +23,20 @@ func WithEndpointWriterQueueSize(queueSize int) ConfigOption {
 	}
 }
 
+// WithEndpointWriterWatermarks enables flow control on the endpoint writers, an endpoint becomes congested
+// when its queue reaches the high watermark and recovers once it drains to the low watermark.
+// The low watermark must not be negative and must be below the high watermark, see Config.Validate
+func WithEndpointWriterWatermarks(low, high int) ConfigOption {
+	return func(config *Config) {
+		if low < 0 || low >= high {
+			config.errs = append(config.errs, fmt.Errorf("invalid endpoint writer watermarks, low %d must be at least 0 and below high %d", low, high))
+			return
+		}
+		config.EndpointWriterLowWatermark = low
+		config.EndpointWriterHighWatermark = high
+	}
+}
+
 // WithEndpointManagerBatchSize sets the batch size for the endpoint manager
 func WithEndpointManagerBatchSize(batchSize int) ConfigOption {
 	return func(config *Config) {
@@ -68,3 +87,54 @@ func WithKinds(kinds ...*Kind) ConfigOption {
 		}
 	}
 }
+
+// WithHeartbeatInterval sets how often endpoint writers send heartbeats to measure the round-trip time,
+// a zero interval disables heartbeats
+func WithHeartbeatInterval(interval time.Duration) ConfigOption {
+	return func(config *Config) {
+		config.HeartbeatInterval = interval
+	}
+}
+
+// WithFailureDetector enables the phi accrual failure detector on the endpoint heartbeats
+func WithFailureDetector(config *FailureDetectorConfig) ConfigOption {
+	return func(c *Config) {
+		c.FailureDetector = config
+	}
+}
+
+// WithLabels adds labels that the activator reports to placement policies
+func WithLabels(labels map[string]string) ConfigOption {
+	return func(config *Config) {
+		for k, v := range labels {
+			config.Labels[k] = v
+		}
+	}
+}
+
+// WithSerializer registers a serializer under an explicit serializer ID
+func WithSerializer(serializerID int32, serializer Serializer) ConfigOption {
+	return func(config *Config) {
+		if err := config.Serializers.Register(serializerID, serializer); err != nil {
+			config.errs = append(config.errs, err)
+		}
+	}
+}
+
+// WithSerializerFor sets the serializer used for all messages of the same type as message
+func WithSerializerFor(message interface{}, serializerID int32) ConfigOption {
+	return func(config *Config) {
+		if err := config.Serializers.RegisterType(message, serializerID); err != nil {
+			config.errs = append(config.errs, err)
+		}
+	}
+}
+
+// WithDefaultSerializer sets the serializer used for message types without an explicit serializer
+func WithDefaultSerializer(serializerID int32) ConfigOption {
+	return func(config *Config) {
+		if err := config.Serializers.SetDefault(serializerID); err != nil {
+			config.errs = append(config.errs, err)
+		}
+	}
+}
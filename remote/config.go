@@ -1,7 +1,9 @@
 package remote
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/asynkron/protoactor-go/actor"
 	"google.golang.org/grpc"
@@ -16,10 +18,20 @@ func defaultConfig() *Config {
 		EndpointWriterQueueSize:  1000000,
 		EndpointManagerQueueSize: 1000000,
 		Kinds:                    make(map[string]*actor.Props),
+		Labels:                   make(map[string]string),
 		MaxRetryCount:            5,
+		HeartbeatInterval:        5 * time.Second,
+		Serializers:              newDefaultSerializerRegistry(),
 	}
 }
 
+func newDefaultSerializerRegistry() *SerializerRegistry {
+	registry := legacySerializers.clone()
+	_ = registry.SetDefault(DefaultSerializerID)
+
+	return registry
+}
+
 func newConfig(options ...ConfigOption) *Config {
 	config := defaultConfig()
 	for _, option := range options {
@@ -56,4 +68,21 @@ type Config struct {
 	EndpointManagerQueueSize int
 	Kinds                    map[string]*actor.Props
 	MaxRetryCount            int
+	Serializers              *SerializerRegistry
+	Labels                   map[string]string
+	HeartbeatInterval        time.Duration
+	// EndpointWriterHighWatermark is the queue length at which an endpoint is considered congested, 0 disables flow control
+	EndpointWriterHighWatermark int
+	// EndpointWriterLowWatermark is the queue length at which a congested endpoint is considered drained
+	EndpointWriterLowWatermark int
+	// FailureDetector terminates endpoints whose heartbeat responses stop arriving, nil disables it
+	FailureDetector *FailureDetectorConfig
+
+	// errs are the errors of the options, see Validate
+	errs []error
+}
+
+// Validate returns the errors of the options applied to the config, e.g. a serializer registered twice
+func (rc *Config) Validate() error {
+	return errors.Join(rc.errs...)
 }
@@ -163,8 +163,13 @@ func (em *endpointManager) endpointEvent(evn interface{}) {
 	switch msg := evn.(type) {
 	case *EndpointTerminatedEvent:
 		em.remote.Logger().Debug("EndpointManager received endpoint terminated event, removing endpoint", slog.Any("message", evn))
+		if health, ok := em.remote.endpointHealth.lookup(msg.Address); ok {
+			health.connected.Store(false)
+			em.remote.clearCongested(health)
+		}
 		em.removeEndpoint(msg)
 	case *EndpointConnectedEvent:
+		em.remote.endpointHealth.get(msg.Address).onConnected()
 		endpoint := em.ensureConnected(msg.Address)
 		em.remote.actorSystem.Root.Send(endpoint.watcher, msg)
 	}
@@ -210,6 +215,7 @@ func (em *endpointManager) remoteDeliver(msg *remoteDeliver) {
 	address := msg.target.Address
 	endpoint := em.ensureConnected(address)
 	em.remote.actorSystem.Root.Send(endpoint.writer, msg)
+	em.remote.checkCongested(em.remote.endpointHealth.get(address))
 }
 
 func (em *endpointManager) ensureConnected(address string) *endpoint {
@@ -244,12 +250,23 @@ func (em *endpointManager) ensureConnected(address string) *endpoint {
 // 	return el.valueFunc()
 // }
 
+// isConnected returns true while this remote has an endpoint sending to the address
+func (em *endpointManager) isConnected(address string) bool {
+	if em.connections == nil {
+		return false
+	}
+	_, ok := em.connections.Load(address)
+	return ok
+}
+
 func (em *endpointManager) removeEndpoint(msg *EndpointTerminatedEvent) {
 	v, ok := em.connections.Load(msg.Address)
 	if ok {
 		le := v.(*endpointLazy)
 		if le.unloaded.CompareAndSwap(false, true) {
 			em.connections.Delete(msg.Address)
+			em.remote.activatorStatuses.Delete(msg.Address)
+			em.remote.endpointHealth.remove(msg.Address)
 			ep := le.Get()
 			em.remote.Logger().Debug("Sending EndpointTerminatedEvent to EndpointWatcher and EndpointWriter", slog.String("address", msg.Address))
 			em.remote.actorSystem.Root.Send(ep.watcher, msg)
@@ -290,9 +307,15 @@ func (state *endpointSupervisor) HandleFailure(actorSystem *actor.ActorSystem, s
 }
 
 func (state *endpointSupervisor) spawnEndpointWriter(remote *Remote, address string, ctx actor.Context) *actor.PID {
+	health := remote.endpointHealth.get(address)
+	mailboxProducer := endpointWriterMailboxProducer(remote.config.EndpointWriterBatchSize, remote.config.EndpointWriterQueueSize)
 	props := actor.
 		PropsFromProducer(endpointWriterProducer(remote, address, remote.config),
-			actor.WithMailbox(endpointWriterMailboxProducer(remote.config.EndpointWriterBatchSize, remote.config.EndpointWriterQueueSize)))
+			actor.WithMailbox(func() actor.Mailbox {
+				mb := mailboxProducer()
+				health.mailbox.Store(mb)
+				return mb
+			}))
 	pid := ctx.Spawn(props)
 	return pid
 }
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"io"
 	"log/slog"
+	"sync"
 
 	"google.golang.org/protobuf/proto"
 
@@ -35,7 +36,22 @@ func newEndpointReader(r *Remote) *endpointReader {
 	}
 }
 
-func (s *endpointReader) Receive(stream Remoting_ReceiveServer) error {
+// lockedServerStream serializes the sends on the stream, the heartbeat responses are sent by the receive loop
+// while the disconnect request is sent by another goroutine, and gRPC does not allow concurrent sends on a stream
+type lockedServerStream struct {
+	Remoting_ReceiveServer
+	mu sync.Mutex
+}
+
+func (s *lockedServerStream) Send(msg *RemoteMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.Remoting_ReceiveServer.Send(msg)
+}
+
+func (s *endpointReader) Receive(serverStream Remoting_ReceiveServer) error {
+	stream := &lockedServerStream{Remoting_ReceiveServer: serverStream}
 	disconnectChan := make(chan bool, 1)
 	s.remote.edpManager.endpointReaderConnections.Store(stream, disconnectChan)
 	defer func() {
@@ -61,6 +77,17 @@ func (s *endpointReader) Receive(stream Remoting_ReceiveServer) error {
 		}
 	}()
 
+	var health *endpointHealth
+	defer func() {
+		// the health of an address is kept while this remote sends to it, see endpointManager.removeEndpoint
+		if health == nil {
+			return
+		}
+		if !s.remote.edpManager.isConnected(health.address) {
+			s.remote.endpointHealth.remove(health.address)
+		}
+	}()
+
 	for {
 		msg, err := stream.Recv()
 		switch {
@@ -79,12 +106,31 @@ func (s *endpointReader) Receive(stream Remoting_ReceiveServer) error {
 		case *RemoteMessage_ConnectRequest:
 			s.remote.Logger().Debug("EndpointReader received connect request", slog.Any("message", t.ConnectRequest))
 			c := t.ConnectRequest
+			if sc := c.GetServerConnection(); sc != nil {
+				health = s.remote.endpointHealth.get(sc.Address)
+			}
 			_, err := s.OnConnectRequest(stream, c)
 			if err != nil {
 				s.remote.Logger().Error("EndpointReader failed to handle connect request", slog.Any("error", err))
 				return err
 			}
+		case *RemoteMessage_Heartbeat:
+			err := stream.Send(&RemoteMessage{
+				MessageType: &RemoteMessage_HeartbeatResponse{
+					HeartbeatResponse: &HeartbeatResponse{
+						Timestamp:       t.Heartbeat.Timestamp,
+						ActivatorStatus: s.remote.activatorStatus(),
+					},
+				},
+			})
+			if err != nil {
+				s.remote.Logger().Error("EndpointReader failed to send heartbeat response", slog.Any("error", err))
+				return err
+			}
 		case *RemoteMessage_MessageBatch:
+			if health != nil {
+				health.bytesReceived.Add(int64(proto.Size(msg)))
+			}
 			m := t.MessageBatch
 			err := s.onMessageBatch(m)
 			if err != nil {
@@ -133,7 +179,7 @@ func (s *endpointReader) onMessageBatch(m *MessageBatch) error {
 			return errors.New("unknown target")
 		}
 
-		message, err := Deserialize(data, m.TypeNames[envelope.TypeId], envelope.SerializerId)
+		message, err := s.remote.Serializers().Deserialize(data, m.TypeNames[envelope.TypeId], envelope.SerializerId)
 		if err != nil {
 			s.remote.Logger().Error("EndpointReader failed to deserialize", slog.Any("error", err))
 			return err
@@ -211,6 +257,12 @@ func deserializeTarget(pid *actor.PID, index int32, requestId uint32, arr []*act
 }
 
 func (s *endpointReader) onServerConnection(stream Remoting_ReceiveServer, sc *ServerConnection) {
+	for _, id := range sc.SerializerIds {
+		if _, ok := s.remote.Serializers().Get(id); !ok {
+			s.remote.Logger().Warn("EndpointReader peer uses serializer that is not registered locally", slog.String("address", sc.Address), slog.Int("serializerId", int(id)))
+		}
+	}
+
 	if s.remote.BlockList().IsBlocked(sc.SystemId) {
 		s.remote.Logger().Debug("EndpointReader is blocked")
 
@@ -218,8 +270,9 @@ func (s *endpointReader) onServerConnection(stream Remoting_ReceiveServer, sc *S
 			&RemoteMessage{
 				MessageType: &RemoteMessage_ConnectResponse{
 					ConnectResponse: &ConnectResponse{
-						Blocked:  true,
-						MemberId: s.remote.actorSystem.ID,
+						Blocked:       true,
+						MemberId:      s.remote.actorSystem.ID,
+						SerializerIds: s.remote.Serializers().IDs(),
 					},
 				},
 			})
@@ -238,8 +291,9 @@ func (s *endpointReader) onServerConnection(stream Remoting_ReceiveServer, sc *S
 			&RemoteMessage{
 				MessageType: &RemoteMessage_ConnectResponse{
 					ConnectResponse: &ConnectResponse{
-						Blocked:  false,
-						MemberId: s.remote.actorSystem.ID,
+						Blocked:       false,
+						MemberId:      s.remote.actorSystem.ID,
+						SerializerIds: s.remote.Serializers().IDs(),
 					},
 				},
 			})
@@ -0,0 +1,44 @@
+package remote
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// concurrencyCheckingStream records whether Send is called concurrently
+type concurrencyCheckingStream struct {
+	Remoting_ReceiveServer
+	sending    atomic.Int32
+	concurrent atomic.Bool
+}
+
+func (s *concurrencyCheckingStream) Send(*RemoteMessage) error {
+	if s.sending.Add(1) > 1 {
+		s.concurrent.Store(true)
+	}
+	time.Sleep(time.Millisecond)
+	s.sending.Add(-1)
+
+	return nil
+}
+
+func TestLockedServerStream_SerializesSends(t *testing.T) {
+	inner := &concurrencyCheckingStream{}
+	stream := &lockedServerStream{Remoting_ReceiveServer: inner}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = stream.Send(&RemoteMessage{})
+		}()
+	}
+	wg.Wait()
+
+	assert.False(t, inner.concurrent.Load())
+}
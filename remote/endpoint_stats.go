@@ -0,0 +1,175 @@
+package remote
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/metrics"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// EndpointStats is a snapshot of the health of the connection to a remote address
+type EndpointStats struct {
+	Address       string
+	Connected     bool
+	Congested     bool
+	Reconnects    int64
+	RoundTripTime time.Duration
+	LastHeartbeat time.Time
+	QueueLength   int
+	BytesSent     int64
+	BytesReceived int64
+}
+
+type endpointHealth struct {
+	address       string
+	connected     atomic.Bool
+	congested     atomic.Bool
+	connects      atomic.Int64
+	roundTripTime atomic.Int64
+	lastHeartbeat atomic.Int64
+	bytesSent     atomic.Int64
+	bytesReceived atomic.Int64
+	mailbox       atomic.Value
+}
+
+func (h *endpointHealth) onConnected() {
+	h.connected.Store(true)
+	h.connects.Add(1)
+}
+
+func (h *endpointHealth) onHeartbeatResponse(sentAt int64) {
+	now := time.Now()
+	h.roundTripTime.Store(now.UnixNano() - sentAt)
+	h.lastHeartbeat.Store(now.UnixNano())
+}
+
+func (h *endpointHealth) queueLength() int {
+	if mb, ok := h.mailbox.Load().(actor.Mailbox); ok {
+		return mb.UserMessageCount()
+	}
+	return 0
+}
+
+func (h *endpointHealth) snapshot() EndpointStats {
+	reconnects := h.connects.Load() - 1
+	if reconnects < 0 {
+		reconnects = 0
+	}
+	stats := EndpointStats{
+		Address:       h.address,
+		Connected:     h.connected.Load(),
+		Congested:     h.congested.Load(),
+		Reconnects:    reconnects,
+		RoundTripTime: time.Duration(h.roundTripTime.Load()),
+		QueueLength:   h.queueLength(),
+		BytesSent:     h.bytesSent.Load(),
+		BytesReceived: h.bytesReceived.Load(),
+	}
+	if last := h.lastHeartbeat.Load(); last > 0 {
+		stats.LastHeartbeat = time.Unix(0, last)
+	}
+	return stats
+}
+
+type endpointHealthRegistry struct {
+	endpoints sync.Map
+}
+
+func (r *endpointHealthRegistry) get(address string) *endpointHealth {
+	if h, ok := r.endpoints.Load(address); ok {
+		return h.(*endpointHealth)
+	}
+	h, _ := r.endpoints.LoadOrStore(address, &endpointHealth{address: address})
+	return h.(*endpointHealth)
+}
+
+// lookup returns the health of the address without registering it
+func (r *endpointHealthRegistry) lookup(address string) (*endpointHealth, bool) {
+	h, ok := r.endpoints.Load(address)
+	if !ok {
+		return nil, false
+	}
+	return h.(*endpointHealth), true
+}
+
+// remove forgets the health of the address once its endpoint is terminated
+func (r *endpointHealthRegistry) remove(address string) {
+	r.endpoints.Delete(address)
+}
+
+func (r *endpointHealthRegistry) forEach(fn func(h *endpointHealth)) {
+	r.endpoints.Range(func(_, value interface{}) bool {
+		fn(value.(*endpointHealth))
+		return true
+	})
+}
+
+// EndpointStats returns the health statistics of the connection to the given address
+func (r *Remote) EndpointStats(address string) (EndpointStats, bool) {
+	h, ok := r.endpointHealth.lookup(address)
+	if !ok {
+		return EndpointStats{}, false
+	}
+	return h.snapshot(), true
+}
+
+// AllEndpointStats returns the health statistics of every endpoint this remote has communicated with
+func (r *Remote) AllEndpointStats() []EndpointStats {
+	stats := make([]EndpointStats, 0)
+	r.endpointHealth.forEach(func(h *endpointHealth) {
+		stats = append(stats, h.snapshot())
+	})
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Address < stats[j].Address })
+	return stats
+}
+
+func (r *Remote) registerEndpointMetrics() {
+	if r.actorSystem.Config.MetricsProvider == nil {
+		return
+	}
+
+	instruments := metrics.NewRemoteMetrics(r.Logger())
+	meter := otel.Meter(metrics.LibName)
+
+	registration, err := meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		r.endpointHealth.forEach(func(h *endpointHealth) {
+			stats := h.snapshot()
+			attrs := metric.WithAttributes(
+				attribute.String("address", r.actorSystem.Address()),
+				attribute.String("endpoint", stats.Address),
+			)
+			o.ObserveInt64(instruments.EndpointReconnectCount, stats.Reconnects, attrs)
+			o.ObserveFloat64(instruments.EndpointRoundTripTime, stats.RoundTripTime.Seconds(), attrs)
+			o.ObserveInt64(instruments.EndpointQueueLength, int64(stats.QueueLength), attrs)
+			o.ObserveInt64(instruments.EndpointBytesSent, stats.BytesSent, attrs)
+			o.ObserveInt64(instruments.EndpointBytesReceived, stats.BytesReceived, attrs)
+		})
+		return nil
+	}, instruments.Observables()...)
+	if err != nil {
+		err = fmt.Errorf("failed to instrument remote endpoints, %w", err)
+		r.Logger().Error(err.Error(), slog.Any("error", err))
+		return
+	}
+
+	r.metricsRegistration = registration
+}
+
+func (r *Remote) unregisterEndpointMetrics() {
+	if r.metricsRegistration == nil {
+		return
+	}
+	if err := r.metricsRegistration.Unregister(); err != nil {
+		r.Logger().Error("failed to unregister remote endpoint metrics", slog.Any("error", err))
+	}
+	r.metricsRegistration = nil
+}
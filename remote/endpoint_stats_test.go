@@ -0,0 +1,97 @@
+package remote
+
+import (
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemote_EndpointStats(t *testing.T) {
+	received := make(chan struct{}, 1)
+	system1 := actor.NewActorSystem()
+	remote1 := NewRemote(system1, Configure("localhost", 0, WithHeartbeatInterval(10*time.Millisecond)))
+	remote1.Start()
+	defer remote1.Shutdown(true)
+
+	system2 := actor.NewActorSystem()
+	remote2 := NewRemote(system2, Configure("localhost", 0))
+	remote2.Start()
+	defer remote2.Shutdown(true)
+
+	pid, _ := system2.Root.SpawnNamed(actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(*ActorPidRequest); ok {
+			received <- struct{}{}
+		}
+	}), "target")
+
+	_, ok := remote1.EndpointStats(system2.Address())
+	assert.False(t, ok)
+
+	system1.Root.Send(actor.NewPID(system2.Address(), pid.Id), &ActorPidRequest{Kind: "foo"})
+	select {
+	case <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("message was not delivered")
+	}
+
+	assert.Eventually(t, func() bool {
+		stats, ok := remote1.EndpointStats(system2.Address())
+		return ok && stats.Connected && stats.BytesSent > 0 && stats.RoundTripTime > 0 && !stats.LastHeartbeat.IsZero()
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Eventually(t, func() bool {
+		stats, ok := remote2.EndpointStats(system1.Address())
+		return ok && stats.BytesReceived > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	stats := remote1.AllEndpointStats()
+	assert.Len(t, stats, 1)
+	assert.Equal(t, int64(0), stats[0].Reconnects)
+}
+
+func TestRemote_EndpointStatsAreRemovedWithTheEndpoint(t *testing.T) {
+	system1 := actor.NewActorSystem()
+	remote1 := NewRemote(system1, Configure("localhost", 0, WithHeartbeatInterval(10*time.Millisecond)))
+	remote1.Start()
+	defer remote1.Shutdown(true)
+
+	system2 := actor.NewActorSystem()
+	remote2 := NewRemote(system2, Configure("localhost", 0, WithHeartbeatInterval(10*time.Millisecond)))
+	remote2.Start()
+
+	_, err := remote1.ActivatorStatus(system2.Address(), time.Second)
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		stats, ok := remote1.EndpointStats(system2.Address())
+		return ok && !stats.LastHeartbeat.IsZero()
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		_, ok := remote2.EndpointStats(system1.Address())
+		return ok
+	}, 5*time.Second, 10*time.Millisecond)
+
+	remote2.Shutdown(true)
+	assert.Eventually(t, func() bool {
+		_, ok := remote1.EndpointStats(system2.Address())
+		return !ok
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Empty(t, remote1.AllEndpointStats())
+}
+
+func TestEndpointWriter_sendHeartbeats_without_the_mailbox(t *testing.T) {
+	remote := NewRemote(actor.NewActorSystem(), Configure("localhost", 0))
+	writer := &endpointWriter{address: "localhost:1", remote: remote}
+	stream := &recordingStream{}
+
+	stop := make(chan struct{})
+	go writer.sendHeartbeats(stream, time.Millisecond, stop)
+	defer close(stop)
+
+	assert.Eventually(t, func() bool {
+		writer.sendMu.Lock()
+		defer writer.sendMu.Unlock()
+		return len(stream.sent) >= 2 && stream.sent[0].GetHeartbeat() != nil
+	}, time.Second, time.Millisecond)
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"io"
 	"log/slog"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/asynkron/protoactor-go/actor"
@@ -18,16 +20,25 @@ func endpointWriterProducer(remote *Remote, address string, config *Config) acto
 			address: address,
 			config:  config,
 			remote:  remote,
+			health:  remote.endpointHealth.get(address),
 		}
 	}
 }
 
 type endpointWriter struct {
-	config  *Config
-	address string
-	conn    *grpc.ClientConn
-	stream  Remoting_ReceiveClient
-	remote  *Remote
+	config          *Config
+	address         string
+	conn            *grpc.ClientConn
+	stream          Remoting_ReceiveClient
+	sendMu          sync.Mutex // the heartbeats are sent on the stream outside the mailbox
+	remote          *Remote
+	peerSerializers map[int32]struct{} // nil when the peer did not announce its serializers
+	health          *endpointHealth
+	stopHeartbeat   chan struct{}
+	cancelStream    context.CancelFunc
+	stopMonitor     chan struct{}
+	// unreachable is set by the reachability monitor once the failure detector suspects the endpoint
+	unreachable atomic.Bool
 }
 
 type restartAfterConnectFailure struct {
@@ -76,6 +87,11 @@ func (state *endpointWriter) initialize(ctx actor.Context) {
 	}
 
 	state.remote.Logger().Info("EndpointWriter connected", slog.String("address", state.address), slog.Duration("cost", time.Since(now)))
+
+	if interval := state.config.HeartbeatInterval; interval > 0 {
+		state.stopHeartbeat = make(chan struct{})
+		go state.sendHeartbeats(state.stream, interval, state.stopHeartbeat)
+	}
 }
 
 func (state *endpointWriter) initializeInternal() error {
@@ -85,7 +101,9 @@ func (state *endpointWriter) initializeInternal() error {
 	}
 	state.conn = conn
 	c := NewRemotingClient(conn)
-	stream, err := c.Receive(context.Background(), state.config.CallOptions...)
+	streamCtx, cancelStream := context.WithCancel(context.Background())
+	state.cancelStream = cancelStream
+	stream, err := c.Receive(streamCtx, state.config.CallOptions...)
 	if err != nil {
 		state.remote.Logger().Error("EndpointWriter failed to create receive stream", slog.String("address", state.address), slog.Any("error", err))
 		return err
@@ -97,8 +115,9 @@ func (state *endpointWriter) initializeInternal() error {
 			ConnectRequest: &ConnectRequest{
 				ConnectionType: &ConnectRequest_ServerConnection{
 					ServerConnection: &ServerConnection{
-						SystemId: state.remote.actorSystem.ID,
-						Address:  state.remote.actorSystem.Address(),
+						SystemId:      state.remote.actorSystem.ID,
+						Address:       state.remote.actorSystem.Address(),
+						SerializerIds: state.remote.Serializers().IDs(),
 					},
 				},
 			},
@@ -115,23 +134,36 @@ func (state *endpointWriter) initializeInternal() error {
 		return err
 	}
 
-	switch connection.MessageType.(type) {
+	switch cr := connection.MessageType.(type) {
 	case *RemoteMessage_ConnectResponse:
 		state.remote.Logger().Debug("Received connect response", slog.String("fromAddress", state.address))
 		// TODO: handle blocked status received from remote server
-		break
+		state.setPeerSerializers(cr.ConnectResponse.SerializerIds)
 	default:
 		state.remote.Logger().Error("EndpointWriter got invalid connect response", slog.String("address", state.address), slog.Any("type", connection.MessageType))
 		return errors.New("invalid connect response")
 	}
 
+	// peers running an older version do not announce their serializers and do not answer heartbeats either
+	var detector *PhiAccrualFailureDetector
+	if state.config.FailureDetector != nil && state.config.HeartbeatInterval > 0 && state.peerSerializers != nil {
+		detector = NewPhiAccrualFailureDetector(*state.config.FailureDetector, state.config.HeartbeatInterval)
+		detector.Start(time.Now())
+		state.stopMonitor = make(chan struct{})
+		go state.monitorReachability(detector, state.config.HeartbeatInterval, cancelStream, state.stopMonitor)
+	}
+
 	go func() {
 		for {
-			_, err := stream.Recv()
+			msg, err := stream.Recv()
 			switch {
 			case errors.Is(err, io.EOF):
 				state.remote.Logger().Debug("EndpointWriter stream completed", slog.String("address", state.address))
 				return
+			case err != nil && state.unreachable.Load():
+				// the reachability monitor cancelled the stream and already published the endpoint as terminated
+				state.remote.Logger().Debug("EndpointWriter stream of unreachable endpoint cancelled", slog.String("address", state.address))
+				return
 			case err != nil:
 				state.remote.Logger().Error("EndpointWriter lost connection", slog.String("address", state.address), slog.Any("error", err))
 				terminated := &EndpointTerminatedEvent{
@@ -139,6 +171,14 @@ func (state *endpointWriter) initializeInternal() error {
 				}
 				state.remote.actorSystem.EventStream.Publish(terminated)
 				return
+			case msg.GetHeartbeatResponse() != nil:
+				state.health.onHeartbeatResponse(msg.GetHeartbeatResponse().Timestamp)
+				if status := msg.GetHeartbeatResponse().ActivatorStatus; status != nil {
+					state.remote.onActivatorStatus(state.address, status)
+				}
+				if detector != nil {
+					detector.Heartbeat(time.Now())
+				}
 			default: // DisconnectRequest
 				state.remote.Logger().Info("EndpointWriter got DisconnectRequest form remote", slog.String("address", state.address))
 				terminated := &EndpointTerminatedEvent{
@@ -154,7 +194,37 @@ func (state *endpointWriter) initializeInternal() error {
 	return nil
 }
 
+func (state *endpointWriter) setPeerSerializers(serializerIDs []int32) {
+	// peers running an older version do not announce their serializers, assume they support everything
+	if len(serializerIDs) == 0 {
+		state.peerSerializers = nil
+		return
+	}
+
+	state.peerSerializers = make(map[int32]struct{}, len(serializerIDs))
+	for _, id := range serializerIDs {
+		state.peerSerializers[id] = struct{}{}
+	}
+
+	for _, id := range state.remote.Serializers().IDs() {
+		if _, ok := state.peerSerializers[id]; !ok {
+			state.remote.Logger().Warn("EndpointWriter peer does not support serializer", slog.String("address", state.address), slog.Int("serializerId", int(id)))
+		}
+	}
+}
+
+func (state *endpointWriter) peerSupports(serializerID int32) bool {
+	if state.peerSerializers == nil {
+		return true
+	}
+
+	_, ok := state.peerSerializers[serializerID]
+	return ok
+}
+
 func (state *endpointWriter) sendEnvelopes(msg []interface{}, ctx actor.Context) {
+	defer state.remote.checkDecongested(state.health)
+
 	envelopes := make([]*MessageEnvelope, 0)
 
 	// type name uniqueness map name string to type index
@@ -185,12 +255,10 @@ func (state *endpointWriter) sendEnvelopes(msg []interface{}, ctx actor.Context)
 
 		rd, _ := tmp.(*remoteDeliver)
 
-		if state.stream == nil { // not connected yet since first connection attempt failed and we are waiting for the retry
-			if rd.sender != nil {
-				state.remote.actorSystem.Root.Send(rd.sender, &actor.DeadLetterResponse{Target: rd.target})
-			} else {
-				state.remote.actorSystem.EventStream.Publish(&actor.DeadLetterEvent{Message: rd.message, Sender: rd.sender, PID: rd.target})
-			}
+		// not connected yet since first connection attempt failed and we are waiting for the retry,
+		// or the failure detector suspects the endpoint which is terminating
+		if state.stream == nil || state.unreachable.Load() {
+			state.deadLetter(rd)
 			continue
 		}
 
@@ -214,7 +282,17 @@ func (state *endpointWriter) sendEnvelopes(msg []interface{}, ctx actor.Context)
 			}
 		}
 
-		bytes, typeName, err := Serialize(message, serializerID)
+		serializerID = rd.serializerID
+		if serializerID < 0 {
+			serializerID = state.remote.Serializers().SerializerIDFor(message)
+		}
+		if !state.peerSupports(serializerID) {
+			state.remote.Logger().Error("EndpointWriter peer does not support serializer", slog.String("address", state.address), slog.Int("serializerId", int(serializerID)), slog.Any("message", message))
+			state.deadLetter(rd)
+			continue
+		}
+
+		bytes, typeName, err := state.remote.Serializers().Serialize(message, serializerID)
 		if err != nil {
 			state.remote.Logger().Error("EndpointWriter failed to serialize message", slog.String("address", state.address), slog.Any("error", err), slog.Any("message", message))
 			continue
@@ -245,7 +323,7 @@ func (state *endpointWriter) sendEnvelopes(msg []interface{}, ctx actor.Context)
 		return
 	}
 
-	err := state.stream.Send(&RemoteMessage{
+	batch := &RemoteMessage{
 		MessageType: &RemoteMessage_MessageBatch{
 			MessageBatch: &MessageBatch{
 				TypeNames: typeNamesArr,
@@ -254,11 +332,87 @@ func (state *endpointWriter) sendEnvelopes(msg []interface{}, ctx actor.Context)
 				Envelopes: envelopes,
 			},
 		},
-	})
+	}
+	state.sendMu.Lock()
+	err := state.stream.Send(batch)
+	state.sendMu.Unlock()
 	if err != nil {
 		ctx.Stash()
 		state.remote.Logger().Debug("gRPC Failed to send", slog.String("address", state.address), slog.Any("error", err))
 		ctx.Stop(ctx.Self())
+		return
+	}
+	state.health.bytesSent.Add(int64(proto.Size(batch)))
+}
+
+// monitorReachability publishes the endpoint as terminated when the failure detector suspects it.
+// The detector is evaluated on its own timer rather than from the mailbox, a send blocked on a half-open
+// connection would otherwise keep the endpoint from ever being suspected. The detector is started when the
+// endpoint connects, so a connection which never answers a heartbeat is suspected too, peers without heartbeat
+// support are not monitored.
+func (state *endpointWriter) monitorReachability(detector *PhiAccrualFailureDetector, interval time.Duration, cancelStream context.CancelFunc, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			if detector.IsAvailable(now) {
+				continue
+			}
+
+			phi := detector.Phi(now)
+			state.remote.Logger().Warn("EndpointWriter failure detector marked endpoint as unreachable", slog.String("address", state.address), slog.Float64("phi", phi))
+			state.unreachable.Store(true)
+			state.remote.actorSystem.EventStream.Publish(&EndpointUnreachableEvent{Address: state.address, Phi: phi})
+			state.remote.actorSystem.EventStream.Publish(&EndpointTerminatedEvent{Address: state.address})
+
+			// unblock a send waiting on the half-open connection
+			cancelStream()
+			return
+		}
+	}
+}
+
+// deadLetter reports a message which is not sent to the endpoint
+func (state *endpointWriter) deadLetter(rd *remoteDeliver) {
+	if rd.sender != nil {
+		state.remote.actorSystem.Root.Send(rd.sender, &actor.DeadLetterResponse{Target: rd.target})
+	} else {
+		state.remote.actorSystem.EventStream.Publish(&actor.DeadLetterEvent{Message: rd.message, Sender: rd.sender, PID: rd.target})
+	}
+}
+
+// sendHeartbeats sends the heartbeats on their own timer rather than through the mailbox, the round-trip time
+// would otherwise include the time the heartbeat waited behind the queued messages
+func (state *endpointWriter) sendHeartbeats(stream Remoting_ReceiveClient, interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			state.sendHeartbeat(stream)
+		}
+	}
+}
+
+func (state *endpointWriter) sendHeartbeat(stream Remoting_ReceiveClient) {
+	state.sendMu.Lock()
+	defer state.sendMu.Unlock()
+
+	err := stream.Send(&RemoteMessage{
+		MessageType: &RemoteMessage_Heartbeat{
+			Heartbeat: &Heartbeat{Timestamp: time.Now().UnixNano()},
+		},
+	})
+	if err != nil {
+		// a broken stream is detected and reported by the receiving goroutine
+		state.remote.Logger().Debug("EndpointWriter failed to send heartbeat", slog.String("address", state.address), slog.Any("error", err))
 	}
 }
 
@@ -332,6 +486,14 @@ func (state *endpointWriter) Receive(ctx actor.Context) {
 
 func (state *endpointWriter) closeClientConn() {
 	state.remote.Logger().Info("EndpointWriter closing client connection", slog.String("address", state.address))
+	if state.stopHeartbeat != nil {
+		close(state.stopHeartbeat)
+		state.stopHeartbeat = nil
+	}
+	if state.stopMonitor != nil {
+		close(state.stopMonitor)
+		state.stopMonitor = nil
+	}
 	if state.stream != nil {
 		err := state.stream.CloseSend()
 		if err != nil {
@@ -339,6 +501,10 @@ func (state *endpointWriter) closeClientConn() {
 		}
 		state.stream = nil
 	}
+	if state.cancelStream != nil {
+		state.cancelStream()
+		state.cancelStream = nil
+	}
 	if state.conn != nil {
 		err := state.conn.Close()
 		if err != nil {
@@ -1,11 +1,17 @@
 package remote
 
+import "errors"
+
+// ErrEndpointCongested is returned by SendWithBackpressure when the target endpoint is congested
+var ErrEndpointCongested = errors.New("remote: endpoint is congested")
+
 var (
 	ErrUnAvailable             = &ResponseError{ResponseStatusCodeUNAVAILABLE}
 	ErrTimeout                 = &ResponseError{ResponseStatusCodeTIMEOUT}
 	ErrProcessNameAlreadyExist = &ResponseError{ResponseStatusCodePROCESSNAMEALREADYEXIST}
 	ErrDeadLetter              = &ResponseError{ResponseStatusCodeDeadLetter}
 	ErrUnknownError            = &ResponseError{ResponseStatusCodeERROR}
+	ErrUnknownKind             = &ResponseError{ResponseStatusCodeUNKNOWNKIND}
 )
 
 // ResponseError is an error type.
@@ -0,0 +1,129 @@
+package remote
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// FailureDetectorConfig configures the phi accrual failure detector that marks endpoints
+// unreachable when heartbeat responses stop arriving
+type FailureDetectorConfig struct {
+	// Threshold is the phi value above which an endpoint is considered unreachable
+	Threshold float64
+	// MaxSampleSize is the number of heartbeat intervals kept to estimate the distribution
+	MaxSampleSize int
+	// MinStdDeviation is the lower bound of the standard deviation, it avoids a too sensitive detector
+	// when the heartbeats arrive at very regular intervals
+	MinStdDeviation time.Duration
+	// AcceptableHeartbeatPause is the duration of missing heartbeats that is tolerated, e.g. during GC pauses
+	AcceptableHeartbeatPause time.Duration
+}
+
+// DefaultFailureDetectorConfig returns a failure detector configuration suited to the default heartbeat interval
+func DefaultFailureDetectorConfig() *FailureDetectorConfig {
+	return &FailureDetectorConfig{
+		Threshold:                10,
+		MaxSampleSize:            200,
+		MinStdDeviation:          500 * time.Millisecond,
+		AcceptableHeartbeatPause: 10 * time.Second,
+	}
+}
+
+// PhiAccrualFailureDetector implements the phi accrual failure detector as described in
+// "The φ Accrual Failure Detector" by Hayashibara et al.
+// Instead of a boolean it outputs a suspicion level phi, derived from the distribution of the
+// intervals between previous heartbeats.
+type PhiAccrualFailureDetector struct {
+	mu            sync.Mutex
+	config        FailureDetectorConfig
+	intervals     []time.Duration
+	intervalSum   float64
+	squaredSum    float64
+	lastHeartbeat time.Time
+}
+
+// NewPhiAccrualFailureDetector creates a failure detector, firstHeartbeatEstimate is the expected
+// heartbeat interval used until enough heartbeats have been received
+func NewPhiAccrualFailureDetector(config FailureDetectorConfig, firstHeartbeatEstimate time.Duration) *PhiAccrualFailureDetector {
+	fd := &PhiAccrualFailureDetector{
+		config:    config,
+		intervals: make([]time.Duration, 0, config.MaxSampleSize),
+	}
+
+	// seed the history with a distribution around the expected interval
+	stdDeviation := firstHeartbeatEstimate / 4
+	fd.addInterval(firstHeartbeatEstimate - stdDeviation)
+	fd.addInterval(firstHeartbeatEstimate + stdDeviation)
+
+	return fd
+}
+
+// Heartbeat records the arrival of a heartbeat
+func (fd *PhiAccrualFailureDetector) Heartbeat(now time.Time) {
+	fd.mu.Lock()
+	defer fd.mu.Unlock()
+
+	if !fd.lastHeartbeat.IsZero() {
+		fd.addInterval(now.Sub(fd.lastHeartbeat))
+	}
+	fd.lastHeartbeat = now
+}
+
+// Start starts the clock of the detector when the endpoint is connected, an endpoint which never answers a
+// heartbeat, such as a connection which is half-open from the start, is then suspected as well
+func (fd *PhiAccrualFailureDetector) Start(now time.Time) {
+	fd.mu.Lock()
+	defer fd.mu.Unlock()
+
+	if fd.lastHeartbeat.IsZero() {
+		fd.lastHeartbeat = now
+	}
+}
+
+// Phi returns the suspicion level of the monitored endpoint, it is 0 until the detector is started or the
+// first heartbeat arrived
+func (fd *PhiAccrualFailureDetector) Phi(now time.Time) float64 {
+	fd.mu.Lock()
+	defer fd.mu.Unlock()
+
+	if fd.lastHeartbeat.IsZero() {
+		return 0
+	}
+
+	n := float64(len(fd.intervals))
+	mean := fd.intervalSum / n
+	stdDeviation := math.Sqrt(math.Max(fd.squaredSum/n-mean*mean, 0))
+	stdDeviation = math.Max(stdDeviation, float64(fd.config.MinStdDeviation))
+
+	return phi(float64(now.Sub(fd.lastHeartbeat)), mean+float64(fd.config.AcceptableHeartbeatPause), stdDeviation)
+}
+
+// IsAvailable returns false once phi exceeds the configured threshold
+func (fd *PhiAccrualFailureDetector) IsAvailable(now time.Time) bool {
+	return fd.Phi(now) < fd.config.Threshold
+}
+
+func (fd *PhiAccrualFailureDetector) addInterval(interval time.Duration) {
+	if fd.config.MaxSampleSize > 0 && len(fd.intervals) >= fd.config.MaxSampleSize {
+		dropped := float64(fd.intervals[0])
+		fd.intervals = fd.intervals[1:]
+		fd.intervalSum -= dropped
+		fd.squaredSum -= dropped * dropped
+	}
+
+	value := float64(interval)
+	fd.intervals = append(fd.intervals, interval)
+	fd.intervalSum += value
+	fd.squaredSum += value * value
+}
+
+// phi uses a logistic approximation of the cumulative normal distribution
+func phi(timeDiff, mean, stdDeviation float64) float64 {
+	y := (timeDiff - mean) / stdDeviation
+	e := math.Exp(-y * (1.5976 + 0.070566*y*y))
+	if timeDiff > mean {
+		return -math.Log10(e / (1.0 + e))
+	}
+	return -math.Log10(1.0 - 1.0/(1.0+e))
+}
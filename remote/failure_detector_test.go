@@ -0,0 +1,209 @@
+package remote
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhiAccrualFailureDetector_Phi(t *testing.T) {
+	fd := NewPhiAccrualFailureDetector(FailureDetectorConfig{
+		Threshold:       8,
+		MaxSampleSize:   100,
+		MinStdDeviation: 10 * time.Millisecond,
+	}, 100*time.Millisecond)
+
+	now := time.Now()
+	assert.Equal(t, 0.0, fd.Phi(now), "phi is 0 before the first heartbeat")
+
+	for i := 0; i < 20; i++ {
+		fd.Heartbeat(now)
+		now = now.Add(100 * time.Millisecond)
+	}
+	last := now.Add(-100 * time.Millisecond)
+
+	assert.Less(t, fd.Phi(last.Add(50*time.Millisecond)), 1.0)
+	assert.True(t, fd.IsAvailable(last.Add(100*time.Millisecond)))
+	assert.Less(t, fd.Phi(last.Add(100*time.Millisecond)), fd.Phi(last.Add(150*time.Millisecond)))
+	assert.False(t, fd.IsAvailable(last.Add(time.Second)))
+}
+
+func TestPhiAccrualFailureDetector_AcceptableHeartbeatPause(t *testing.T) {
+	config := FailureDetectorConfig{
+		Threshold:                8,
+		MaxSampleSize:            100,
+		MinStdDeviation:          10 * time.Millisecond,
+		AcceptableHeartbeatPause: 2 * time.Second,
+	}
+	fd := NewPhiAccrualFailureDetector(config, 100*time.Millisecond)
+
+	now := time.Now()
+	for i := 0; i < 20; i++ {
+		fd.Heartbeat(now)
+		now = now.Add(100 * time.Millisecond)
+	}
+
+	assert.True(t, fd.IsAvailable(now.Add(time.Second)))
+	assert.False(t, fd.IsAvailable(now.Add(5*time.Second)))
+}
+
+func TestPhiAccrualFailureDetector_MaxSampleSize(t *testing.T) {
+	fd := NewPhiAccrualFailureDetector(FailureDetectorConfig{MaxSampleSize: 3}, 100*time.Millisecond)
+
+	now := time.Now()
+	for i := 0; i < 10; i++ {
+		fd.Heartbeat(now)
+		now = now.Add(time.Second)
+	}
+
+	assert.Len(t, fd.intervals, 3)
+}
+
+func TestRemote_FailureDetector_terminates_watchers_of_unresponsive_endpoint(t *testing.T) {
+	system1 := actor.NewActorSystem()
+	remote1 := NewRemote(system1, Configure("localhost", 0,
+		WithHeartbeatInterval(20*time.Millisecond),
+		WithFailureDetector(&FailureDetectorConfig{
+			Threshold:                3,
+			MaxSampleSize:            100,
+			MinStdDeviation:          10 * time.Millisecond,
+			AcceptableHeartbeatPause: 50 * time.Millisecond,
+		})))
+	remote1.Start()
+	defer remote1.Shutdown(true)
+
+	system2 := actor.NewActorSystem()
+	remote2 := NewRemote(system2, Configure("localhost", 0))
+	remote2.Start()
+	defer remote2.Shutdown(true)
+
+	watchee, _ := system2.Root.SpawnNamed(actor.PropsFromFunc(func(ctx actor.Context) {}), "watchee")
+
+	unreachable := make(chan *EndpointUnreachableEvent, 1)
+	var endpointTerminated atomic.Int32
+	sub := system1.EventStream.Subscribe(func(evt interface{}) {
+		switch e := evt.(type) {
+		case *EndpointUnreachableEvent:
+			unreachable <- e
+		case *EndpointTerminatedEvent:
+			if e.Address == system2.Address() {
+				endpointTerminated.Add(1)
+			}
+		}
+	})
+	defer system1.EventStream.Unsubscribe(sub)
+
+	terminated := make(chan *actor.Terminated, 1)
+	system1.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		switch msg := ctx.Message().(type) {
+		case *actor.Started:
+			ctx.Watch(actor.NewPID(system2.Address(), watchee.Id))
+		case *actor.Terminated:
+			terminated <- msg
+		}
+	}))
+
+	assert.Eventually(t, func() bool {
+		stats, ok := remote1.EndpointStats(system2.Address())
+		return ok && !stats.LastHeartbeat.IsZero()
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the connection stays open but heartbeats are no longer answered, like a half-open connection
+	remote2.edpReader.suspend(true)
+
+	select {
+	case e := <-unreachable:
+		assert.Equal(t, system2.Address(), e.Address)
+	case <-time.After(5 * time.Second):
+		t.Fatal("endpoint was not marked as unreachable")
+	}
+
+	select {
+	case msg := <-terminated:
+		assert.Equal(t, actor.TerminatedReason_AddressTerminated, msg.Why)
+		assert.Equal(t, watchee.Id, msg.Who.Id)
+	case <-time.After(5 * time.Second):
+		t.Fatal("watcher did not receive Terminated")
+	}
+
+	// the cancelled stream does not publish the endpoint as terminated a second time
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int32(1), endpointTerminated.Load())
+}
+
+func TestPhiAccrualFailureDetector_suspects_endpoint_which_never_answers(t *testing.T) {
+	fd := NewPhiAccrualFailureDetector(FailureDetectorConfig{
+		Threshold:       3,
+		MaxSampleSize:   100,
+		MinStdDeviation: 10 * time.Millisecond,
+	}, 100*time.Millisecond)
+
+	connectedAt := time.Now()
+	assert.True(t, fd.IsAvailable(connectedAt.Add(time.Second)))
+
+	fd.Start(connectedAt)
+	assert.True(t, fd.IsAvailable(connectedAt.Add(50*time.Millisecond)))
+	assert.False(t, fd.IsAvailable(connectedAt.Add(time.Second)))
+}
+
+func TestEndpointWriter_monitorReachability_does_not_depend_on_the_mailbox(t *testing.T) {
+	system := actor.NewActorSystem()
+	remote := NewRemote(system, Configure("localhost", 0))
+	writer := &endpointWriter{address: "localhost:1", remote: remote}
+
+	events := make(chan interface{}, 2)
+	sub := system.EventStream.Subscribe(func(evt interface{}) {
+		switch evt.(type) {
+		case *EndpointUnreachableEvent, *EndpointTerminatedEvent:
+			events <- evt
+		}
+	})
+	defer system.EventStream.Unsubscribe(sub)
+
+	detector := NewPhiAccrualFailureDetector(FailureDetectorConfig{
+		Threshold:       3,
+		MaxSampleSize:   100,
+		MinStdDeviation: 10 * time.Millisecond,
+	}, 20*time.Millisecond)
+	detector.Heartbeat(time.Now())
+
+	// no heartbeat tick is processed by the writer, as when its send blocks on a half-open connection
+	cancelled := make(chan struct{})
+	go writer.monitorReachability(detector, 10*time.Millisecond, func() { close(cancelled) }, make(chan struct{}))
+
+	select {
+	case <-cancelled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the stream of the unreachable endpoint was not cancelled")
+	}
+	assert.True(t, writer.unreachable.Load())
+	assert.IsType(t, &EndpointUnreachableEvent{}, <-events)
+	assert.IsType(t, &EndpointTerminatedEvent{}, <-events)
+}
+
+func TestEndpointWriter_sendEnvelopes_dead_letters_messages_to_unreachable_endpoint(t *testing.T) {
+	system := actor.NewActorSystem()
+	remote := NewRemote(system, Configure("localhost", 0))
+	writer := &endpointWriter{address: "localhost:1", remote: remote, health: remote.endpointHealth.get("localhost:1")}
+	writer.unreachable.Store(true)
+
+	deadLetters := make(chan *actor.DeadLetterEvent, 3)
+	sub := system.EventStream.Subscribe(func(evt interface{}) {
+		if e, ok := evt.(*actor.DeadLetterEvent); ok {
+			deadLetters <- e
+		}
+	})
+	defer system.EventStream.Unsubscribe(sub)
+
+	target := actor.NewPID("localhost:1", "target")
+	batch := []interface{}{
+		&remoteDeliver{message: &ActorPidRequest{Name: "1"}, target: target, serializerID: -1},
+		&remoteDeliver{message: &ActorPidRequest{Name: "2"}, target: target, serializerID: -1},
+	}
+	writer.sendEnvelopes(batch, nil)
+
+	assert.Len(t, deadLetters, 2)
+}
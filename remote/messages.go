@@ -10,6 +10,25 @@ type EndpointConnectedEvent struct {
 	Address string
 }
 
+// EndpointUnreachableEvent is published when the failure detector suspects an endpoint,
+// it is followed by an EndpointTerminatedEvent for the same address
+type EndpointUnreachableEvent struct {
+	Address string
+	Phi     float64
+}
+
+// EndpointCongestedEvent is published when the endpoint writer queue of an address reaches the high watermark
+type EndpointCongestedEvent struct {
+	Address     string
+	QueueLength int
+}
+
+// EndpointDecongestedEvent is published when the endpoint writer queue of a congested address drains to the low watermark
+type EndpointDecongestedEvent struct {
+	Address     string
+	QueueLength int
+}
+
 type remoteWatch struct {
 	Watcher *actor.PID
 	Watchee *actor.PID
@@ -0,0 +1,216 @@
+package remote
+
+import (
+	"errors"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrNoPlacementCandidate is returned when no activator satisfies the placement policy
+var ErrNoPlacementCandidate = errors.New("remote: no activator satisfies the placement policy")
+
+// PlacementPolicy selects the activator a remote actor is spawned on.
+// Candidates passed to Select always support the requested kind.
+type PlacementPolicy interface {
+	Select(kind string, candidates []*ActivatorStatusResponse) (*ActivatorStatusResponse, bool)
+}
+
+// PlacementPolicyFunc adapts a function to the PlacementPolicy interface
+type PlacementPolicyFunc func(kind string, candidates []*ActivatorStatusResponse) (*ActivatorStatusResponse, bool)
+
+func (f PlacementPolicyFunc) Select(kind string, candidates []*ActivatorStatusResponse) (*ActivatorStatusResponse, bool) {
+	return f(kind, candidates)
+}
+
+// LeastActorsPlacement places the actor on the activator hosting the fewest actors
+func LeastActorsPlacement() PlacementPolicy {
+	return PlacementPolicyFunc(func(_ string, candidates []*ActivatorStatusResponse) (*ActivatorStatusResponse, bool) {
+		var selected *ActivatorStatusResponse
+		for _, c := range candidates {
+			if selected == nil || c.ActorCount < selected.ActorCount {
+				selected = c
+			}
+		}
+		return selected, selected != nil
+	})
+}
+
+type roundRobinPlacement struct {
+	val int32
+}
+
+// RoundRobinPlacement places actors on the candidate activators in turn
+func RoundRobinPlacement() PlacementPolicy {
+	return &roundRobinPlacement{}
+}
+
+func (r *roundRobinPlacement) Select(_ string, candidates []*ActivatorStatusResponse) (*ActivatorStatusResponse, bool) {
+	if len(candidates) == 0 {
+		return nil, false
+	}
+	i := atomic.AddInt32(&r.val, 1)
+	return candidates[int(uint32(i))%len(candidates)], true
+}
+
+// LabelPlacement only considers activators carrying all the given labels and
+// delegates the selection among them to next
+func LabelPlacement(labels map[string]string, next PlacementPolicy) PlacementPolicy {
+	return PlacementPolicyFunc(func(kind string, candidates []*ActivatorStatusResponse) (*ActivatorStatusResponse, bool) {
+		matching := make([]*ActivatorStatusResponse, 0, len(candidates))
+		for _, c := range candidates {
+			if HasLabels(c.Labels, labels) {
+				matching = append(matching, c)
+			}
+		}
+		return next.Select(kind, matching)
+	})
+}
+
+// HasLabels returns true if actual contains all the required labels with the same values
+func HasLabels(actual, required map[string]string) bool {
+	for k, v := range required {
+		if actual[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// ActivatorStatus requests the status of the activator at the given address
+func (r *Remote) ActivatorStatus(address string, timeout time.Duration) (*ActivatorStatusResponse, error) {
+	res, err := r.actorSystem.Root.RequestFuture(r.ActivatorForAddress(address), &ActivatorStatusRequest{}, timeout).Result()
+	if err != nil {
+		return nil, err
+	}
+	status, ok := res.(*ActivatorStatusResponse)
+	if !ok {
+		return nil, errors.New("remote: Unknown response when requesting activator status")
+	}
+	return status, nil
+}
+
+// KnownActivators returns the address of this remote and of every remote which published the status of its
+// activator to this remote, the remotes publish it with their heartbeat responses
+func (r *Remote) KnownActivators() []string {
+	addresses := []string{r.actorSystem.Address()}
+	r.activatorStatuses.Range(func(key, _ interface{}) bool {
+		addresses = append(addresses, key.(string))
+		return true
+	})
+	return addresses
+}
+
+// SpawnPlaced spawns a named remote actor of a given kind on the address chosen by the placement policy.
+// The candidates are the given addresses, such as the members of a cluster, or KnownActivators when none are given.
+// The policy selects among the statuses the activators published with their last heartbeat response,
+// the status of a candidate which did not publish it yet is requested from its activator.
+// ErrUnknownKind is returned when none of the candidates can spawn the kind.
+func (r *Remote) SpawnPlaced(name, kind string, policy PlacementPolicy, timeout time.Duration, addresses ...string) (*ActorPidResponse, error) {
+	if len(addresses) == 0 {
+		addresses = r.KnownActivators()
+	}
+
+	candidates := r.placementCandidates(kind, addresses, timeout)
+	if len(candidates) == 0 {
+		return nil, ErrUnknownKind
+	}
+
+	selected, ok := policy.Select(kind, candidates)
+	if !ok {
+		return nil, ErrNoPlacementCandidate
+	}
+
+	res, err := r.SpawnNamed(selected.Address, name, kind, timeout)
+	if err == nil && res.StatusCode == ResponseStatusCodeOK.ToInt32() {
+		r.countPlacedActor(selected.Address)
+	}
+	return res, err
+}
+
+// activatorStatus returns the status of the local activator
+func (r *Remote) activatorStatus() *ActivatorStatusResponse {
+	return &ActivatorStatusResponse{
+		Address:    r.actorSystem.Address(),
+		ActorCount: r.activatorActors.Load(),
+		Kinds:      r.GetKnownKinds(),
+		Labels:     r.labels(),
+	}
+}
+
+// onActivatorStatus caches the status the activator at the address published
+func (r *Remote) onActivatorStatus(address string, status *ActivatorStatusResponse) {
+	// the activator reports its own address, keep the address the caller knows it by
+	status.Address = address
+	r.activatorStatuses.Store(address, status)
+}
+
+// countPlacedActor counts the actor spawned on the address in its cached status until the activator publishes
+// its next status, so that the following placements do not all select the same activator
+func (r *Remote) countPlacedActor(address string) {
+	v, ok := r.activatorStatuses.Load(address)
+	if !ok {
+		return
+	}
+	status := proto.Clone(v.(*ActivatorStatusResponse)).(*ActivatorStatusResponse)
+	status.ActorCount++
+	r.activatorStatuses.CompareAndSwap(address, v, status)
+}
+
+func (r *Remote) placementCandidates(kind string, addresses []string, timeout time.Duration) []*ActivatorStatusResponse {
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		statuses = make(map[string]*ActivatorStatusResponse, len(addresses))
+	)
+
+	for _, address := range addresses {
+		if address == r.actorSystem.Address() {
+			statuses[address] = r.activatorStatus()
+			continue
+		}
+		if status, ok := r.activatorStatuses.Load(address); ok {
+			statuses[address] = status.(*ActivatorStatusResponse)
+			continue
+		}
+
+		wg.Add(1)
+		go func(address string) {
+			defer wg.Done()
+
+			status, err := r.ActivatorStatus(address, timeout)
+			if err != nil {
+				r.Logger().Warn("Failed to get activator status", slog.String("address", address), slog.Any("error", err))
+				return
+			}
+			status.Address = address
+
+			mu.Lock()
+			statuses[address] = status
+			mu.Unlock()
+		}(address)
+	}
+	wg.Wait()
+
+	// keep the candidate order stable for round-robin placement
+	candidates := make([]*ActivatorStatusResponse, 0, len(statuses))
+	for _, address := range addresses {
+		if status, ok := statuses[address]; ok && supportsKind(status, kind) {
+			candidates = append(candidates, status)
+		}
+	}
+
+	return candidates
+}
+
+func supportsKind(status *ActivatorStatusResponse, kind string) bool {
+	for _, k := range status.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+package remote
+
+import (
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeastActorsPlacement(t *testing.T) {
+	candidates := []*ActivatorStatusResponse{
+		{Address: "a", ActorCount: 3},
+		{Address: "b", ActorCount: 1},
+		{Address: "c", ActorCount: 2},
+	}
+
+	selected, ok := LeastActorsPlacement().Select("kind", candidates)
+	assert.True(t, ok)
+	assert.Equal(t, "b", selected.Address)
+
+	_, ok = LeastActorsPlacement().Select("kind", nil)
+	assert.False(t, ok)
+}
+
+func TestRoundRobinPlacement(t *testing.T) {
+	candidates := []*ActivatorStatusResponse{{Address: "a"}, {Address: "b"}}
+	policy := RoundRobinPlacement()
+
+	first, _ := policy.Select("kind", candidates)
+	second, _ := policy.Select("kind", candidates)
+	third, _ := policy.Select("kind", candidates)
+
+	assert.NotEqual(t, first.Address, second.Address)
+	assert.Equal(t, first.Address, third.Address)
+}
+
+func TestLabelPlacement(t *testing.T) {
+	candidates := []*ActivatorStatusResponse{
+		{Address: "a", ActorCount: 0, Labels: map[string]string{"zone": "us-1"}},
+		{Address: "b", ActorCount: 5, Labels: map[string]string{"zone": "eu-1", "role": "worker"}},
+		{Address: "c", ActorCount: 9, Labels: map[string]string{"zone": "eu-1", "role": "worker"}},
+	}
+
+	policy := LabelPlacement(map[string]string{"zone": "eu-1", "role": "worker"}, LeastActorsPlacement())
+	selected, ok := policy.Select("kind", candidates)
+	assert.True(t, ok)
+	assert.Equal(t, "b", selected.Address)
+
+	policy = LabelPlacement(map[string]string{"zone": "ap-1"}, LeastActorsPlacement())
+	_, ok = policy.Select("kind", candidates)
+	assert.False(t, ok)
+}
+
+func TestRemote_SpawnPlaced(t *testing.T) {
+	system := actor.NewActorSystem()
+	config := Configure("localhost", 0,
+		WithKinds(NewKind("someKind", actor.PropsFromFunc(func(ctx actor.Context) {}))),
+		WithLabels(map[string]string{"zone": "eu-1"}))
+	remote := NewRemote(system, config)
+	remote.Start()
+	defer remote.Shutdown(true)
+
+	status, err := remote.ActivatorStatus(system.Address(), time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"someKind"}, status.Kinds)
+	assert.Equal(t, "eu-1", status.Labels["zone"])
+	assert.Equal(t, int32(0), status.ActorCount)
+
+	res, err := remote.SpawnPlaced("placed", "someKind", LeastActorsPlacement(), time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, system.Address(), res.Pid.Address)
+
+	status, _ = remote.ActivatorStatus(system.Address(), time.Second)
+	assert.Equal(t, int32(1), status.ActorCount)
+
+	_, err = remote.SpawnPlaced("placed", "unknownKind", LeastActorsPlacement(), time.Second)
+	assert.Equal(t, ErrUnknownKind, err)
+
+	_, err = remote.SpawnNamed(system.Address(), "placed", "unknownKind", time.Second)
+	assert.Equal(t, ErrUnknownKind, err)
+
+	_, err = remote.SpawnPlaced("placed", "someKind", LabelPlacement(map[string]string{"zone": "us-1"}, LeastActorsPlacement()), time.Second)
+	assert.Equal(t, ErrNoPlacementCandidate, err)
+}
+
+func TestRemote_SpawnPlacedUsesThePublishedActivatorStatus(t *testing.T) {
+	system1 := actor.NewActorSystem()
+	remote1 := NewRemote(system1, Configure("localhost", 0, WithHeartbeatInterval(10*time.Millisecond)))
+	remote1.Start()
+	defer remote1.Shutdown(true)
+
+	system2 := actor.NewActorSystem()
+	remote2 := NewRemote(system2, Configure("localhost", 0,
+		WithKinds(NewKind("someKind", actor.PropsFromFunc(func(ctx actor.Context) {})))))
+	remote2.Start()
+
+	assert.Equal(t, []string{system1.Address()}, remote1.KnownActivators())
+
+	_, err := remote1.ActivatorStatus(system2.Address(), time.Second)
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return len(remote1.KnownActivators()) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	res, err := remote1.SpawnPlaced("placed", "someKind", LeastActorsPlacement(), time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, system2.Address(), res.Pid.Address)
+
+	remote2.Shutdown(true)
+	assert.Eventually(t, func() bool {
+		return len(remote1.KnownActivators()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestRemote_countPlacedActor(t *testing.T) {
+	remote := NewRemote(actor.NewActorSystem(), Configure("localhost", 0))
+	published := &ActivatorStatusResponse{Address: "remote:1", ActorCount: 3}
+	remote.onActivatorStatus("localhost:1", published)
+
+	// the placed actor is counted until the activator publishes its next status
+	remote.countPlacedActor("localhost:1")
+	status, _ := remote.activatorStatuses.Load("localhost:1")
+	assert.Equal(t, "localhost:1", status.(*ActivatorStatusResponse).Address)
+	assert.Equal(t, int32(4), status.(*ActivatorStatusResponse).ActorCount)
+	assert.Equal(t, int32(3), published.ActorCount)
+
+	remote.countPlacedActor("localhost:2")
+	_, ok := remote.activatorStatuses.Load("localhost:2")
+	assert.False(t, ok)
+}
@@ -1,17 +1,43 @@
 package remote
 
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+const (
+	// ProtoSerializerID is the stable ID of the built-in protobuf serializer
+	ProtoSerializerID int32 = 0
+	// JsonSerializerID is the stable ID of the built-in JSON serializer
+	JsonSerializerID int32 = 1
+)
+
 var (
+	// DefaultSerializerID is the serializer used by registries created through Configure
+	//
+	// Deprecated: use WithDefaultSerializer instead.
 	DefaultSerializerID int32
-	serializers         []Serializer
-)
 
-func init() {
-	RegisterSerializer(newProtoSerializer())
-	RegisterSerializer(newJsonSerializer())
-}
+	// legacySerializers backs the package level Serialize, Deserialize and RegisterSerializer functions
+	legacySerializers = NewSerializerRegistry()
+)
 
+// RegisterSerializer registers a serializer globally using the next free serializer ID.
+// Serializers registered this way are copied into every Config created afterwards.
+//
+// Deprecated: the assigned ID depends on registration order, use WithSerializer
+// to register a serializer with an explicit ID on a single Remote instead.
 func RegisterSerializer(serializer Serializer) {
-	serializers = append(serializers, serializer)
+	legacySerializers.mu.Lock()
+	defer legacySerializers.mu.Unlock()
+
+	id := int32(len(legacySerializers.serializers))
+	for legacySerializers.serializers[id] != nil {
+		id++
+	}
+	legacySerializers.serializers[id] = serializer
 }
 
 type Serializer interface {
@@ -20,20 +46,177 @@ type Serializer interface {
 	GetTypeName(msg interface{}) (string, error)
 }
 
+// Serialize serializes the message using the globally registered serializers
 func Serialize(message interface{}, serializerID int32) ([]byte, string, error) {
-	res, err := serializers[serializerID].Serialize(message)
+	return legacySerializers.Serialize(message, serializerID)
+}
+
+// Deserialize deserializes the message using the globally registered serializers
+func Deserialize(message []byte, typeName string, serializerID int32) (interface{}, error) {
+	return legacySerializers.Deserialize(message, typeName, serializerID)
+}
+
+// ErrUnknownSerializer is returned when a serializer ID has not been registered
+type ErrUnknownSerializer struct {
+	SerializerID int32
+}
+
+func (e *ErrUnknownSerializer) Error() string {
+	return fmt.Sprintf("unknown serializer id %d", e.SerializerID)
+}
+
+// SerializerRegistry maps stable serializer IDs to serializers and decides
+// which serializer is used for a given message type
+type SerializerRegistry struct {
+	mu          sync.RWMutex
+	serializers map[int32]Serializer
+	types       map[reflect.Type]int32
+	defaultID   int32
+}
+
+// NewSerializerRegistry creates a registry containing the protobuf and JSON serializers
+func NewSerializerRegistry() *SerializerRegistry {
+	return &SerializerRegistry{
+		serializers: map[int32]Serializer{
+			ProtoSerializerID: newProtoSerializer(),
+			JsonSerializerID:  newJsonSerializer(),
+		},
+		types:     make(map[reflect.Type]int32),
+		defaultID: ProtoSerializerID,
+	}
+}
+
+// Register adds a serializer under the given ID, the ID must not already be in use
+func (r *SerializerRegistry) Register(serializerID int32, serializer Serializer) error {
+	if serializerID < 0 {
+		return fmt.Errorf("serializer id %d must not be negative", serializerID)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.serializers[serializerID]; ok {
+		return fmt.Errorf("serializer id %d is already registered", serializerID)
+	}
+	r.serializers[serializerID] = serializer
+
+	return nil
+}
+
+// RegisterType makes the registry use the given serializer for all messages of the same type as message
+func (r *SerializerRegistry) RegisterType(message interface{}, serializerID int32) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.serializers[serializerID]; !ok {
+		return &ErrUnknownSerializer{SerializerID: serializerID}
+	}
+	r.types[reflect.TypeOf(message)] = serializerID
+
+	return nil
+}
+
+// SetDefault sets the serializer used for message types without an explicit serializer
+func (r *SerializerRegistry) SetDefault(serializerID int32) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.serializers[serializerID]; !ok {
+		return &ErrUnknownSerializer{SerializerID: serializerID}
+	}
+	r.defaultID = serializerID
+
+	return nil
+}
+
+// Default returns the ID of the serializer used for message types without an explicit serializer
+func (r *SerializerRegistry) Default() int32 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.defaultID
+}
+
+// Get returns the serializer registered under the given ID
+func (r *SerializerRegistry) Get(serializerID int32) (Serializer, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s, ok := r.serializers[serializerID]
+	return s, ok
+}
+
+// IDs returns the sorted IDs of all registered serializers
+func (r *SerializerRegistry) IDs() []int32 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := make([]int32, 0, len(r.serializers))
+	for id := range r.serializers {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return ids
+}
+
+// SerializerIDFor returns the ID of the serializer to use for the given message
+func (r *SerializerRegistry) SerializerIDFor(message interface{}) int32 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if id, ok := r.types[reflect.TypeOf(message)]; ok {
+		return id
+	}
+
+	return r.defaultID
+}
+
+// Serialize serializes the message with the given serializer and returns the data and the type name
+func (r *SerializerRegistry) Serialize(message interface{}, serializerID int32) ([]byte, string, error) {
+	serializer, ok := r.Get(serializerID)
+	if !ok {
+		return nil, "", &ErrUnknownSerializer{SerializerID: serializerID}
+	}
+
+	res, err := serializer.Serialize(message)
 	if err != nil {
 		return nil, "", err
 	}
-	typeName, err := serializers[serializerID].GetTypeName(message)
+	typeName, err := serializer.GetTypeName(message)
 	if err != nil {
 		return nil, "", err
 	}
 	return res, typeName, nil
 }
 
-func Deserialize(message []byte, typeName string, serializerID int32) (interface{}, error) {
-	return serializers[serializerID].Deserialize(typeName, message)
+// Deserialize deserializes the data with the given serializer
+func (r *SerializerRegistry) Deserialize(message []byte, typeName string, serializerID int32) (interface{}, error) {
+	serializer, ok := r.Get(serializerID)
+	if !ok {
+		return nil, &ErrUnknownSerializer{SerializerID: serializerID}
+	}
+
+	return serializer.Deserialize(typeName, message)
+}
+
+func (r *SerializerRegistry) clone() *SerializerRegistry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c := &SerializerRegistry{
+		serializers: make(map[int32]Serializer, len(r.serializers)),
+		types:       make(map[reflect.Type]int32, len(r.types)),
+		defaultID:   r.defaultID,
+	}
+	for id, s := range r.serializers {
+		c.serializers[id] = s
+	}
+	for t, id := range r.types {
+		c.types[t] = id
+	}
+
+	return c
 }
 
 // RootSerializable is the root level in-process representation of a message
@@ -55,3 +55,120 @@ func TestProtobufSerializer_Serialize_PID(t *testing.T) {
 	assert.Equal(t, "actor.PID", typeName)
 	assert.True(t, m.Equal(typed))
 }
+
+type testSerializer struct {
+	protoSerializer
+}
+
+func TestSerializerRegistry_builtin_ids_are_stable(t *testing.T) {
+	r := NewSerializerRegistry()
+
+	assert.Equal(t, []int32{ProtoSerializerID, JsonSerializerID}, r.IDs())
+	assert.Equal(t, ProtoSerializerID, r.Default())
+}
+
+func TestSerializerRegistry_Register_rejects_duplicate_id(t *testing.T) {
+	r := NewSerializerRegistry()
+
+	assert.NoError(t, r.Register(10, &testSerializer{}))
+	assert.Error(t, r.Register(10, &testSerializer{}))
+	assert.Error(t, r.Register(ProtoSerializerID, &testSerializer{}))
+	assert.Error(t, r.Register(-1, &testSerializer{}))
+	assert.Equal(t, []int32{ProtoSerializerID, JsonSerializerID, 10}, r.IDs())
+}
+
+func TestSerializerRegistry_SerializerIDFor(t *testing.T) {
+	r := NewSerializerRegistry()
+	assert.NoError(t, r.Register(10, &testSerializer{}))
+	assert.NoError(t, r.RegisterType(&actor.PID{}, 10))
+
+	assert.Equal(t, int32(10), r.SerializerIDFor(&actor.PID{Id: "foo"}))
+	assert.Equal(t, ProtoSerializerID, r.SerializerIDFor(&ActorPidRequest{}))
+
+	var unknown *ErrUnknownSerializer
+	assert.ErrorAs(t, r.RegisterType(&ActorPidRequest{}, 42), &unknown)
+	assert.ErrorAs(t, r.SetDefault(42), &unknown)
+}
+
+func TestSerializerRegistry_round_trip(t *testing.T) {
+	r := NewSerializerRegistry()
+	assert.NoError(t, r.Register(10, &testSerializer{}))
+
+	m := &actor.PID{Address: "localhost:1234", Id: "foo"}
+	b, typeName, err := r.Serialize(m, 10)
+	assert.NoError(t, err)
+
+	res, err := r.Deserialize(b, typeName, 10)
+	assert.NoError(t, err)
+	assert.True(t, m.Equal(res.(*actor.PID)))
+
+	var unknown *ErrUnknownSerializer
+	_, err = r.Deserialize(b, typeName, 42)
+	assert.ErrorAs(t, err, &unknown)
+}
+
+func TestConfigure_WithSerializer(t *testing.T) {
+	c := Configure("localhost", 0,
+		WithSerializer(10, &testSerializer{}),
+		WithSerializerFor(&actor.PID{}, 10),
+		WithDefaultSerializer(JsonSerializerID))
+
+	assert.Equal(t, int32(10), c.Serializers.SerializerIDFor(&actor.PID{}))
+	assert.Equal(t, JsonSerializerID, c.Serializers.SerializerIDFor(&ActorPidRequest{}))
+
+	assert.NoError(t, c.Validate())
+
+	// registries are not shared between configs
+	assert.Equal(t, []int32{ProtoSerializerID, JsonSerializerID}, Configure("localhost", 0).Serializers.IDs())
+}
+
+func TestConfigure_WithSerializerErrors(t *testing.T) {
+	c := Configure("localhost", 0,
+		WithSerializer(ProtoSerializerID, &testSerializer{}),
+		WithDefaultSerializer(42))
+
+	err := c.Validate()
+	assert.Error(t, err)
+
+	var unknown *ErrUnknownSerializer
+	assert.ErrorAs(t, err, &unknown)
+
+	// the remote is not started with an invalid config
+	r := NewRemote(actor.NewActorSystem(), c)
+	err = r.TryStart()
+	assert.ErrorAs(t, err, &unknown)
+}
+
+type recordingStream struct {
+	Remoting_ReceiveClient
+	sent []*RemoteMessage
+}
+
+func (s *recordingStream) Send(msg *RemoteMessage) error {
+	s.sent = append(s.sent, msg)
+	return nil
+}
+
+func TestEndpointWriter_sendEnvelopes_dead_letters_messages_the_peer_cannot_deserialize(t *testing.T) {
+	system := actor.NewActorSystem()
+	remote := NewRemote(system, Configure("localhost", 0))
+	stream := &recordingStream{}
+	writer := &endpointWriter{address: "localhost:1", remote: remote, health: remote.endpointHealth.get("localhost:1"), stream: stream}
+	writer.setPeerSerializers([]int32{JsonSerializerID})
+
+	deadLetters := make(chan *actor.DeadLetterEvent, 1)
+	sub := system.EventStream.Subscribe(func(evt interface{}) {
+		if e, ok := evt.(*actor.DeadLetterEvent); ok {
+			deadLetters <- e
+		}
+	})
+	defer system.EventStream.Unsubscribe(sub)
+
+	target := actor.NewPID("localhost:1", "target")
+	writer.sendEnvelopes([]interface{}{
+		&remoteDeliver{message: &ActorPidRequest{Name: "proto"}, target: target, serializerID: -1},
+	}, nil)
+
+	assert.Len(t, deadLetters, 1)
+	assert.Empty(t, stream.sent)
+}
@@ -5,11 +5,14 @@ import (
 	"io/ioutil"
 	"log/slog"
 	"net"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/asynkron/protoactor-go/extensions"
 
 	"github.com/asynkron/protoactor-go/actor"
+	"go.opentelemetry.io/otel/metric"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/grpclog"
 )
@@ -25,6 +28,12 @@ type Remote struct {
 	kinds        map[string]*actor.Props
 	activatorPid *actor.PID
 	blocklist    *BlockList
+
+	endpointHealth      *endpointHealthRegistry
+	metricsRegistration metric.Registration
+
+	activatorActors   atomic.Int32
+	activatorStatuses sync.Map
 }
 
 func NewRemote(actorSystem *actor.ActorSystem, config *Config) *Remote {
@@ -33,6 +42,8 @@ func NewRemote(actorSystem *actor.ActorSystem, config *Config) *Remote {
 		config:      config,
 		kinds:       make(map[string]*actor.Props),
 		blocklist:   NewBlockList(),
+
+		endpointHealth: &endpointHealthRegistry{},
 	}
 	for k, v := range config.Kinds {
 		r.kinds[k] = v
@@ -56,12 +67,27 @@ func (r *Remote) ExtensionID() extensions.ExtensionID {
 
 func (r *Remote) BlockList() *BlockList { return r.blocklist }
 
-// Start the remote server
+// Serializers returns the serializer registry of this remote
+func (r *Remote) Serializers() *SerializerRegistry { return r.config.Serializers }
+
+// Start the remote server, it panics if the config is invalid or the address can not be listened on,
+// use TryStart to handle these errors
 func (r *Remote) Start() {
+	if err := r.TryStart(); err != nil {
+		panic(err)
+	}
+}
+
+// TryStart starts the remote server, it returns the errors of the config without starting, see Config.Validate
+func (r *Remote) TryStart() error {
+	if err := r.config.Validate(); err != nil {
+		return fmt.Errorf("invalid remote config: %w", err)
+	}
+
 	grpclog.SetLoggerV2(grpclog.NewLoggerV2(ioutil.Discard, ioutil.Discard, ioutil.Discard))
 	lis, err := net.Listen("tcp", r.config.Address())
 	if err != nil {
-		panic(fmt.Errorf("failed to listen: %v", err))
+		return fmt.Errorf("failed to listen: %w", err)
 	}
 
 	var address string
@@ -77,15 +103,20 @@ func (r *Remote) Start() {
 
 	r.edpManager = newEndpointManager(r)
 	r.edpManager.start()
+	r.registerEndpointMetrics()
 
 	r.s = grpc.NewServer(r.config.ServerOptions...)
 	r.edpReader = newEndpointReader(r)
 	RegisterRemotingServer(r.s, r.edpReader)
 	r.Logger().Info("Starting Proto.Actor server", slog.String("address", address))
 	go r.s.Serve(lis)
+
+	return nil
 }
 
 func (r *Remote) Shutdown(graceful bool) {
+	r.unregisterEndpointMetrics()
+
 	if graceful {
 		// TODO: need more graceful
 		r.edpReader.suspend(true)